@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log"
 
 	"github.com/sangtandoan/subscription_tracker/internal/authenticator"
 	"github.com/sangtandoan/subscription_tracker/internal/chrono"
@@ -9,6 +11,7 @@ import (
 	"github.com/sangtandoan/subscription_tracker/internal/db"
 	"github.com/sangtandoan/subscription_tracker/internal/handler"
 	"github.com/sangtandoan/subscription_tracker/internal/pkg/mailer"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/storage"
 	"github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
 	"github.com/sangtandoan/subscription_tracker/internal/repo"
 	"github.com/sangtandoan/subscription_tracker/internal/router"
@@ -62,21 +65,32 @@ func main() {
 		panic(err)
 	}
 
-	service := service.NewService(repo, authenticator, cfg)
-
 	validator := validator.NewAppValidator()
 
+	mailer := mailer.NewSMTPMailer(cfg.Mailer)
+
+	background := chrono.NewBackground()
+
+	storage := storage.NewLocalStorage(cfg.Attachment.Dir)
+
+	service := service.NewService(repo, authenticator, cfg, validator, mailer, background, storage)
+
+	// services of the built-in catalog missing from the database are added on every start,
+	// unless an admin deleted them
+	num, err := service.Catalog.SeedCatalog(context.Background())
+	if err != nil {
+		log.Println("could not seed catalog:", err)
+	} else {
+		fmt.Println("Seeded catalog services:", num)
+	}
+
 	handler := handler.NewHandler(service, validator)
 
 	router := router.NewRouter(handler, authenticator)
 
-	mailer := mailer.NewSMTPMailer(cfg.Mailer)
-
-	crono := chrono.NewChrono(repo, mailer)
+	crono := chrono.NewChrono(repo, service.Budget, mailer, storage, cfg.Chrono)
 	go crono.ScheduleDailyTask(8, 00)
 
-	background := chrono.NewBackground()
-
 	srv := server.NewServer(cfg.Server.Addr, router.Setup(), background)
 	srv.Run()
 }
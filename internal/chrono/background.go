@@ -12,7 +12,8 @@ type Background struct {
 
 func NewBackground() *Background {
 	return &Background{
-		Wg: &sync.WaitGroup{},
+		Wg:     &sync.WaitGroup{},
+		logger: slog.Default(),
 	}
 }
 
@@ -2,30 +2,68 @@ package chrono
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
 	"time"
 
-	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/config"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
 	"github.com/sangtandoan/subscription_tracker/internal/pkg/mailer"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/money"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/storage"
 	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
 )
 
-var days = [4]int{7, 5, 3, 1}
+// budgetThresholds are the percents of a budget alerted once per month, in ascending order
+var budgetThresholds = []int{80, 100}
 
 type Chrono interface {
 	CheckSubscriptionDailyToSendEmail()
 }
 
 type chrono struct {
-	subscriptionRepo repo.SubscriptionRepo
-	userRepo         repo.UserRepo
-	mailer           mailer.Mailer
+	subscriptionRepo  repo.SubscriptionRepo
+	userRepo          repo.UserRepo
+	reminderLogRepo   repo.ReminderLogRepo
+	priceRepo         repo.SubscriptionPriceRepo
+	chargeRepo        repo.ChargeRepo
+	budgetRepo        repo.BudgetRepo
+	budgetService     service.BudgetService
+	attachmentRepo    repo.AttachmentRepo
+	paymentMethodRepo repo.PaymentMethodRepo
+	transaction       repo.TransactionManager
+	mailer            mailer.Mailer
+	storage           storage.Storage
+	config            *config.ChronoConfig
 }
 
-func NewChrono(repo *repo.Repo, mailer mailer.Mailer) *chrono {
-	return &chrono{subscriptionRepo: repo.Subscription, userRepo: repo.User, mailer: mailer}
+func NewChrono(
+	repo *repo.Repo,
+	budgetService service.BudgetService,
+	mailer mailer.Mailer,
+	storage storage.Storage,
+	config *config.ChronoConfig,
+) *chrono {
+	return &chrono{
+		subscriptionRepo:  repo.Subscription,
+		userRepo:          repo.User,
+		reminderLogRepo:   repo.ReminderLog,
+		priceRepo:         repo.Price,
+		chargeRepo:        repo.Charge,
+		budgetRepo:        repo.Budget,
+		budgetService:     budgetService,
+		attachmentRepo:    repo.Attachment,
+		paymentMethodRepo: repo.PaymentMethod,
+		transaction:       repo.Transaction,
+		mailer:            mailer,
+		storage:           storage,
+		config:            config,
+	}
 }
 
 func (c *chrono) ScheduleDailyTask(targetHour, targetMinute int) {
@@ -52,16 +90,23 @@ func (c *chrono) ScheduleDailyTask(targetHour, targetMinute int) {
 		time.Sleep(waitDuration)
 
 		c.CheckSubscriptionsDailyToSendEmail()
+		// cancelled subscriptions must be ended before renewing,
+		// so they are never rolled forward
+		c.CheckSubscriptionsDailyToEndCancelled()
 		c.CheckSubscriptionsDailyToUpdateStartDate()
+		c.CheckBudgetsDailyToSendAlert()
+		c.CheckPaymentMethodsDailyToSendExpiryWarning()
+		c.PurgeDeletedSubscriptionsDaily()
+		c.PurgeOrphanAttachmentsDaily()
 	}
 }
 
 func (c *chrono) CheckSubscriptionsDailyToSendEmail() {
-	errsCh := make(chan error, len(days))
+	errsCh := make(chan error, len(c.config.ReminderDays))
 
 	wg := &sync.WaitGroup{}
 
-	for _, num := range days {
+	for _, num := range c.config.ReminderDays {
 		ctx := context.Background()
 		wg.Add(1)
 		go c.querySubsAtSpecifyNumDays(ctx, wg, num, errsCh)
@@ -71,6 +116,212 @@ func (c *chrono) CheckSubscriptionsDailyToSendEmail() {
 	fmt.Println("wg wait done")
 }
 
+func (c *chrono) CheckSubscriptionsDailyToEndCancelled() {
+	num, err := c.subscriptionRepo.EndCancelledSubscriptions(context.Background())
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	fmt.Println("Ended cancelled subscriptions:", num)
+}
+
+func (c *chrono) PurgeDeletedSubscriptionsDaily() {
+	before := time.Now().AddDate(0, 0, -c.config.TrashRetentionDays)
+
+	num, err := c.subscriptionRepo.PurgeDeletedSubscriptions(context.Background(), before)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	fmt.Println("Purged deleted subscriptions:", num)
+}
+
+// PurgeOrphanAttachmentsDaily deletes the attachments of purged subscriptions with their content,
+// an attachment whose content could not be deleted is retried the next day
+func (c *chrono) PurgeOrphanAttachmentsDaily() {
+	ctx := context.Background()
+
+	attachments, err := c.attachmentRepo.GetOrphanAttachments(ctx)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	var num int
+	for _, attachment := range attachments {
+		err := c.storage.Delete(ctx, attachment.StorageKey)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		err = c.attachmentRepo.DeleteAttachment(ctx, attachment.ID, attachment.UserID)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		num++
+	}
+
+	fmt.Println("Purged orphan attachments:", num)
+}
+
+func (c *chrono) CheckBudgetsDailyToSendAlert() {
+	ctx := context.Background()
+
+	userIDs, err := c.budgetRepo.GetBudgetUserIDs(ctx)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		err := c.sendBudgetAlerts(ctx, userID)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// sendBudgetAlerts emails the user for every budget whose projected spend of the month
+// crossed a threshold not alerted yet, a budget crossing both thresholds at once only gets the highest
+func (c *chrono) sendBudgetAlerts(ctx context.Context, userID uuid.UUID) error {
+	statuses, err := c.budgetService.GetBudgets(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var user *models.User
+	for _, status := range statuses {
+		if status.UsedPercent < budgetThresholds[0] {
+			continue
+		}
+
+		if user == nil {
+			user, err = c.userRepo.GetUserByID(ctx, userID)
+			if err != nil {
+				return err
+			}
+		}
+
+		thresholds, err := c.recordBudgetAlerts(ctx, status)
+		if err != nil {
+			return err
+		}
+
+		if len(thresholds) == 0 {
+			continue
+		}
+
+		fmt.Printf("sending budget alert to %s\n", user.Email)
+		threshold := thresholds[len(thresholds)-1]
+		err = c.mailer.SendWithRetry(newBudgetAlertRequest(status, user.Email, threshold), 3)
+		if err != nil {
+			log.Println(err)
+			// the thresholds are alerted again the next day
+			c.deleteBudgetAlerts(ctx, status, thresholds)
+		}
+	}
+
+	return nil
+}
+
+// recordBudgetAlerts records every threshold crossed by the budget this month
+// and returns the ones which were not alerted yet, in ascending order.
+// Alerts are recorded before the email is sent so a threshold is never alerted twice,
+// they are deleted again when the email could not be sent
+func (c *chrono) recordBudgetAlerts(ctx context.Context, status *service.BudgetStatus) ([]int, error) {
+	var alerts []int
+	for _, threshold := range budgetThresholds {
+		if status.UsedPercent < threshold {
+			break
+		}
+
+		created, err := c.budgetRepo.CreateBudgetAlert(ctx, status.Budget.ID, status.MonthStart, threshold)
+		if err != nil {
+			return nil, err
+		}
+
+		if created {
+			alerts = append(alerts, threshold)
+		}
+	}
+
+	return alerts, nil
+}
+
+func (c *chrono) deleteBudgetAlerts(ctx context.Context, status *service.BudgetStatus, thresholds []int) {
+	for _, threshold := range thresholds {
+		err := c.budgetRepo.DeleteBudgetAlert(ctx, status.Budget.ID, status.MonthStart, threshold)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// CheckPaymentMethodsDailyToSendExpiryWarning warns the owner of every payment method expiring
+// within a month, once per expiry, with the subscriptions which will fail to renew
+func (c *chrono) CheckPaymentMethodsDailyToSendExpiryWarning() {
+	ctx := context.Background()
+	now := time.Now()
+
+	methods, err := c.paymentMethodRepo.GetExpiringPaymentMethods(ctx, now, now.AddDate(0, 1, 0))
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, method := range methods {
+		err := c.sendCardExpiryWarning(ctx, method)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// sendCardExpiryWarning emails the owner of a payment method about its expiry.
+// A payment method paying no subscription is checked again the next day,
+// in case a subscription is linked to it before it expires
+func (c *chrono) sendCardExpiryWarning(ctx context.Context, method *models.PaymentMethod) error {
+	subs, err := c.subscriptionRepo.GetPaymentMethodSubscriptions(ctx, method.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(subs) == 0 {
+		return nil
+	}
+
+	user, err := c.userRepo.GetUserByID(ctx, method.UserID)
+	if err != nil {
+		return err
+	}
+
+	// the warning is recorded before the email is sent so it is never sent twice,
+	// and forgotten again when the email could not be sent so it is retried the next day
+	marked, err := c.paymentMethodRepo.MarkPaymentMethodReminded(ctx, method.ID)
+	if err != nil {
+		return err
+	}
+
+	if !marked {
+		return nil
+	}
+
+	fmt.Printf("sending card expiry warning to %s\n", user.Email)
+	err = c.mailer.SendWithRetry(newCardExpiryRequest(method, subs, user.Email), 3)
+	if err != nil {
+		if unmarkErr := c.paymentMethodRepo.UnmarkPaymentMethodReminded(ctx, method.ID); unmarkErr != nil {
+			log.Println(unmarkErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (c *chrono) CheckSubscriptionsDailyToUpdateStartDate() {
 	wg := &sync.WaitGroup{}
 
@@ -105,23 +356,111 @@ func (c *chrono) generateUpdateSubscriptionWorker(
 	done func(),
 ) {
 	for job := range jobs {
-		job.StartDate = job.EndDate
-		duration, err := enums.ParseString2Duration(job.Duration)
+		err := c.renewSubscription(ctx, job)
 		if err != nil {
-			log.Fatal(err)
+			log.Println(err)
+		} else {
+			fmt.Println("Updated subscription with ID:", job.ID)
 		}
-		job.EndDate = duration.AddDurationToTime(job.StartDate)
+		done()
+	}
+}
 
-		arg := repo.UpdateSubscriptionStartAndEndDateParams{
-			ID:        job.ID,
-			StartDate: job.StartDate,
-			EndDate:   job.EndDate,
+// renewSubscription rolls a subscription forward until its current period includes now,
+// so periods missed by a failed run are caught up by the next one
+func (c *chrono) renewSubscription(ctx context.Context, job *repo.SubscriptionRow) error {
+	now := time.Now()
+	for !job.EndDate.After(now) {
+		var err error
+		if job.InTrial() {
+			err = c.convertTrialSubscription(ctx, job)
+		} else {
+			err = c.renewPeriod(ctx, job)
 		}
-		c.subscriptionRepo.UpdateSubscriptionStartAndEndDate(ctx, &arg)
+		if err != nil {
+			return err
+		}
+	}
 
-		fmt.Println("Updated subscription with ID:", job.ID)
-		done()
+	return nil
+}
+
+// renewPeriod books the charge of the period following the current one and moves the subscription to it,
+// both in one transaction so a booked period is always the current one
+func (c *chrono) renewPeriod(ctx context.Context, job *repo.SubscriptionRow) error {
+	// renewals are chained from the previous end date,
+	// the anchor day keeps month end renewals from drifting
+	startDate := job.EndDate
+	endDate := job.Duration.AddDurationToTimeWithAnchor(startDate, job.BillingAnchorDay)
+
+	// a price changed for a later renewal takes effect with the period starting at its date
+	err := c.applyPriceAt(ctx, job, startDate)
+	if err != nil {
+		return err
+	}
+
+	err = c.transaction.WithTx(ctx, func(txContext context.Context) error {
+		err := c.bookCharge(txContext, job, startDate, endDate)
+		if err != nil {
+			return err
+		}
+
+		return c.subscriptionRepo.UpdateSubscriptionStartAndEndDate(
+			txContext,
+			&repo.UpdateSubscriptionStartAndEndDateParams{
+				ID:        job.ID,
+				StartDate: startDate,
+				EndDate:   endDate,
+				Amount:    job.Amount,
+				Currency:  job.Currency,
+			},
+		)
+	})
+	if err != nil {
+		return err
+	}
+
+	job.StartDate, job.EndDate = startDate, endDate
+
+	return nil
+}
+
+// convertTrialSubscription switches a subscription whose trial ended to its paid billing cycle,
+// the first paid period starts at the trial end date
+func (c *chrono) convertTrialSubscription(ctx context.Context, job *repo.SubscriptionRow) error {
+	startDate := *job.TrialEndDate
+	endDate := job.Duration.AddDurationToTimeWithAnchor(startDate, job.BillingAnchorDay)
+
+	if job.PostTrialAmount != nil {
+		job.Amount = job.PostTrialAmount
+	}
+
+	err := c.applyPriceAt(ctx, job, startDate)
+	if err != nil {
+		return err
+	}
+
+	err = c.transaction.WithTx(ctx, func(txContext context.Context) error {
+		err := c.bookCharge(txContext, job, startDate, endDate)
+		if err != nil {
+			return err
+		}
+
+		return c.subscriptionRepo.ConvertTrialSubscription(txContext, &repo.ConvertTrialSubscriptionParams{
+			ID:        job.ID,
+			StartDate: startDate,
+			EndDate:   endDate,
+			Amount:    job.Amount,
+		})
+	})
+	if err != nil {
+		return err
 	}
+
+	job.StartDate, job.EndDate = startDate, endDate
+	fmt.Println("Converted trial subscription with ID:", job.ID)
+
+	return nil
 }
 
 func (c *chrono) querySubsAtSpecifyNumDays(
@@ -206,22 +545,242 @@ func (c *chrono) sendEmail(
 		}
 
 		fmt.Printf("sending email to %s\n", user.Email)
-		sendEmailReq := mailer.SendRequest{
-			To:       []string{user.Email},
-			Template: mailer.RemindTemplate,
-			Data: mailer.RemindData{
-				Name:        job.Name,
-				NumDays:     numDays,
-				Email:       user.Email,
-				RenewalDate: job.EndDate,
-			},
-		}
+		sendEmailReq := newRemindRequest(
+			job,
+			user.Email,
+			numDays,
+			c.getPriceAt(ctx, job, job.StartDate),
+			c.getPriceAt(ctx, job, job.EndDate),
+		)
 
-		err = c.mailer.SendWithRetry(&sendEmailReq, 3)
+		err = c.mailer.SendWithRetry(sendEmailReq, 3)
 		if err != nil {
 			errsCh <- err
+		} else {
+			c.logReminder(ctx, job, numDays, reminderKind(sendEmailReq.Template))
 		}
 
 		done <- 1
 	}
 }
+
+// logReminder keeps the history of sent reminders,
+// a failure is only logged since the email is already sent
+func (c *chrono) logReminder(
+	ctx context.Context,
+	job *repo.SubscriptionRow,
+	numDays int,
+	kind string,
+) {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	err = c.reminderLogRepo.CreateReminderLog(ctx, &repo.CreateReminderLogParams{
+		ID:               id,
+		UserID:           job.UserID,
+		SubscriptionID:   job.ID,
+		SubscriptionName: job.Name,
+		Kind:             kind,
+		DaysBefore:       numDays,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// applyPriceAt sets the amount of job to the price effective at a date,
+// the current price is kept when there is no history
+func (c *chrono) applyPriceAt(ctx context.Context, job *repo.SubscriptionRow, at time.Time) error {
+	price, err := c.priceRepo.GetSubscriptionPriceAt(ctx, job.ID, at)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	job.Amount, job.Currency = &price.Amount, &price.Currency
+
+	return nil
+}
+
+// bookCharge records the charge of the period starting at start in the ledger at the amount of job
+func (c *chrono) bookCharge(
+	ctx context.Context,
+	job *repo.SubscriptionRow,
+	start, end time.Time,
+) error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.chargeRepo.CreateCharge(ctx, &repo.CreateChargeParams{
+		ID:               id,
+		UserID:           job.UserID,
+		SubscriptionID:   job.ID,
+		SubscriptionName: job.Name,
+		PeriodStart:      start,
+		PeriodEnd:        end,
+		Amount:           job.Amount,
+		Currency:         job.Currency,
+	})
+
+	return err
+}
+
+func newBudgetAlertRequest(
+	status *service.BudgetStatus,
+	email string,
+	threshold int,
+) *mailer.SendRequest {
+	budget := status.Budget
+
+	scope := "all"
+	if budget.Duration != nil {
+		scope = budget.Duration.String()
+	}
+
+	remaining := status.Remaining
+	if remaining < 0 {
+		remaining = -remaining
+	}
+
+	return &mailer.SendRequest{
+		To:       []string{email},
+		Template: mailer.BudgetAlertTemplate,
+		Data: mailer.BudgetAlertData{
+			Email:     email,
+			Month:     status.MonthStart.Format("January 2006"),
+			Scope:     scope,
+			Budget:    money.Format(budget.Amount, budget.Currency),
+			Committed: money.Format(status.Committed, budget.Currency),
+			Remaining: money.Format(remaining, budget.Currency),
+			Overspent: status.Remaining < 0,
+			Threshold: threshold,
+		},
+	}
+}
+
+func newCardExpiryRequest(
+	method *models.PaymentMethod,
+	subs []*repo.SubscriptionRow,
+	email string,
+) *mailer.SendRequest {
+	subscriptions := make([]mailer.CardExpirySubscription, 0, len(subs))
+	for _, sub := range subs {
+		subscriptions = append(subscriptions, mailer.CardExpirySubscription{
+			Name:        sub.Name,
+			NextRenewal: sub.EndDate.Format("2006-01-02"),
+		})
+	}
+
+	return &mailer.SendRequest{
+		To:       []string{email},
+		Template: mailer.CardExpiryTemplate,
+		Data: mailer.CardExpiryData{
+			Email:         email,
+			Card:          fmt.Sprintf("%s (%s ending in %s)", method.Label, method.Brand, method.Last4),
+			Expiry:        fmt.Sprintf("%02d/%d", method.ExpiryMonth, method.ExpiryYear),
+			ExpiresAt:     method.ExpiresAt(),
+			Subscriptions: subscriptions,
+		},
+	}
+}
+
+// getPriceAt returns the price of the period starting at a date, the current period for its start date
+// and the renewal for its end date, it is nil during a trial or when the price is unknown
+func (c *chrono) getPriceAt(
+	ctx context.Context,
+	job *repo.SubscriptionRow,
+	at time.Time,
+) *models.SubscriptionPrice {
+	if job.InTrial() {
+		return nil
+	}
+
+	price, err := c.priceRepo.GetSubscriptionPriceAt(ctx, job.ID, at)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Println(err)
+		}
+		return nil
+	}
+
+	return price
+}
+
+func reminderKind(template mailer.MailTemplateOption) string {
+	switch template {
+	case mailer.TrialRemindTemplate:
+		return models.ReminderKindTrial
+	case mailer.PriceIncreaseTemplate:
+		return models.ReminderKindPriceIncrease
+	}
+
+	return models.ReminderKindRenewal
+}
+
+// isPriceIncrease tells if the renewal is charged more than previous in the same currency
+func isPriceIncrease(previous, renewal *models.SubscriptionPrice) bool {
+	return previous != nil && renewal != nil &&
+		previous.Currency == renewal.Currency && renewal.Amount > previous.Amount
+}
+
+// newRemindRequest warns before a trial converts instead of before a renewal
+// when the subscription is still in its free trial,
+// and warns about the new price when the renewal is charged more than previous
+func newRemindRequest(
+	job *repo.SubscriptionRow,
+	email string,
+	numDays int,
+	previous, renewal *models.SubscriptionPrice,
+) *mailer.SendRequest {
+	if job.InTrial() {
+		data := mailer.TrialRemindData{
+			Name:         job.Name,
+			NumDays:      numDays,
+			Email:        email,
+			TrialEndDate: job.EndDate,
+		}
+
+		if job.PostTrialAmount != nil && job.Currency != nil {
+			data.PostTrialPrice = money.Format(*job.PostTrialAmount, *job.Currency)
+		}
+
+		return &mailer.SendRequest{
+			To:       []string{email},
+			Template: mailer.TrialRemindTemplate,
+			Data:     data,
+		}
+	}
+
+	if isPriceIncrease(previous, renewal) {
+		return &mailer.SendRequest{
+			To:       []string{email},
+			Template: mailer.PriceIncreaseTemplate,
+			Data: mailer.PriceIncreaseData{
+				Name:        job.Name,
+				NumDays:     numDays,
+				Email:       email,
+				RenewalDate: job.EndDate,
+				OldPrice:    money.Format(previous.Amount, previous.Currency),
+				NewPrice:    money.Format(renewal.Amount, renewal.Currency),
+			},
+		}
+	}
+
+	return &mailer.SendRequest{
+		To:       []string{email},
+		Template: mailer.RemindTemplate,
+		Data: mailer.RemindData{
+			Name:        job.Name,
+			NumDays:     numDays,
+			Email:       email,
+			RenewalDate: job.EndDate,
+		},
+	}
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 	"github.com/labstack/gommon/log"
@@ -16,6 +18,9 @@ type Config struct {
 	Authenticator *AuthenticatorConfig
 	GoogleOAuth   *oauth2.Config
 	Mailer        *MailerConfig
+	Chrono        *ChronoConfig
+	Export        *ExportConfig
+	Attachment    *AttachmentConfig
 }
 
 type DBConfig struct {
@@ -39,6 +44,31 @@ type MailerConfig struct {
 	Port     int
 }
 
+type ChronoConfig struct {
+	// reminders are emailed and shown by calendar feeds this many days before a renewal
+	ReminderDays []int
+	// subscriptions in trash longer than this are purged
+	TrashRetentionDays int
+}
+
+type ExportConfig struct {
+	// BaseURL of the API, download links of exports are sent by email under it
+	BaseURL string
+	// Dir keeps the exports generated in background until they expire
+	Dir string
+	// exports of users with more subscriptions than this are generated in background
+	SyncLimit   int
+	ExpiryHours int
+}
+
+type AttachmentConfig struct {
+	// Dir keeps the attached files of the local storage
+	Dir string
+	// MaxFileSize is the biggest file accepted and UserQuota the total size of the files of a user, in bytes
+	MaxFileSize int64
+	UserQuota   int64
+}
+
 type AuthenticatorConfig struct {
 	SecretKey   string
 	TokenExpiry string
@@ -89,6 +119,24 @@ func LoadConfig() (*Config, error) {
 		Endpoint:     google.Endpoint,
 	}
 
+	chronoConfig := &ChronoConfig{
+		ReminderDays:       getEnvAsIntSlice("REMINDER_DAYS", []int{7, 5, 3, 1}),
+		TrashRetentionDays: getEnvAsInt("TRASH_RETENTION_DAYS", 30),
+	}
+
+	exportConfig := &ExportConfig{
+		BaseURL:     getEnv("EXPORT_BASE_URL", "http://localhost:8080"),
+		Dir:         getEnv("EXPORT_DIR", filepath.Join(os.TempDir(), "subdub-exports")),
+		SyncLimit:   getEnvAsInt("EXPORT_SYNC_LIMIT", 500),
+		ExpiryHours: getEnvAsInt("EXPORT_EXPIRY_HOURS", 24),
+	}
+
+	attachmentConfig := &AttachmentConfig{
+		Dir:         getEnv("ATTACHMENT_DIR", filepath.Join("data", "attachments")),
+		MaxFileSize: int64(getEnvAsInt("ATTACHMENT_MAX_FILE_SIZE_MB", 10)) << 20,
+		UserQuota:   int64(getEnvAsInt("ATTACHMENT_USER_QUOTA_MB", 100)) << 20,
+	}
+
 	srvConfig := &ServerConfig{
 		Addr: getEnv("ADDR", ":8080"),
 	}
@@ -99,6 +147,9 @@ func LoadConfig() (*Config, error) {
 		Authenticator: authenticatorConfig,
 		Mailer:        mailerConfig,
 		GoogleOAuth:   googleOAuthConfig,
+		Chrono:        chronoConfig,
+		Export:        exportConfig,
+		Attachment:    attachmentConfig,
 	}, nil
 }
 
@@ -160,6 +211,26 @@ func getEnvAsInt(key string, fallback int) int {
 	return valueAsInt
 }
 
+// getEnvAsIntSlice parses a comma separated list of positive integers like "7,3,1"
+func getEnvAsIntSlice(key string, fallback []int) []int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	var values []int
+	for _, item := range strings.Split(value, ",") {
+		valueAsInt, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil || valueAsInt < 1 {
+			return fallback
+		}
+
+		values = append(values, valueAsInt)
+	}
+
+	return values
+}
+
 func getEnvAsBool(key string, fallback bool) bool {
 	value := os.Getenv(key)
 	if value == "" {
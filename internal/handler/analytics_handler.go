@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/internal/utils"
+)
+
+const (
+	defaultSpendTop = 5
+	maxSpendTop     = 50
+)
+
+type analyticsHandler struct {
+	s service.AnalyticsService
+}
+
+func NewAnalyticsHandler(s service.AnalyticsService) *analyticsHandler {
+	return &analyticsHandler{s}
+}
+
+// GetSpendHandler godoc
+//
+//	@Summary		Get spend analytics
+//	@Description	Get the recurring spend of active subscriptions which are not set to cancel,
+//	@Description	normalized to a monthly and yearly cost and grouped by currency.
+//	@Description	Free trials count with their post trial price, subscriptions without a price are only counted in unpriced_count
+//	@Tags			analytics
+//	@Accept			json
+//	@Produce		json
+//	@Param			top	query		int	false	"Number of most expensive subscriptions per currency, 1 to 50"	default(5)
+//	@Success		200	{object}	service.GetSpendResponse
+//	@Failure		400	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/analytics/spend [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *analyticsHandler) GetSpendHandler(c *gin.Context) {
+	err := checkQueryParams(c, []string{"top"})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req := service.GetSpendRequest{Top: defaultSpendTop}
+
+	if top := c.Query("top"); top != "" {
+		req.Top, err = strconv.Atoi(top)
+		if err != nil || req.Top < 1 || req.Top > maxSpendTop {
+			_ = c.Error(apperror.ErrInvalidTop)
+			return
+		}
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.GetSpend(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get spend successfully", res))
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/internal/utils"
+)
+
+type attachmentHandler struct {
+	s service.AttachmentService
+}
+
+func NewAttachmentHandler(s service.AttachmentService) *attachmentHandler {
+	return &attachmentHandler{s}
+}
+
+// UploadAttachmentHandler godoc
+//
+//	@Summary		Upload attachment
+//	@Description	Attach a file like an invoice or a receipt to a subscription.
+//	@Description	Pdf, png, jpeg, gif, webp and plain text files are accepted, the type is sniffed from the content.
+//	@Description	Files are at most ATTACHMENT_MAX_FILE_SIZE_MB (default 10MB) and the attachments of a user
+//	@Description	at most ATTACHMENT_USER_QUOTA_MB (default 100MB) in total
+//	@Tags			attachments
+//	@Accept			mpfd
+//	@Produce		json
+//	@Param			id		path		string	true	"Subscription ID"
+//	@Param			file	formData	file	true	"Attached file"
+//	@Success		201		{object}	models.Attachment
+//	@Failure		400		{object}	error
+//	@Failure		404		{object}	error
+//	@Failure		413		{object}	error
+//	@Failure		415		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/subscriptions/{id}/attachments [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *attachmentHandler) UploadAttachmentHandler(c *gin.Context) {
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		_ = c.Error(apperror.ErrAttachmentFileRequired)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer file.Close()
+
+	res, err := h.s.UploadAttachment(c.Request.Context(), &service.UploadAttachmentRequest{
+		File:           file,
+		FileName:       fileHeader.Filename,
+		Size:           fileHeader.Size,
+		SubscriptionID: subscriptionID,
+		UserID:         userID,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewAppResponse("uploaded attachment successfully", res))
+}
+
+// GetAttachmentsHandler godoc
+//
+//	@Summary		Get attachments
+//	@Description	Get the attachments of a subscription, newest first
+//	@Tags			attachments
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Subscription ID"
+//	@Success		200	{array}		models.Attachment
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/subscriptions/{id}/attachments [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *attachmentHandler) GetAttachmentsHandler(c *gin.Context) {
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.GetAttachments(c.Request.Context(), subscriptionID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get attachments successfully", res))
+}
+
+// DownloadAttachmentHandler godoc
+//
+//	@Summary		Download attachment
+//	@Description	Download the content of an attachment with its sniffed content type
+//	@Tags			attachments
+//	@Produce		octet-stream
+//	@Param			id	path		string	true	"Attachment ID"
+//	@Success		200	{file}		file	"Attached file"
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/attachments/{id} [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *attachmentHandler) DownloadAttachmentHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	file, err := h.s.OpenAttachment(c.Request.Context(), id, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer file.Close()
+
+	attachment := file.Attachment
+
+	// the sniffed type is trusted, browsers must not guess another one from the content
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, file, map[string]string{
+		"Content-Disposition": `attachment; filename="` + attachment.FileName + `"; filename*=UTF-8''` +
+			url.PathEscape(attachment.FileName),
+	})
+}
+
+// DeleteAttachmentHandler godoc
+//
+//	@Summary		Delete attachment
+//	@Description	Delete an attachment with its content
+//	@Tags			attachments
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Attachment ID"
+//	@Success		200	{object}	response.AppResponse
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/attachments/{id} [delete]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *attachmentHandler) DeleteAttachmentHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = h.s.DeleteAttachment(c.Request.Context(), id, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("deleted attachment successfully", nil))
+}
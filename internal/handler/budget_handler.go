@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/internal/utils"
+)
+
+type budgetHandler struct {
+	s service.BudgetService
+	v validator.Validator
+}
+
+func NewBudgetHandler(s service.BudgetService, v validator.Validator) *budgetHandler {
+	return &budgetHandler{s, v}
+}
+
+// GetBudgetsHandler godoc
+//
+//	@Summary		Get budgets
+//	@Description	Get the status of every budget of current user for the current month.
+//	@Description	Committed counts every renewal of the month including the upcoming ones, spent only the renewals up to today
+//	@Tags			budgets
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		service.BudgetStatus
+//	@Failure		500	{object}	error
+//	@Router			/budgets/ [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *budgetHandler) GetBudgetsHandler(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.GetBudgets(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get budgets successfully", res))
+}
+
+// SetBudgetHandler godoc
+//
+//	@Summary		Set budget
+//	@Description	Set the monthly budget of a currency, for every subscription or only the ones billed at duration.
+//	@Description	The amount is replaced when the budget already exists
+//	@Tags			budgets
+//	@Accept			json
+//	@Produce		json
+//	@Param			budget	body		service.SetBudgetRequest	true	"Set budget request"
+//	@Success		200		{object}	models.Budget
+//	@Failure		400		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/budgets/ [put]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *budgetHandler) SetBudgetHandler(c *gin.Context) {
+	var req service.SetBudgetRequest
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.SetBudget(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("set budget successfully", res))
+}
+
+// DeleteBudgetHandler godoc
+//
+//	@Summary		Delete budget
+//	@Description	Delete a budget, no more alerts are sent for it
+//	@Tags			budgets
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Budget ID"
+//	@Success		200	{object}	response.AppResponse
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/budgets/{id} [delete]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *budgetHandler) DeleteBudgetHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = h.s.DeleteBudget(c.Request.Context(), id, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("deleted budget successfully", nil))
+}
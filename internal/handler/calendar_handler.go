@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/internal/utils"
+)
+
+type calendarHandler struct {
+	s service.CalendarService
+}
+
+func NewCalendarHandler(s service.CalendarService) *calendarHandler {
+	return &calendarHandler{s}
+}
+
+// GetCalendarFeedHandler godoc
+//
+//	@Summary		Get calendar feed
+//	@Description	Get the iCalendar feed of upcoming renewals for the next year, with reminders as alarms.
+//	@Description	It does not need the Authorization header, the token in the path is the only credential
+//	@Tags			calendar
+//	@Produce		text/calendar
+//	@Param			file	path		string	true	"Calendar token followed by .ics"
+//	@Success		200		{string}	string	"iCalendar feed"
+//	@Failure		404		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/calendar/{file} [get]
+func (h *calendarHandler) GetCalendarFeedHandler(c *gin.Context) {
+	token, ok := strings.CutSuffix(c.Param("file"), ".ics")
+	if !ok || token == "" {
+		_ = c.Error(apperror.ErrCalendarNotFound)
+		return
+	}
+
+	feed, err := h.s.GetCalendarFeed(c.Request.Context(), token)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
+
+// RotateCalendarTokenHandler godoc
+//
+//	@Summary		Rotate calendar token
+//	@Description	Create a new calendar feed token, the feed URL of the previous token stops working
+//	@Tags			calendar
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	service.RotateCalendarTokenResponse
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/calendar/token [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *calendarHandler) RotateCalendarTokenHandler(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.RotateCalendarToken(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewAppResponse("rotated calendar token successfully", res))
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/internal/utils"
+)
+
+const (
+	defaultCatalogLimit = 10
+	maxCatalogLimit     = 50
+)
+
+type catalogHandler struct {
+	s service.CatalogService
+	v validator.Validator
+}
+
+func NewCatalogHandler(s service.CatalogService, v validator.Validator) *catalogHandler {
+	return &catalogHandler{s, v}
+}
+
+// SearchCatalogHandler godoc
+//
+//	@Summary		Search catalog
+//	@Description	Autocomplete of well-known services with their default plans,
+//	@Description	services whose name starts with q come first. A plan id can be sent as catalog_plan_id
+//	@Description	when creating a subscription to prefill its name, duration and price
+//	@Tags			catalog
+//	@Accept			json
+//	@Produce		json
+//	@Param			q		query		string	false	"Part of the name of the service"
+//	@Param			limit	query		int		false	"Maximum number of services, 1 to 50"	default(10)
+//	@Success		200		{array}		models.CatalogEntry
+//	@Failure		400		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/catalog [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *catalogHandler) SearchCatalogHandler(c *gin.Context) {
+	err := checkQueryParams(c, []string{"q", "limit"})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	limit := defaultCatalogLimit
+	if value := c.Query("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxCatalogLimit {
+			_ = c.Error(apperror.ErrInvalidCatalogLimit)
+			return
+		}
+	}
+
+	res, err := h.s.SearchCatalog(c.Request.Context(), c.Query("q"), limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("search catalog successfully", res))
+}
+
+// GetCatalogEntryHandler godoc
+//
+//	@Summary		Get catalog service
+//	@Description	Get a service of the catalog with its default plans
+//	@Tags			catalog
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Catalog service ID"
+//	@Success		200	{object}	models.CatalogEntry
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/catalog/{id} [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *catalogHandler) GetCatalogEntryHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	res, err := h.s.GetCatalogEntry(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get catalog service successfully", res))
+}
+
+// CreateCatalogEntryHandler godoc
+//
+//	@Summary		Create catalog service
+//	@Description	Add a service with its plans to the catalog, only admins can edit the catalog
+//	@Tags			catalog
+//	@Accept			json
+//	@Produce		json
+//	@Param			service	body		service.CreateCatalogEntryRequest	true	"Create catalog service request"
+//	@Success		201		{object}	models.CatalogEntry
+//	@Failure		400		{object}	error
+//	@Failure		403		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/catalog [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *catalogHandler) CreateCatalogEntryHandler(c *gin.Context) {
+	var req service.CreateCatalogEntryRequest
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.CreateCatalogEntry(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewAppResponse("created catalog service successfully", res))
+}
+
+// UpdateCatalogEntryHandler godoc
+//
+//	@Summary		Update catalog service
+//	@Description	Replace a service of the catalog, plans are matched by slug and the missing ones are deleted.
+//	@Description	Only admins can edit the catalog
+//	@Tags			catalog
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Catalog service ID"
+//	@Param			service	body		service.UpdateCatalogEntryRequest	true	"Update catalog service request"
+//	@Success		200		{object}	models.CatalogEntry
+//	@Failure		400		{object}	error
+//	@Failure		403		{object}	error
+//	@Failure		404		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/catalog/{id} [put]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *catalogHandler) UpdateCatalogEntryHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	var req service.UpdateCatalogEntryRequest
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	req.ID = id
+
+	res, err := h.s.UpdateCatalogEntry(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("updated catalog service successfully", res))
+}
+
+// DeleteCatalogEntryHandler godoc
+//
+//	@Summary		Delete catalog service
+//	@Description	Delete a service with its plans from the catalog, subscriptions created from it are kept.
+//	@Description	Only admins can edit the catalog
+//	@Tags			catalog
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Catalog service ID"
+//	@Success		200	{object}	response.AppResponse
+//	@Failure		400	{object}	error
+//	@Failure		403	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/catalog/{id} [delete]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *catalogHandler) DeleteCatalogEntryHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = h.s.DeleteCatalogEntry(c.Request.Context(), id, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("deleted catalog service successfully", nil))
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/internal/utils"
+)
+
+type categoryHandler struct {
+	s service.CategoryService
+	v validator.Validator
+}
+
+func NewCategoryHandler(s service.CategoryService, v validator.Validator) *categoryHandler {
+	return &categoryHandler{
+		s,
+		v,
+	}
+}
+
+// GetAllCategoriesHandler godoc
+//
+//	@Summary		Get all categories
+//	@Description	Get all categories of current user ordered by name
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		models.Category
+//	@Failure		500	{object}	error
+//	@Router			/categories/ [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *categoryHandler) GetAllCategoriesHandler(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.GetAllCategories(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get all categories successfully", res))
+}
+
+// CreateCategoryHandler godoc
+//
+//	@Summary		Create category
+//	@Description	Create a category, names are unique per user
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	body		service.CreateCategoryRequest	true	"Create category request"
+//	@Success		201			{object}	models.Category
+//	@Failure		400			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/categories/ [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *categoryHandler) CreateCategoryHandler(c *gin.Context) {
+	var req service.CreateCategoryRequest
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.CreateCategory(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewAppResponse("created category successfully", res))
+}
+
+// UpdateCategoryHandler godoc
+//
+//	@Summary		Update category
+//	@Description	Rename a category
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string							true	"Category ID"
+//	@Param			category	body		service.UpdateCategoryRequest	true	"Update category request"
+//	@Success		200			{object}	models.Category
+//	@Failure		400			{object}	error
+//	@Failure		404			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/categories/{id} [patch]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *categoryHandler) UpdateCategoryHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	var req service.UpdateCategoryRequest
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	req.ID = id
+
+	res, err := h.s.UpdateCategory(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("updated category successfully", res))
+}
+
+// DeleteCategoryHandler godoc
+//
+//	@Summary		Delete category
+//	@Description	Delete a category, its subscriptions become uncategorized
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Category ID"
+//	@Success		200	{object}	response.AppResponse
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/categories/{id} [delete]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *categoryHandler) DeleteCategoryHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = h.s.DeleteCategory(c.Request.Context(), id, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("deleted category successfully", nil))
+}
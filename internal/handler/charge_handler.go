@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/internal/utils"
+)
+
+type chargeHandler struct {
+	s service.ChargeService
+	v validator.Validator
+}
+
+func NewChargeHandler(s service.ChargeService, v validator.Validator) *chargeHandler {
+	return &chargeHandler{s, v}
+}
+
+// GetChargesHandler godoc
+//
+//	@Summary		Get charges
+//	@Description	Get the charge ledger of current user, a charge is booked for every billing period when the subscription renews.
+//	@Description	Charges are ordered by most recent period first and totaled per currency
+//	@Tags			charges
+//	@Accept			json
+//	@Produce		json
+//	@Param			subscription_id	query		string	false	"Filter by subscription ID"
+//	@Param			currency		query		string	false	"Filter by ISO 4217 currency code"
+//	@Param			from			query		string	false	"Periods starting on or after this date, YYYY-MM-DD"
+//	@Param			to				query		string	false	"Periods starting on or before this date, YYYY-MM-DD"
+//	@Success		200				{object}	service.GetChargesResponse
+//	@Failure		400				{object}	error
+//	@Failure		500				{object}	error
+//	@Router			/charges/ [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *chargeHandler) GetChargesHandler(c *gin.Context) {
+	err := checkQueryParams(c, []string{"subscription_id", "currency", "from", "to"})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req service.GetChargesRequest
+
+	if subscriptionID := c.Query("subscription_id"); subscriptionID != "" {
+		id, err := uuid.Parse(subscriptionID)
+		if err != nil {
+			_ = c.Error(apperror.ErrInvalidUUID)
+			return
+		}
+		req.SubscriptionID = &id
+	}
+
+	req.Currency = strings.ToUpper(c.Query("currency"))
+
+	req.From, err = parseDateQuery(c, "from")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.To, err = parseDateQuery(c, "to")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		_ = c.Error(apperror.ErrInvalidChargeRange)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.GetCharges(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get charges successfully", res))
+}
+
+// BackfillChargesHandler godoc
+//
+//	@Summary		Backfill charges
+//	@Description	Book the past billing periods of every subscription of current user,
+//	@Description	from the period in progress when the subscription was added. Periods already booked are skipped,
+//	@Description	so it is safe to call again
+//	@Tags			charges
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	service.BackfillChargesResponse
+//	@Failure		500	{object}	error
+//	@Router			/charges/backfill [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *chargeHandler) BackfillChargesHandler(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.BackfillCharges(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("backfilled charges successfully", res))
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/internal/utils"
+)
+
+type exportHandler struct {
+	s service.ExportService
+}
+
+func NewExportHandler(s service.ExportService) *exportHandler {
+	return &exportHandler{s}
+}
+
+// ExportUserDataHandler godoc
+//
+//	@Summary		Export personal data
+//	@Description	Download a zip of the profile, subscriptions, price history, charges, categories, tags,
+//	@Description	payment methods, attachment metadata, memberships, budgets, sessions metadata,
+//	@Description	linked auth providers and reminder history, as export.json and a csv file per entity.
+//	@Description	Accounts with more than EXPORT_SYNC_LIMIT subscriptions have to queue an export with POST /export
+//	@Tags			export
+//	@Produce		application/zip
+//	@Success		200	{file}		file	"Zip of the personal data"
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/export [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *exportHandler) ExportUserDataHandler(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	export, err := h.s.ExportUserData(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="`+export.FileName+`"`)
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+
+	err = export.WriteZip(c.Writer)
+	if err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// QueueExportHandler godoc
+//
+//	@Summary		Queue export
+//	@Description	Generate the export of personal data in background and email its download link when ready.
+//	@Description	A new export can only be queued once the previous one expired
+//	@Tags			export
+//	@Produce		json
+//	@Success		202	{object}	response.AppResponse
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/export [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *exportHandler) QueueExportHandler(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = h.s.QueueExport(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, response.NewAppResponse(
+		"export is being generated, a download link will be emailed when it is ready",
+		nil,
+	))
+}
+
+// DownloadExportHandler godoc
+//
+//	@Summary		Download export
+//	@Description	Download an export generated in background. It does not need the Authorization header,
+//	@Description	the token in the emailed link is the only credential
+//	@Tags			export
+//	@Produce		application/zip
+//	@Param			file	path		string	true	"Export token followed by .zip"
+//	@Success		200		{file}		file	"Zip of the personal data"
+//	@Failure		404		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/export/{file} [get]
+func (h *exportHandler) DownloadExportHandler(c *gin.Context) {
+	token, ok := strings.CutSuffix(c.Param("file"), ".zip")
+	if !ok || token == "" {
+		_ = c.Error(apperror.ErrExportNotFound)
+		return
+	}
+
+	file, err := h.s.OpenExport(token)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer file.Close()
+
+	c.Header("Cache-Control", "no-store")
+	c.DataFromReader(http.StatusOK, file.Size, "application/zip", file, map[string]string{
+		"Content-Disposition": `attachment; filename="` + file.Name + `"`,
+	})
+}
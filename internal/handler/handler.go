@@ -6,17 +6,43 @@ import (
 )
 
 type Handler struct {
-	User         *userHandler
-	Subscription *subscriptionHandler
-	Auth         *authHandler
-	OAuth2       *oAuth2Handler
+	User          *userHandler
+	Subscription  *subscriptionHandler
+	Auth          *authHandler
+	OAuth2        *oAuth2Handler
+	Category      *categoryHandler
+	Tag           *tagHandler
+	Analytics     *analyticsHandler
+	Calendar      *calendarHandler
+	Import        *importHandler
+	Export        *exportHandler
+	Statement     *statementHandler
+	Charge        *chargeHandler
+	Budget        *budgetHandler
+	Member        *memberHandler
+	Catalog       *catalogHandler
+	Attachment    *attachmentHandler
+	PaymentMethod *paymentMethodHandler
 }
 
 func NewHandler(service *service.Service, validator validator.Validator) *Handler {
 	return &Handler{
-		User:         NewUserHandler(service.User),
-		Subscription: NewSubscriptionHandler(service.Subscription, validator),
-		Auth:         NewAuthHandler(service.Auth, validator),
-		OAuth2:       NewOAuth2Handler(service.OAuth2),
+		User:          NewUserHandler(service.User),
+		Subscription:  NewSubscriptionHandler(service.Subscription, validator),
+		Auth:          NewAuthHandler(service.Auth, validator),
+		OAuth2:        NewOAuth2Handler(service.OAuth2),
+		Category:      NewCategoryHandler(service.Category, validator),
+		Tag:           NewTagHandler(service.Tag, validator),
+		Analytics:     NewAnalyticsHandler(service.Analytics),
+		Calendar:      NewCalendarHandler(service.Calendar),
+		Import:        NewImportHandler(service.Import),
+		Export:        NewExportHandler(service.Export),
+		Statement:     NewStatementHandler(service.Statement, validator),
+		Charge:        NewChargeHandler(service.Charge, validator),
+		Budget:        NewBudgetHandler(service.Budget, validator),
+		Member:        NewMemberHandler(service.Member, validator),
+		Catalog:       NewCatalogHandler(service.Catalog, validator),
+		Attachment:    NewAttachmentHandler(service.Attachment),
+		PaymentMethod: NewPaymentMethodHandler(service.PaymentMethod, validator),
 	}
 }
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/internal/utils"
+)
+
+const maxImportFileSize = 1 << 20
+
+type importHandler struct {
+	s service.ImportService
+}
+
+func NewImportHandler(s service.ImportService) *importHandler {
+	return &importHandler{s}
+}
+
+// ImportSubscriptionsHandler godoc
+//
+//	@Summary		Import subscriptions
+//	@Description	Import subscriptions from a csv file of at most 1MB and 1000 rows.
+//	@Description	The header must have name, start_date (YYYY-MM-DD) and duration columns, amount (e.g. 9.99) and currency are optional
+//	@Description	and any other column is kept in the notes of the subscription.
+//	@Description	Valid rows are imported together and invalid rows are reported, with dry_run nothing is imported
+//	@Tags			subscriptions
+//	@Accept			mpfd
+//	@Produce		json
+//	@Param			file	formData	file	true	"CSV file"
+//	@Param			dry_run	query		bool	false	"Only validate the file"	default(false)
+//	@Success		200		{object}	service.ImportSubscriptionsResponse
+//	@Success		201		{object}	service.ImportSubscriptionsResponse
+//	@Failure		400		{object}	error
+//	@Failure		413		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/subscriptions/import [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *importHandler) ImportSubscriptionsHandler(c *gin.Context) {
+	req := service.ImportSubscriptionsRequest{}
+
+	var err error
+	if dryRun := c.Query("dry_run"); dryRun != "" {
+		req.DryRun, err = strconv.ParseBool(dryRun)
+		if err != nil {
+			_ = c.Error(apperror.NewAppError(
+				http.StatusBadRequest,
+				"invalid dry_run, should be true or false",
+			))
+			return
+		}
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		_ = c.Error(apperror.ErrImportFileRequired)
+		return
+	}
+
+	if fileHeader.Size > maxImportFileSize {
+		_ = c.Error(apperror.ErrImportTooLarge)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer file.Close()
+	req.File = file
+
+	res, err := h.s.ImportSubscriptions(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if req.DryRun {
+		c.JSON(http.StatusOK, response.NewAppResponse("validated subscriptions successfully", res))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewAppResponse("imported subscriptions successfully", res))
+}
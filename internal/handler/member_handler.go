@@ -0,0 +1,322 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/internal/utils"
+)
+
+type memberHandler struct {
+	s service.MemberService
+	v validator.Validator
+}
+
+func NewMemberHandler(s service.MemberService, v validator.Validator) *memberHandler {
+	return &memberHandler{s, v}
+}
+
+// InviteMemberHandler godoc
+//
+//	@Summary		Invite member
+//	@Description	Share a subscription with a registered user, who owes a percent of the price or a fixed amount
+//	@Description	of every billing period once the invitation is accepted. Shares can not exceed the price
+//	@Tags			members
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Subscription ID"
+//	@Param			member	body		service.InviteMemberRequest	true	"Invite member request"
+//	@Success		201		{object}	models.SubscriptionMember
+//	@Failure		400		{object}	error
+//	@Failure		404		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/subscriptions/{id}/members [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *memberHandler) InviteMemberHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	var req service.InviteMemberRequest
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	req.SubscriptionID = id
+
+	res, err := h.s.InviteMember(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewAppResponse("invited member successfully", res))
+}
+
+// GetMembersHandler godoc
+//
+//	@Summary		Get members
+//	@Description	Get the members of a subscription, for its owner and its members
+//	@Tags			members
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Subscription ID"
+//	@Success		200	{array}		models.SubscriptionMember
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/subscriptions/{id}/members [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *memberHandler) GetMembersHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.GetMembers(c.Request.Context(), id, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get members successfully", res))
+}
+
+// RemoveMemberHandler godoc
+//
+//	@Summary		Remove member
+//	@Description	The owner removes a member from a subscription, or a member leaves it
+//	@Tags			members
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Subscription ID"
+//	@Param			member_id	path		string	true	"Member ID"
+//	@Success		200			{object}	response.AppResponse
+//	@Failure		400			{object}	error
+//	@Failure		404			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/subscriptions/{id}/members/{member_id} [delete]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *memberHandler) RemoveMemberHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("member_id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = h.s.RemoveMember(c.Request.Context(), id, memberID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("removed member successfully", nil))
+}
+
+// GetInvitationsHandler godoc
+//
+//	@Summary		Get invitations
+//	@Description	Get the pending invitations of current user to share subscriptions
+//	@Tags			members
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		models.SubscriptionInvitation
+//	@Failure		500	{object}	error
+//	@Router			/invitations/ [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *memberHandler) GetInvitationsHandler(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.GetInvitations(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get invitations successfully", res))
+}
+
+// AcceptInvitationHandler godoc
+//
+//	@Summary		Accept invitation
+//	@Description	Accept a pending invitation, the subscription is then listed with the subscriptions of current user
+//	@Tags			members
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Invitation ID"
+//	@Success		200	{object}	models.SubscriptionMember
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/invitations/{id}/accept [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *memberHandler) AcceptInvitationHandler(c *gin.Context) {
+	h.respondInvitation(c, true)
+}
+
+// DeclineInvitationHandler godoc
+//
+//	@Summary		Decline invitation
+//	@Description	Decline a pending invitation, the owner can invite current user again
+//	@Tags			members
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Invitation ID"
+//	@Success		200	{object}	models.SubscriptionMember
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/invitations/{id}/decline [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *memberHandler) DeclineInvitationHandler(c *gin.Context) {
+	h.respondInvitation(c, false)
+}
+
+func (h *memberHandler) respondInvitation(c *gin.Context, accept bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.RespondInvitation(c.Request.Context(), id, userID, accept)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	msg := "declined invitation successfully"
+	if accept {
+		msg = "accepted invitation successfully"
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse(msg, res))
+}
+
+// GetBalancesHandler godoc
+//
+//	@Summary		Get balances
+//	@Description	Get who owes whom for the billing periods of the subscriptions current user shares,
+//	@Description	a member owes its share of every period booked in the charge ledger once it accepted.
+//	@Description	Balances are netted per user and currency, positive amounts are owed to current user
+//	@Tags			members
+//	@Accept			json
+//	@Produce		json
+//	@Param			from	query		string	false	"Periods starting on or after this date, YYYY-MM-DD"
+//	@Param			to		query		string	false	"Periods starting on or before this date, YYYY-MM-DD"
+//	@Success		200		{object}	service.GetBalancesResponse
+//	@Failure		400		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/balances/ [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *memberHandler) GetBalancesHandler(c *gin.Context) {
+	err := checkQueryParams(c, []string{"from", "to"})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req service.GetBalancesRequest
+
+	req.From, err = parseDateQuery(c, "from")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.To, err = parseDateQuery(c, "to")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		_ = c.Error(apperror.ErrInvalidChargeRange)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.GetBalances(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get balances successfully", res))
+}
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/internal/utils"
+)
+
+type paymentMethodHandler struct {
+	s service.PaymentMethodService
+	v validator.Validator
+}
+
+func NewPaymentMethodHandler(
+	s service.PaymentMethodService,
+	v validator.Validator,
+) *paymentMethodHandler {
+	return &paymentMethodHandler{
+		s,
+		v,
+	}
+}
+
+// GetPaymentMethodsHandler godoc
+//
+//	@Summary		Get payment methods
+//	@Description	Get all payment methods of current user ordered by label
+//	@Tags			payment-methods
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		models.PaymentMethod
+//	@Failure		500	{object}	error
+//	@Router			/payment-methods/ [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *paymentMethodHandler) GetPaymentMethodsHandler(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.GetPaymentMethods(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get payment methods successfully", res))
+}
+
+// CreatePaymentMethodHandler godoc
+//
+//	@Summary		Create payment method
+//	@Description	Register a card with its last four digits and expiry, full card numbers are never accepted.
+//	@Description	The owner is emailed a month before the card expires with the subscriptions it pays
+//	@Tags			payment-methods
+//	@Accept			json
+//	@Produce		json
+//	@Param			payment_method	body		service.CreatePaymentMethodRequest	true	"Create payment method request"
+//	@Success		201				{object}	models.PaymentMethod
+//	@Failure		400				{object}	error
+//	@Failure		500				{object}	error
+//	@Router			/payment-methods/ [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *paymentMethodHandler) CreatePaymentMethodHandler(c *gin.Context) {
+	var req service.CreatePaymentMethodRequest
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.CreatePaymentMethod(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewAppResponse("created payment method successfully", res))
+}
+
+// UpdatePaymentMethodHandler godoc
+//
+//	@Summary		Update payment method
+//	@Description	Replace a payment method, e.g. with the expiry of a renewed card.
+//	@Description	Changing the expiry warns the owner again before the new one
+//	@Tags			payment-methods
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string								true	"Payment method ID"
+//	@Param			payment_method	body		service.UpdatePaymentMethodRequest	true	"Update payment method request"
+//	@Success		200				{object}	models.PaymentMethod
+//	@Failure		400				{object}	error
+//	@Failure		404				{object}	error
+//	@Failure		500				{object}	error
+//	@Router			/payment-methods/{id} [put]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *paymentMethodHandler) UpdatePaymentMethodHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	var req service.UpdatePaymentMethodRequest
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	req.ID = id
+
+	res, err := h.s.UpdatePaymentMethod(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("updated payment method successfully", res))
+}
+
+// DeletePaymentMethodHandler godoc
+//
+//	@Summary		Delete payment method
+//	@Description	Delete a payment method, its subscriptions are unlinked
+//	@Tags			payment-methods
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Payment method ID"
+//	@Success		200	{object}	response.AppResponse
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/payment-methods/{id} [delete]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *paymentMethodHandler) DeletePaymentMethodHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = h.s.DeletePaymentMethod(c.Request.Context(), id, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("deleted payment method successfully", nil))
+}
+
+// SetSubscriptionPaymentMethodHandler godoc
+//
+//	@Summary		Set subscription payment method
+//	@Description	Link a subscription to the payment method it is paid with,
+//	@Description	a null payment_method_id unlinks it
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string										true	"Subscription ID"
+//	@Param			payment_method	body		service.SetSubscriptionPaymentMethodRequest	true	"Set payment method request"
+//	@Success		200				{object}	models.Subscription
+//	@Failure		400				{object}	error
+//	@Failure		404				{object}	error
+//	@Failure		500				{object}	error
+//	@Router			/subscriptions/{id}/payment-method [put]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *paymentMethodHandler) SetSubscriptionPaymentMethodHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	var req service.SetSubscriptionPaymentMethodRequest
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.ID = id
+	req.UserID = userID
+
+	res, err := h.s.SetSubscriptionPaymentMethod(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("set subscription payment method successfully", res))
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/internal/utils"
+)
+
+const maxStatementFileSize = 2 << 20
+
+type statementHandler struct {
+	s service.StatementService
+	v validator.Validator
+}
+
+func NewStatementHandler(s service.StatementService, v validator.Validator) *statementHandler {
+	return &statementHandler{s, v}
+}
+
+// DetectSubscriptionsHandler godoc
+//
+//	@Summary		Detect subscriptions from a bank statement
+//	@Description	Read an OFX/QFX or csv bank statement of at most 2MB and propose a subscription for every series
+//	@Description	of charges of the same amount by the same merchant at a weekly, monthly or yearly cadence.
+//	@Description	A csv file needs date, description and either amount or debit columns.
+//	@Description	Nothing is created, candidates are created with /subscriptions/statements/confirm
+//	@Tags			subscriptions
+//	@Accept			mpfd
+//	@Produce		json
+//	@Param			file		formData	file	true	"OFX, QFX or csv statement"
+//	@Param			currency	query		string	false	"Currency of a statement which does not tell it"	example(USD)
+//	@Success		200			{object}	service.DetectSubscriptionsResponse
+//	@Failure		400			{object}	error
+//	@Failure		413			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/subscriptions/statements/detect [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *statementHandler) DetectSubscriptionsHandler(c *gin.Context) {
+	err := checkQueryParams(c, []string{"currency"})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req := service.DetectSubscriptionsRequest{
+		Currency: strings.ToUpper(c.Query("currency")),
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		_ = c.Error(apperror.ErrStatementFileRequired)
+		return
+	}
+
+	if fileHeader.Size > maxStatementFileSize {
+		_ = c.Error(apperror.ErrStatementTooLarge)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer file.Close()
+	req.File = file
+
+	res, err := h.s.DetectSubscriptions(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("detected subscriptions successfully", res))
+}
+
+// ConfirmSubscriptionsHandler godoc
+//
+//	@Summary		Confirm detected subscriptions
+//	@Description	Create the subscriptions of the confirmed candidates of a bank statement, they are created together
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			subscriptions	body		service.ConfirmSubscriptionsRequest	true	"Confirmed candidates"
+//	@Success		201				{array}		models.Subscription
+//	@Failure		400				{object}	error
+//	@Failure		404				{object}	error
+//	@Failure		500				{object}	error
+//	@Router			/subscriptions/statements/confirm [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *statementHandler) ConfirmSubscriptionsHandler(c *gin.Context) {
+	var req service.ConfirmSubscriptionsRequest
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.ConfirmSubscriptions(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewAppResponse("created subscriptions successfully", res))
+}
@@ -2,11 +2,15 @@ package handler
 
 import (
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sangtandoan/subscription_tracker/internal/authenticator"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
 	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
 	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
 	"github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
@@ -14,6 +18,11 @@ import (
 	"github.com/sangtandoan/subscription_tracker/internal/utils"
 )
 
+const (
+	defaultUpcomingRangeDays = 30
+	maxUpcomingRangeDays     = 366
+)
+
 type subscriptionHandler struct {
 	s service.SubscriptionService
 	v validator.Validator
@@ -54,14 +63,27 @@ func (h *subscriptionHandler) GetSubscriptionsBeforeNumDays(c *gin.Context) {
 // GetAllSubscriptionsHandler godoc
 //
 //	@Summary		Get all subscriptions
-//	@Description	get all subscriptions
+//	@Description	get all subscriptions, subscriptions shared with current user are included with is_shared set
 //	@Tags			subscriptions
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{array}		service.GetAllSubscriptionsResponse
-//	@Failure		400	{object}	error
-//	@Failure		404	{object}	error
-//	@Failure		500	{object}	error
+//	@Param			limit			query		int			false	"Limit"											default(10)
+//	@Param			offset			query		int			false	"Offset, ignored when after or before is set"	default(0)
+//	@Param			after			query		string		false	"Cursor returned as next_cursor"
+//	@Param			before			query		string		false	"Cursor returned as prev_cursor"
+//	@Param			is_cancelled	query		bool		false	"Filter by cancellation"
+//	@Param			status			query		string		false	"Filter by status"	Enums(active, cancelled, ended)
+//	@Param			category		query		string		false	"Filter by category ID"
+//	@Param			tag				query		[]string	false	"Filter by tag IDs, subscriptions must have all of them"	collectionFormat(multi)
+//	@Param			q				query		string		false	"Case-insensitive search on name"
+//	@Param			sort			query		string		false	"Sort field"										Enums(name, start_date, end_date, price, created_at)	default(start_date)
+//	@Param			order			query		string		false	"Sort direction, also applied to the default sort"	Enums(asc, desc)										default(asc)
+//	@Param			end_date_from	query		string		false	"Only subscriptions ending on or after this date (YYYY-MM-DD)"
+//	@Param			end_date_to		query		string		false	"Only subscriptions ending on or before this date (YYYY-MM-DD)"
+//	@Success		200				{object}	service.GetAllSubscriptionsResponse
+//	@Failure		400				{object}	error
+//	@Failure		404				{object}	error
+//	@Failure		500				{object}	error
 //	@Router			/subscriptions/ [get]
 //
 // This tells that this handler is protected by an API key
@@ -70,34 +92,25 @@ func (h *subscriptionHandler) GetSubscriptionsBeforeNumDays(c *gin.Context) {
 func (h *subscriptionHandler) GetAllSubscriptionsHandler(c *gin.Context) {
 	req := &service.GetAllSubscriptionsRequest{}
 
-	userID, err := utils.GetUserIDFromContext(c)
+	err := checkQueryParams(c, getAllSubscriptionsQueryParams)
 	if err != nil {
-		c.Error(err)
+		_ = c.Error(err)
 		return
 	}
-	req.UserID = userID
 
-	limit := c.Query("limit")
-	if limit == "" {
-		limit = "10"
-	}
-	limitInt, err := strconv.Atoi(limit)
+	userID, err := utils.GetUserIDFromContext(c)
 	if err != nil {
-		_ = c.Error(err)
+		c.Error(err)
 		return
 	}
-	req.Limit = limitInt
+	req.UserID = userID
 
-	offset := c.Query("offset")
-	if offset == "" {
-		offset = "0"
-	}
-	offsetInt, err := strconv.Atoi(offset)
+	req.Limit, req.Offset, err = parsePagination(c)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
-	req.Offset = offsetInt
+	req.After, req.Before = c.Query("after"), c.Query("before")
 
 	isCancelled := c.Query("is_cancelled")
 	if isCancelled == "" {
@@ -105,12 +118,71 @@ func (h *subscriptionHandler) GetAllSubscriptionsHandler(c *gin.Context) {
 	} else {
 		isCancelledBool, err := strconv.ParseBool(isCancelled)
 		if err != nil {
-			_ = c.Error(err)
+			_ = c.Error(apperror.NewAppError(
+				http.StatusBadRequest,
+				"invalid is_cancelled, should be true or false",
+			))
 			return
 		}
 		req.IsCancelled = &isCancelledBool
 	}
 
+	status := c.Query("status")
+	if status != "" && !slices.Contains(models.AllSubscriptionStatuses, status) {
+		_ = c.Error(apperror.ErrInvalidStatus)
+		return
+	}
+	req.Status = status
+
+	if category := c.Query("category"); category != "" {
+		categoryID, err := uuid.Parse(category)
+		if err != nil {
+			_ = c.Error(apperror.ErrInvalidUUID)
+			return
+		}
+		req.CategoryID = &categoryID
+	}
+
+	for _, tag := range c.QueryArray("tag") {
+		tagID, err := uuid.Parse(tag)
+		if err != nil {
+			_ = c.Error(apperror.ErrInvalidUUID)
+			return
+		}
+		req.TagIDs = append(req.TagIDs, tagID)
+	}
+
+	req.Query = strings.TrimSpace(c.Query("q"))
+
+	req.Sort = c.Query("sort")
+	if req.Sort != "" && !slices.Contains(models.AllSubscriptionSorts, req.Sort) {
+		_ = c.Error(apperror.ErrInvalidSort)
+		return
+	}
+
+	req.Order = strings.ToLower(c.Query("order"))
+	if req.Order != "" && req.Order != models.SortOrderAsc && req.Order != models.SortOrderDesc {
+		_ = c.Error(apperror.ErrInvalidSortOrder)
+		return
+	}
+
+	req.EndDateFrom, err = parseDateQuery(c, "end_date_from")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.EndDateTo, err = parseDateQuery(c, "end_date_to")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if req.EndDateFrom != nil && req.EndDateTo != nil && req.EndDateFrom.After(*req.EndDateTo) {
+		_ = c.Error(apperror.ErrInvalidDateRange)
+		return
+	}
+
 	res, err := h.s.GetAllSubscriptions(c.Request.Context(), req)
 	if err != nil {
 		_ = c.Error(err)
@@ -174,3 +246,593 @@ func (h *subscriptionHandler) CreateSubscriptionHandler(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, response.NewAppResponse("created subscription sucessfully", res))
 }
+
+// GetSubscriptionHandler godoc
+//
+//	@Summary		Get subscription by id
+//	@Description	Get a subscription of current user, or one shared with them, by id.
+//	@Description	The notes, category, tags and payment method of a shared subscription are not returned
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Subscription ID"
+//	@Success		200	{object}	models.Subscription
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/subscriptions/{id} [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *subscriptionHandler) GetSubscriptionHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.GetSubscription(c.Request.Context(), id, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get subscription successfully", res))
+}
+
+// GetSubscriptionPricesHandler godoc
+//
+//	@Summary		Get subscription price history
+//	@Description	Get the prices of a subscription of current user with the date each is charged from, oldest first
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Subscription ID"
+//	@Success		200	{array}		models.SubscriptionPrice
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/subscriptions/{id}/prices [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *subscriptionHandler) GetSubscriptionPricesHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.GetSubscriptionPrices(c.Request.Context(), id, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get subscription prices successfully", res))
+}
+
+// UpdateSubscriptionHandler godoc
+//
+//	@Summary		Update subscription
+//	@Description	Partially update name, start date, duration and price of a subscription, end date is recomputed.
+//	@Description	A new price is kept in the price history and charged from price_effective_date, the next renewal by default
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string								true	"Subscription ID"
+//	@Param			subscription	body		service.UpdateSubscriptionRequest	true	"Update subscription request"
+//	@Success		200				{object}	models.Subscription
+//	@Failure		400				{object}	error
+//	@Failure		404				{object}	error
+//	@Failure		500				{object}	error
+//	@Router			/subscriptions/{id} [patch]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *subscriptionHandler) UpdateSubscriptionHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	var req service.UpdateSubscriptionRequest
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.ID = id
+	req.UserID = userID
+
+	res, err := h.s.UpdateSubscription(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("updated subscription successfully", res))
+}
+
+// CancelSubscriptionHandler godoc
+//
+//	@Summary		Cancel subscription
+//	@Description	Cancel a subscription now, or at the end of the current period when at_period_end is true
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string								true	"Subscription ID"
+//	@Param			subscription	body		service.CancelSubscriptionRequest	false	"Cancel subscription request"
+//	@Success		200				{object}	models.Subscription
+//	@Failure		400				{object}	error
+//	@Failure		404				{object}	error
+//	@Failure		500				{object}	error
+//	@Router			/subscriptions/{id}/cancel [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *subscriptionHandler) CancelSubscriptionHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	var req service.CancelSubscriptionRequest
+
+	// body is optional, cancel immediately by default
+	if c.Request.ContentLength > 0 {
+		err = c.ShouldBind(&req)
+		if err != nil {
+			_ = c.Error(apperror.ErrInvalidJSON)
+			return
+		}
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.ID = id
+	req.UserID = userID
+
+	res, err := h.s.CancelSubscription(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("cancelled subscription successfully", res))
+}
+
+// ReactivateSubscriptionHandler godoc
+//
+//	@Summary		Reactivate subscription
+//	@Description	Reactivate a cancelled subscription
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Subscription ID"
+//	@Success		200	{object}	models.Subscription
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/subscriptions/{id}/reactivate [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *subscriptionHandler) ReactivateSubscriptionHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.ReactivateSubscription(c.Request.Context(), id, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("reactivated subscription successfully", res))
+}
+
+// SetSubscriptionCategoryHandler godoc
+//
+//	@Summary		Set subscription category
+//	@Description	Move a subscription to a category, a null category_id makes it uncategorized
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string									true	"Subscription ID"
+//	@Param			category	body		service.SetSubscriptionCategoryRequest	true	"Set category request"
+//	@Success		200			{object}	models.Subscription
+//	@Failure		400			{object}	error
+//	@Failure		404			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/subscriptions/{id}/category [put]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *subscriptionHandler) SetSubscriptionCategoryHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	var req service.SetSubscriptionCategoryRequest
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.ID = id
+	req.UserID = userID
+
+	res, err := h.s.SetSubscriptionCategory(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("set subscription category successfully", res))
+}
+
+// SetSubscriptionTagsHandler godoc
+//
+//	@Summary		Set subscription tags
+//	@Description	Replace all tags of a subscription, an empty list removes all of them
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Subscription ID"
+//	@Param			tags	body		service.SetSubscriptionTagsRequest	true	"Set tags request"
+//	@Success		200		{object}	models.Subscription
+//	@Failure		400		{object}	error
+//	@Failure		404		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/subscriptions/{id}/tags [put]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *subscriptionHandler) SetSubscriptionTagsHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	var req service.SetSubscriptionTagsRequest
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.ID = id
+	req.UserID = userID
+
+	res, err := h.s.SetSubscriptionTags(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("set subscription tags successfully", res))
+}
+
+// GetDeletedSubscriptionsHandler godoc
+//
+//	@Summary		Get subscriptions in trash
+//	@Description	get deleted subscriptions which have not been purged yet
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit	query		int		false	"Limit"											default(10)
+//	@Param			offset	query		int		false	"Offset, ignored when after or before is set"	default(0)
+//	@Param			after	query		string	false	"Cursor returned as next_cursor"
+//	@Param			before	query		string	false	"Cursor returned as prev_cursor"
+//	@Success		200		{object}	service.GetAllSubscriptionsResponse
+//	@Failure		400		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/subscriptions/trash [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *subscriptionHandler) GetDeletedSubscriptionsHandler(c *gin.Context) {
+	req := &service.GetAllSubscriptionsRequest{Deleted: true}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	req.UserID = userID
+
+	req.Limit, req.Offset, err = parsePagination(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	req.After, req.Before = c.Query("after"), c.Query("before")
+
+	res, err := h.s.GetAllSubscriptions(c.Request.Context(), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get deleted subscriptions successfully", res))
+}
+
+// GetUpcomingRenewalsHandler godoc
+//
+//	@Summary		Get upcoming renewals
+//	@Description	Project every renewal of the active subscriptions between from and to, both included,
+//	@Description	grouped by day with the amounts of each day summed per currency
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			from	query		string	false	"First day, formatted as YYYY-MM-DD, defaults to today"
+//	@Param			to		query		string	false	"Last day, formatted as YYYY-MM-DD, defaults to 30 days after from, at most 366 days after from"
+//	@Success		200		{object}	service.GetUpcomingRenewalsResponse
+//	@Failure		400		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/subscriptions/upcoming [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *subscriptionHandler) GetUpcomingRenewalsHandler(c *gin.Context) {
+	err := checkQueryParams(c, []string{"from", "to"})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if from == nil {
+		now := time.Now().UTC()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		from = &today
+	}
+
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if to == nil {
+		defaultTo := from.AddDate(0, 0, defaultUpcomingRangeDays)
+		to = &defaultTo
+	}
+
+	if to.Before(*from) || to.After(from.AddDate(0, 0, maxUpcomingRangeDays)) {
+		_ = c.Error(apperror.ErrInvalidUpcomingRange)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.GetUpcomingRenewals(c.Request.Context(), &service.GetUpcomingRenewalsRequest{
+		From:   *from,
+		To:     *to,
+		UserID: userID,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get upcoming renewals successfully", res))
+}
+
+// DeleteSubscriptionHandler godoc
+//
+//	@Summary		Delete subscription
+//	@Description	Move a subscription to trash, it can be restored until it is purged
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Subscription ID"
+//	@Success		200	{object}	response.AppResponse
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/subscriptions/{id} [delete]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *subscriptionHandler) DeleteSubscriptionHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = h.s.DeleteSubscription(c.Request.Context(), id, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("deleted subscription successfully", nil))
+}
+
+// RestoreSubscriptionHandler godoc
+//
+//	@Summary		Restore subscription
+//	@Description	Restore a subscription from trash
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Subscription ID"
+//	@Success		200	{object}	models.Subscription
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/subscriptions/{id}/restore [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *subscriptionHandler) RestoreSubscriptionHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.RestoreSubscription(c.Request.Context(), id, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("restored subscription successfully", res))
+}
+
+// parsePagination gets limit and offset from query params,
+// default limit is 10 and default offset is 0
+func parsePagination(c *gin.Context) (int, int, error) {
+	limit := c.Query("limit")
+	if limit == "" {
+		limit = "10"
+	}
+	limitInt, err := strconv.Atoi(limit)
+	if err != nil || limitInt < 0 {
+		return 0, 0, apperror.ErrInvalidPagination
+	}
+
+	offset := c.Query("offset")
+	if offset == "" {
+		offset = "0"
+	}
+	offsetInt, err := strconv.Atoi(offset)
+	if err != nil || offsetInt < 0 {
+		return 0, 0, apperror.ErrInvalidPagination
+	}
+
+	return limitInt, offsetInt, nil
+}
+
+var getAllSubscriptionsQueryParams = []string{
+	"limit", "offset", "after", "before", "is_cancelled", "status", "category", "tag",
+	"q", "sort", "order", "end_date_from", "end_date_to",
+}
+
+// checkQueryParams returns a bad request error naming a query param which is not allowed,
+// so a typo in a filter is reported instead of being silently ignored
+func checkQueryParams(c *gin.Context, allowed []string) error {
+	for key := range c.Request.URL.Query() {
+		if !slices.Contains(allowed, key) {
+			return apperror.NewAppError(http.StatusBadRequest, "unknown query param: "+key)
+		}
+	}
+
+	return nil
+}
+
+// parseDateQuery parses an optional query param formatted as YYYY-MM-DD
+func parseDateQuery(c *gin.Context, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, apperror.NewAppError(
+			http.StatusBadRequest,
+			"invalid "+key+", should be formatted as YYYY-MM-DD",
+		)
+	}
+
+	return &date, nil
+}
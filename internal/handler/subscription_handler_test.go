@@ -0,0 +1,387 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/authenticator"
+	"github.com/sangtandoan/subscription_tracker/internal/handler"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAllSubscriptionsHandler(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockSubscriptionService)
+		checkResponse func(*testing.T, *gin.Context, *httptest.ResponseRecorder)
+		name          string
+		query         string
+	}{
+		{
+			name:  "Search, sort and end date range",
+			query: "?q=+netflix+&sort=price&order=DESC&end_date_from=2025-01-01&end_date_to=2025-01-31",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+				to := time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+				s.EXPECT().
+					GetAllSubscriptions(gomock.Any(), &service.GetAllSubscriptionsRequest{
+						UserID:      userID,
+						Limit:       10,
+						Query:       "netflix",
+						Sort:        "price",
+						Order:       "desc",
+						EndDateFrom: &from,
+						EndDateTo:   &to,
+					}).
+					Times(1).
+					Return(&service.GetAllSubscriptionsResponse{}, nil)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				require.Empty(t, c.Errors)
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:  "Unknown query param",
+			query: "?sort_by=name",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().GetAllSubscriptions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				requireAppError(t, c, http.StatusBadRequest, "unknown query param: sort_by")
+			},
+		},
+		{
+			name:  "Unknown sort field",
+			query: "?sort=amount",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().GetAllSubscriptions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				require.Len(t, c.Errors, 1)
+				require.ErrorIs(t, c.Errors[0].Err, apperror.ErrInvalidSort)
+			},
+		},
+		{
+			name:  "Unknown sort order",
+			query: "?sort=name&order=up",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().GetAllSubscriptions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				require.Len(t, c.Errors, 1)
+				require.ErrorIs(t, c.Errors[0].Err, apperror.ErrInvalidSortOrder)
+			},
+		},
+		{
+			name:  "Invalid end date",
+			query: "?end_date_to=31-01-2025",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().GetAllSubscriptions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				requireAppError(
+					t,
+					c,
+					http.StatusBadRequest,
+					"invalid end_date_to, should be formatted as YYYY-MM-DD",
+				)
+			},
+		},
+		{
+			name:  "End date range reversed",
+			query: "?end_date_from=2025-02-01&end_date_to=2025-01-01",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().GetAllSubscriptions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				require.Len(t, c.Errors, 1)
+				require.ErrorIs(t, c.Errors[0].Err, apperror.ErrInvalidDateRange)
+			},
+		},
+		{
+			name:  "Negative limit",
+			query: "?limit=-1",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().GetAllSubscriptions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				require.Len(t, c.Errors, 1)
+				require.ErrorIs(t, c.Errors[0].Err, apperror.ErrInvalidPagination)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+
+			subscriptionService := mocks.NewMockSubscriptionService(ctrl)
+			tc.buildStubs(subscriptionService)
+			subscriptionHandler := handler.NewSubscriptionHandler(
+				subscriptionService,
+				validator.NewAppValidator(),
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/subscriptions"+tc.query, nil)
+			rec := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(rec)
+			c.Set(authenticator.SubClaim, userID.String())
+			c.Request = req
+
+			subscriptionHandler.GetAllSubscriptionsHandler(c)
+
+			tc.checkResponse(t, c, rec)
+		})
+	}
+}
+
+// requireAppError checks that the handler failed with an app error with given status and message
+func requireAppError(t *testing.T, c *gin.Context, statusCode int, msg string) {
+	require.Len(t, c.Errors, 1)
+
+	var appError *apperror.AppError
+	require.ErrorAs(t, c.Errors[0].Err, &appError)
+	require.Equal(t, statusCode, appError.StatusCode)
+	require.Equal(t, msg, appError.Msg)
+}
+
+func TestGetUpcomingRenewalsHandler(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockSubscriptionService)
+		checkResponse func(*testing.T, *gin.Context, *httptest.ResponseRecorder)
+		name          string
+		query         string
+	}{
+		{
+			name:  "Date range",
+			query: "?from=2025-05-01&to=2025-05-31",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().
+					GetUpcomingRenewals(gomock.Any(), &service.GetUpcomingRenewalsRequest{
+						From:   time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC),
+						To:     time.Date(2025, time.May, 31, 0, 0, 0, 0, time.UTC),
+						UserID: userID,
+					}).
+					Times(1).
+					Return(&service.GetUpcomingRenewalsResponse{}, nil)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				require.Empty(t, c.Errors)
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:  "Default to is 30 days after from",
+			query: "?from=2025-05-01",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().
+					GetUpcomingRenewals(gomock.Any(), &service.GetUpcomingRenewalsRequest{
+						From:   time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC),
+						To:     time.Date(2025, time.May, 31, 0, 0, 0, 0, time.UTC),
+						UserID: userID,
+					}).
+					Times(1).
+					Return(&service.GetUpcomingRenewalsResponse{}, nil)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				require.Empty(t, c.Errors)
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:  "To before from",
+			query: "?from=2025-05-31&to=2025-05-01",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().GetUpcomingRenewals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				require.Len(t, c.Errors, 1)
+				require.ErrorIs(t, c.Errors[0].Err, apperror.ErrInvalidUpcomingRange)
+			},
+		},
+		{
+			name:  "Range longer than a year",
+			query: "?from=2025-01-01&to=2026-01-03",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().GetUpcomingRenewals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				require.Len(t, c.Errors, 1)
+				require.ErrorIs(t, c.Errors[0].Err, apperror.ErrInvalidUpcomingRange)
+			},
+		},
+		{
+			name:  "Invalid date",
+			query: "?from=05-01-2025",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().GetUpcomingRenewals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				requireAppError(
+					t,
+					c,
+					http.StatusBadRequest,
+					"invalid from, should be formatted as YYYY-MM-DD",
+				)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+
+			subscriptionService := mocks.NewMockSubscriptionService(ctrl)
+			tc.buildStubs(subscriptionService)
+			subscriptionHandler := handler.NewSubscriptionHandler(
+				subscriptionService,
+				validator.NewAppValidator(),
+			)
+
+			req := httptest.NewRequest(
+				http.MethodGet,
+				"/api/v1/subscriptions/upcoming"+tc.query,
+				nil,
+			)
+			rec := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(rec)
+			c.Set(authenticator.SubClaim, userID.String())
+			c.Request = req
+
+			subscriptionHandler.GetUpcomingRenewalsHandler(c)
+
+			tc.checkResponse(t, c, rec)
+		})
+	}
+}
+
+func TestUpdateSubscriptionHandler(t *testing.T) {
+	userID := uuid.New()
+	id := uuid.New()
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockSubscriptionService)
+		checkResponse func(*testing.T, *gin.Context, *httptest.ResponseRecorder)
+		name          string
+		id            string
+		body          string
+	}{
+		{
+			name: "Partial body",
+			id:   id.String(),
+			body: `{"name": "Spotify Family"}`,
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				name := "Spotify Family"
+				s.EXPECT().
+					UpdateSubscription(gomock.Any(), &service.UpdateSubscriptionRequest{
+						Name:   &name,
+						ID:     id,
+						UserID: userID,
+					}).
+					Times(1).
+					Return(&models.Subscription{ID: id, Name: name}, nil)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				require.Empty(t, c.Errors)
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name: "Owned by another user",
+			id:   id.String(),
+			body: `{"name": "Spotify Family"}`,
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().
+					UpdateSubscription(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, apperror.ErrSubscriptionNotFound)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				require.Len(t, c.Errors, 1)
+				require.ErrorIs(t, c.Errors[0].Err, apperror.ErrSubscriptionNotFound)
+			},
+		},
+		{
+			name: "Invalid id",
+			id:   "netflix",
+			body: `{"name": "Spotify Family"}`,
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				require.Len(t, c.Errors, 1)
+				require.ErrorIs(t, c.Errors[0].Err, apperror.ErrInvalidUUID)
+			},
+		},
+		{
+			name: "Name too short",
+			id:   id.String(),
+			body: `{"name": "TV"}`,
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, c *gin.Context, rec *httptest.ResponseRecorder) {
+				// validation errors are mapped to a bad request by the error middleware
+				require.Len(t, c.Errors, 1)
+				require.ErrorContains(t, c.Errors[0].Err, "'min' tag")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+
+			subscriptionService := mocks.NewMockSubscriptionService(ctrl)
+			tc.buildStubs(subscriptionService)
+			subscriptionHandler := handler.NewSubscriptionHandler(
+				subscriptionService,
+				validator.NewAppValidator(),
+			)
+
+			req := httptest.NewRequest(
+				http.MethodPatch,
+				"/api/v1/subscriptions/"+tc.id,
+				strings.NewReader(tc.body),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(rec)
+			c.Set(authenticator.SubClaim, userID.String())
+			c.Params = gin.Params{{Key: "id", Value: tc.id}}
+			c.Request = req
+
+			subscriptionHandler.UpdateSubscriptionHandler(c)
+
+			tc.checkResponse(t, c, rec)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/response"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/internal/utils"
+)
+
+type tagHandler struct {
+	s service.TagService
+	v validator.Validator
+}
+
+func NewTagHandler(s service.TagService, v validator.Validator) *tagHandler {
+	return &tagHandler{
+		s,
+		v,
+	}
+}
+
+// GetAllTagsHandler godoc
+//
+//	@Summary		Get all tags
+//	@Description	Get all tags of current user ordered by name
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		models.Tag
+//	@Failure		500	{object}	error
+//	@Router			/tags/ [get]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *tagHandler) GetAllTagsHandler(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.GetAllTags(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("get all tags successfully", res))
+}
+
+// CreateTagHandler godoc
+//
+//	@Summary		Create tag
+//	@Description	Create a tag, names are unique per user
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			tag	body		service.CreateTagRequest	true	"Create tag request"
+//	@Success		201	{object}	models.Tag
+//	@Failure		400	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/tags/ [post]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *tagHandler) CreateTagHandler(c *gin.Context) {
+	var req service.CreateTagRequest
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	res, err := h.s.CreateTag(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewAppResponse("created tag successfully", res))
+}
+
+// UpdateTagHandler godoc
+//
+//	@Summary		Update tag
+//	@Description	Rename a tag
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string						true	"Tag ID"
+//	@Param			tag	body		service.UpdateTagRequest	true	"Update tag request"
+//	@Success		200	{object}	models.Tag
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/tags/{id} [patch]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *tagHandler) UpdateTagHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	var req service.UpdateTagRequest
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = h.v.Validate(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req.UserID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	req.ID = id
+
+	res, err := h.s.UpdateTag(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("updated tag successfully", res))
+}
+
+// DeleteTagHandler godoc
+//
+//	@Summary		Delete tag
+//	@Description	Delete a tag, it is removed from its subscriptions
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Tag ID"
+//	@Success		200	{object}	response.AppResponse
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/tags/{id} [delete]
+//
+// This tells that this handler is protected by an API key
+//
+//	@Security		ApiKeyAuth
+func (h *tagHandler) DeleteTagHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidUUID)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = h.s.DeleteTag(c.Request.Context(), id, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAppResponse("deleted tag successfully", nil))
+}
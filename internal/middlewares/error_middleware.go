@@ -34,6 +34,11 @@ func ErrorMiddleware(c *gin.Context) {
 
 			fmt.Println(appError.Msg)
 
+			// a streamed response can fail after its status is sent, it can not be replaced
+			if c.Writer.Written() {
+				return
+			}
+
 			c.JSON(appError.StatusCode, gin.H{"success": appError.Success, "errors": appError.Msg})
 			return
 		}
@@ -50,7 +50,8 @@ func GZipMiddleware(c *gin.Context) {
 	// Check URL path for file extensions.
 	// This is a more reliable way to check if we want to compress this content type
 	path := c.Request.URL.Path
-	if strings.HasSuffix(path, ".jpg") || strings.HasSuffix(path, ".mp4") {
+	if strings.HasSuffix(path, ".jpg") || strings.HasSuffix(path, ".mp4") ||
+		strings.HasSuffix(path, ".zip") {
 		c.Next()
 		return
 	}
@@ -13,21 +13,118 @@ type User struct {
 	Email     string
 	Password  string
 	ID        uuid.UUID
+	// IsAdmin is only granted by the operator in the database, it lets the user edit the service catalog
+	IsAdmin bool
 }
 
 type Subscription struct {
+	CreatedAt time.Time        `json:"created_at"`
 	StartDate SubscriptionTime `json:"start_date"`
 	EndDate   SubscriptionTime `json:"end_date"`
-	Name      string           `json:"name,omitempty"`
-	ID        uuid.UUID        `json:"id,omitempty"`
-	UserID    uuid.UUID        `json:"user_id,omitempty"`
+
+	// Amount is stored in minor units (e.g. cents) and Currency is an ISO 4217 code.
+	// Both are nil when the price of the subscription is unknown.
+	Amount      *int64     `json:"amount,omitempty"`
+	Currency    *string    `json:"currency,omitempty"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+
+	// TrialEndDate is set when the subscription started with a free trial,
+	// PostTrialAmount is the price it converts to when the trial ends
+	TrialEndDate    *SubscriptionTime `json:"trial_end_date,omitempty"    swaggertype:"string" example:"2025-02-15"`
+	PostTrialAmount *int64            `json:"post_trial_amount,omitempty"`
+
+	// CategoryID is nil when the subscription is uncategorized
+	CategoryID *uuid.UUID `json:"category_id,omitempty"`
+
+	Notes *string `json:"notes,omitempty"`
+
+	// PaymentMethodID is nil when the subscription is not linked to a payment method
+	PaymentMethodID *uuid.UUID `json:"payment_method_id,omitempty"`
+
+	Name   string `json:"name,omitempty"`
+	Status string `json:"status"                 enums:"active, cancelled, ended"`
 
 	// Duration is a custom type that can be marshaled and unmarshaled
 	// to and from a string, but swagger does not see this so we need to specify it in struct tag swaggertype.
 	//
-	// enums struct tag also helps us to document the enum values in swagger
-	Duration    enums.Duration `json:"duration,omitempty" swaggertype:"string" enums:"weekly, monthly, 6 months, yearly"`
-	IsCancelled bool           `json:"is_cancelled"`
+	// Legacy durations are marshaled to "weekly", "monthly", "6 months" or "yearly",
+	// any other interval is marshaled to "<count> <unit>s" like "45 days"
+	Duration enums.Duration `json:"duration,omitempty" swaggertype:"string" example:"monthly"`
+
+	TagIDs []uuid.UUID `json:"tag_ids"`
+
+	ID     uuid.UUID `json:"id,omitempty"`
+	UserID uuid.UUID `json:"user_id,omitempty"`
+
+	// BillingAnchorDay is the day of month the subscription renews on,
+	// it is clamped to the last day of short months
+	BillingAnchorDay int `json:"billing_anchor_day" example:"31"`
+
+	IsCancelled bool `json:"is_cancelled"`
+
+	// InTrial means the current period is the free trial
+	InTrial bool `json:"in_trial"`
+
+	// CancelAtPeriodEnd means the subscription was cancelled but stays active until EndDate
+	CancelAtPeriodEnd bool `json:"cancel_at_period_end"`
+
+	// IsShared means the subscription is owned by another user who shared it with the current user
+	IsShared bool `json:"is_shared"`
+}
+
+// Lifecycle of a subscription, a cancelled subscription stays cancelled until its end date
+// and then becomes ended, which is terminal
+const (
+	SubscriptionStatusActive    = "active"
+	SubscriptionStatusCancelled = "cancelled"
+	SubscriptionStatusEnded     = "ended"
+)
+
+var AllSubscriptionStatuses = []string{
+	SubscriptionStatusActive,
+	SubscriptionStatusCancelled,
+	SubscriptionStatusEnded,
+}
+
+// Fields the subscription list can be sorted by, price sorts by amount
+const (
+	SubscriptionSortName      = "name"
+	SubscriptionSortStartDate = "start_date"
+	SubscriptionSortEndDate   = "end_date"
+	SubscriptionSortPrice     = "price"
+	SubscriptionSortCreatedAt = "created_at"
+)
+
+var AllSubscriptionSorts = []string{
+	SubscriptionSortName,
+	SubscriptionSortStartDate,
+	SubscriptionSortEndDate,
+	SubscriptionSortPrice,
+	SubscriptionSortCreatedAt,
+}
+
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// Category groups subscriptions, e.g. "streaming" or "dev tools",
+// a subscription belongs to at most one category
+type Category struct {
+	CreatedAt time.Time `json:"created_at"`
+	Name      string    `json:"name"       example:"streaming"`
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+// Tag is a free-form label, a subscription can have many tags
+type Tag struct {
+	CreatedAt time.Time `json:"created_at"`
+	Name      string    `json:"name"       example:"family"`
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
 }
 
 type Session struct {
@@ -47,6 +144,158 @@ type AuthProvider struct {
 	UserID     uuid.UUID
 }
 
+// SubscriptionPrice is a price of a subscription from EffectiveFrom until the next price,
+// a price change usually takes effect at the next renewal
+type SubscriptionPrice struct {
+	EffectiveFrom  SubscriptionTime `json:"effective_from" swaggertype:"string" example:"2025-02-15"`
+	CreatedAt      time.Time        `json:"created_at"`
+	Currency       string           `json:"currency"       example:"USD"`
+	ID             uuid.UUID        `json:"id"`
+	SubscriptionID uuid.UUID        `json:"subscription_id"`
+	// Amount is in minor units (e.g. cents)
+	Amount int64 `json:"amount" example:"1599"`
+}
+
+// Charge is what a billing period of a subscription was charged, it is never updated.
+// SubscriptionID is nil once the subscription is purged
+type Charge struct {
+	PeriodStart      SubscriptionTime `json:"period_start"      swaggertype:"string" example:"2025-01-15"`
+	PeriodEnd        SubscriptionTime `json:"period_end"        swaggertype:"string" example:"2025-02-15"`
+	CreatedAt        time.Time        `json:"created_at"`
+	Amount           *int64           `json:"amount,omitempty"                        example:"1599"`
+	Currency         *string          `json:"currency,omitempty"                      example:"USD"`
+	SubscriptionID   *uuid.UUID       `json:"subscription_id,omitempty"`
+	SubscriptionName string           `json:"subscription_name"                       example:"Netflix"`
+	ID               uuid.UUID        `json:"id"`
+	UserID           uuid.UUID        `json:"user_id"`
+}
+
+// Budget caps the monthly spend of a user in a currency, amounts are in minor units.
+// Duration limits the budget to subscriptions billed at this interval, it is nil for every subscription
+type Budget struct {
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Duration  *enums.Duration `json:"duration,omitempty" swaggertype:"string" example:"monthly"`
+	Currency  string          `json:"currency"                                example:"USD"`
+	ID        uuid.UUID       `json:"id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	Amount    int64           `json:"amount"                                  example:"5000"`
+}
+
+// SubscriptionMember is a user a subscription is shared with.
+// The owner pays the subscription and the member owes either SharePercent of the price or ShareAmount
+type SubscriptionMember struct {
+	InvitedAt    time.Time  `json:"invited_at"`
+	RespondedAt  *time.Time `json:"responded_at,omitempty"`
+	SharePercent *int       `json:"share_percent,omitempty" example:"25"`
+	ShareAmount  *int64     `json:"share_amount,omitempty"  example:"499"`
+	Email        string     `json:"email"                   example:"jane@example.com"`
+	Status       string     `json:"status"                  enums:"pending, accepted, declined"`
+	ID           uuid.UUID  `json:"id"`
+	// SubscriptionID is the shared subscription, UserID is the member
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	UserID         uuid.UUID `json:"user_id"`
+}
+
+// Statuses of a subscription member, a declined member can be invited again
+const (
+	MemberStatusPending  = "pending"
+	MemberStatusAccepted = "accepted"
+	MemberStatusDeclined = "declined"
+)
+
+// SubscriptionInvitation is a pending invitation to share a subscription, as seen by the invitee
+type SubscriptionInvitation struct {
+	InvitedAt        time.Time      `json:"invited_at"`
+	Amount           *int64         `json:"amount,omitempty"        example:"1999"`
+	Currency         *string        `json:"currency,omitempty"      example:"USD"`
+	SharePercent     *int           `json:"share_percent,omitempty" example:"25"`
+	ShareAmount      *int64         `json:"share_amount,omitempty"  example:"499"`
+	SubscriptionName string         `json:"subscription_name"       example:"Spotify Family"`
+	OwnerEmail       string         `json:"owner_email"             example:"john@example.com"`
+	Duration         enums.Duration `json:"duration"                swaggertype:"string" example:"monthly"`
+	ID               uuid.UUID      `json:"id"`
+	SubscriptionID   uuid.UUID      `json:"subscription_id"`
+}
+
+// CatalogEntry is a well-known service of the catalog with its default plans
+type CatalogEntry struct {
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	Slug      string         `json:"slug"       example:"spotify"`
+	Name      string         `json:"name"       example:"Spotify"`
+	LogoURL   string         `json:"logo_url"   example:"https://icons.duckduckgo.com/ip3/spotify.com.ico"`
+	CancelURL string         `json:"cancel_url" example:"https://www.spotify.com/account/subscription/"`
+	Plans     []*CatalogPlan `json:"plans"`
+	ID        uuid.UUID      `json:"id"`
+}
+
+// CatalogPlan is a default plan of a catalog service, Amount is in minor units (e.g. cents)
+// and both Amount and Currency are nil when the price is unknown
+type CatalogPlan struct {
+	Amount    *int64         `json:"amount,omitempty"   example:"1199"`
+	Currency  *string        `json:"currency,omitempty" example:"USD"`
+	Slug      string         `json:"slug"               example:"premium-individual"`
+	Name      string         `json:"name"               example:"Premium Individual"`
+	Duration  enums.Duration `json:"duration"           swaggertype:"string"         example:"monthly"`
+	ID        uuid.UUID      `json:"id"`
+	ServiceID uuid.UUID      `json:"service_id"`
+}
+
+// Attachment is a file like an invoice or a receipt attached to a subscription,
+// ContentType is sniffed from the content and Size is in bytes.
+// SubscriptionID is nil once the subscription is purged
+type Attachment struct {
+	CreatedAt      time.Time  `json:"created_at"`
+	SubscriptionID *uuid.UUID `json:"subscription_id,omitempty"`
+	FileName       string     `json:"file_name"                 example:"invoice-2025-01.pdf"`
+	ContentType    string     `json:"content_type"              example:"application/pdf"`
+	// StorageKey is the key of the content in the blob storage
+	StorageKey string    `json:"-"`
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Size       int64     `json:"size"      example:"48213"`
+}
+
+// PaymentMethod is a card subscriptions are paid with, only its last four digits are kept.
+// The card expires at the end of its expiry month
+type PaymentMethod struct {
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Label       string    `json:"label"        example:"Personal card"`
+	Brand       string    `json:"brand"        example:"visa"`
+	Last4       string    `json:"last4"        example:"4242"`
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	ExpiryMonth int       `json:"expiry_month" example:"8"`
+	ExpiryYear  int       `json:"expiry_year"  example:"2027"`
+}
+
+// ExpiresAt returns the first instant the card can no longer be charged,
+// the start of the month after its expiry month
+func (p *PaymentMethod) ExpiresAt() time.Time {
+	return time.Date(p.ExpiryYear, time.Month(p.ExpiryMonth)+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Kinds of reminder emails
+const (
+	ReminderKindRenewal       = "renewal"
+	ReminderKindTrial         = "trial"
+	ReminderKindPriceIncrease = "price_increase"
+)
+
+// ReminderLog records a reminder email sent before a renewal or the end of a free trial,
+// SubscriptionID is nil once the subscription is purged
+type ReminderLog struct {
+	SentAt           time.Time  `json:"sent_at"`
+	SubscriptionID   *uuid.UUID `json:"subscription_id"`
+	SubscriptionName string     `json:"subscription_name" example:"Netflix"`
+	Kind             string     `json:"kind"              example:"renewal"   enums:"renewal, trial"`
+	ID               uuid.UUID  `json:"id"`
+	UserID           uuid.UUID  `json:"user_id"`
+	DaysBefore       int        `json:"days_before"       example:"7"`
+}
+
 // create this type to enable marshal and unmarshal from format "YYYY-mm-dd"
 // if using normal time.Time, when unmarshal will occur error
 type SubscriptionTime time.Time
@@ -16,13 +16,149 @@ var (
 	)
 	ErrExisted          = NewAppError(http.StatusBadRequest, "resource has already existed")
 	ErrUnAuthorized     = NewAppError(http.StatusUnauthorized, "user unauthorized")
+	ErrForbidden        = NewAppError(http.StatusForbidden, "admin permission is required")
 	ErrTokenExpired     = NewAppError(http.StatusUnauthorized, "token is expired")
 	ErrInvalidUUID      = NewAppError(http.StatusBadRequest, "invalid uuid format")
 	ErrInvalidEmailData = NewAppError(
 		http.StatusBadRequest,
 		"invalid email data with template option",
 	)
-	ErrSendEmail = NewAppError(http.StatusInternalServerError, "could not send email")
+	ErrSendEmail             = NewAppError(http.StatusInternalServerError, "could not send email")
+	ErrSubscriptionNotFound  = NewAppError(http.StatusNotFound, "subscription not found")
+	ErrSubscriptionCancelled = NewAppError(
+		http.StatusBadRequest,
+		"subscription has already been cancelled",
+	)
+	ErrSubscriptionNotCancelled = NewAppError(
+		http.StatusBadRequest,
+		"subscription is not cancelled",
+	)
+	ErrSubscriptionEnded = NewAppError(http.StatusBadRequest, "subscription has already ended")
+	ErrInvalidStatus     = NewAppError(http.StatusBadRequest, "invalid subscription status")
+	ErrInvalidSort       = NewAppError(
+		http.StatusBadRequest,
+		"invalid sort, should be one of: name, start_date, end_date, price, created_at",
+	)
+	ErrInvalidSortOrder = NewAppError(http.StatusBadRequest, "invalid order, should be asc or desc")
+	ErrInvalidDateRange = NewAppError(
+		http.StatusBadRequest,
+		"end_date_from should be before or equal to end_date_to",
+	)
+	ErrInvalidPagination = NewAppError(
+		http.StatusBadRequest,
+		"limit and offset should be non negative integers",
+	)
+	ErrInvalidCursor = NewAppError(
+		http.StatusBadRequest,
+		"invalid cursor, only one of after and before can be used with the sort it was returned for",
+	)
+	ErrCategoryNotFound      = NewAppError(http.StatusNotFound, "category not found")
+	ErrTagNotFound           = NewAppError(http.StatusNotFound, "tag not found")
+	ErrUserNotFound          = NewAppError(http.StatusNotFound, "user not found")
+	ErrCalendarNotFound      = NewAppError(http.StatusNotFound, "calendar not found")
+	ErrExportNotFound        = NewAppError(http.StatusNotFound, "export not found or expired")
+	ErrBudgetNotFound        = NewAppError(http.StatusNotFound, "budget not found")
+	ErrMemberNotFound        = NewAppError(http.StatusNotFound, "member not found")
+	ErrInvitationNotFound    = NewAppError(http.StatusNotFound, "invitation not found")
+	ErrInviteeNotFound       = NewAppError(http.StatusNotFound, "no registered user with this email")
+	ErrCatalogNotFound       = NewAppError(http.StatusNotFound, "catalog service not found")
+	ErrCatalogPlanNotFound   = NewAppError(http.StatusNotFound, "catalog plan not found")
+	ErrAttachmentNotFound    = NewAppError(http.StatusNotFound, "attachment not found")
+	ErrPaymentMethodNotFound = NewAppError(http.StatusNotFound, "payment method not found")
+	ErrCurrencyRequired      = NewAppError(http.StatusBadRequest, "currency is required with amount")
+	ErrInvalidTrialEndDate   = NewAppError(
+		http.StatusBadRequest,
+		"trial end date must be after start date",
+	)
+	ErrInvalidUpcomingRange = NewAppError(
+		http.StatusBadRequest,
+		"from should be before or equal to to, and the range should not exceed 366 days",
+	)
+	ErrImportFileRequired = NewAppError(
+		http.StatusBadRequest,
+		"a csv file should be uploaded in the file form field",
+	)
+	ErrInvalidImportHeader = NewAppError(
+		http.StatusBadRequest,
+		"the first line of the csv file should be a header with name, start_date and duration columns",
+	)
+	ErrImportTooLarge = NewAppError(
+		http.StatusRequestEntityTooLarge,
+		"csv file should be at most 1MB with at most 1000 subscriptions",
+	)
+	ErrStatementFileRequired = NewAppError(
+		http.StatusBadRequest,
+		"a statement file should be uploaded in the file form field",
+	)
+	ErrUnknownStatementFormat = NewAppError(
+		http.StatusBadRequest,
+		"statement should be an OFX/QFX file or a csv file with date, description and amount or debit columns",
+	)
+	ErrStatementTooLarge = NewAppError(
+		http.StatusRequestEntityTooLarge,
+		"statement file should be at most 2MB with at most 10000 transactions",
+	)
+	ErrStatementCurrencyRequired = NewAppError(
+		http.StatusBadRequest,
+		"the statement has no currency, it should be sent in the currency query parameter",
+	)
+	ErrExportTooLarge = NewAppError(
+		http.StatusConflict,
+		"too many subscriptions to download directly, queue an emailed export with POST /export instead",
+	)
+	ErrExportPending = NewAppError(
+		http.StatusConflict,
+		"an export was already queued, use its emailed link or queue a new one once it expired",
+	)
+	ErrInvalidTop  = NewAppError(http.StatusBadRequest, "top should be an integer between 1 and 50")
+	ErrInviteOwner = NewAppError(
+		http.StatusBadRequest,
+		"the owner can not be invited to their own subscription",
+	)
+	ErrAlreadyMember = NewAppError(
+		http.StatusBadRequest,
+		"the user is already invited to or a member of this subscription",
+	)
+	ErrInvalidShare = NewAppError(
+		http.StatusBadRequest,
+		"exactly one of share_percent and share_amount should be set",
+	)
+	ErrShareExceeded = NewAppError(
+		http.StatusBadRequest,
+		"shares of members should not exceed the price of the subscription",
+	)
+	ErrInvalidChargeRange = NewAppError(
+		http.StatusBadRequest,
+		"from should be before or equal to to",
+	)
+	ErrInvalidCatalogLimit = NewAppError(
+		http.StatusBadRequest,
+		"limit should be an integer between 1 and 50",
+	)
+	ErrDuplicatedPlan = NewAppError(
+		http.StatusBadRequest,
+		"plan slugs should be unique within a service",
+	)
+	ErrAttachmentFileRequired = NewAppError(
+		http.StatusBadRequest,
+		"a non empty file should be uploaded in the file form field",
+	)
+	ErrAttachmentTooLarge = NewAppError(
+		http.StatusRequestEntityTooLarge,
+		"attached file is too large",
+	)
+	ErrAttachmentQuotaExceeded = NewAppError(
+		http.StatusRequestEntityTooLarge,
+		"attachment storage quota exceeded, delete some attachments first",
+	)
+	ErrUnsupportedAttachmentType = NewAppError(
+		http.StatusUnsupportedMediaType,
+		"only pdf, png, jpeg, gif, webp and plain text files can be attached",
+	)
+	ErrCardNumberNotAllowed = NewAppError(
+		http.StatusBadRequest,
+		"full card numbers are never stored, only send the last four digits",
+	)
 )
 
 type AppError struct {
@@ -49,6 +185,14 @@ func NewAppError(statusCode int, msg any) *AppError {
 }
 
 func HandleValidateErrors(errors validator.ValidationErrors) *AppError {
+	return &AppError{
+		StatusCode: http.StatusBadRequest,
+		Msg:        ToValidateErrors(errors),
+	}
+}
+
+// ToValidateErrors converts errors to the messages returned to clients
+func ToValidateErrors(errors validator.ValidationErrors) []ValidateError {
 	var errorArr []ValidateError
 	for _, err := range errors {
 		var validateError ValidateError
@@ -58,15 +202,14 @@ func HandleValidateErrors(errors validator.ValidationErrors) *AppError {
 		errorArr = append(errorArr, validateError)
 	}
 
-	return &AppError{
-		StatusCode: http.StatusBadRequest,
-		Msg:        errorArr,
-	}
+	return errorArr
 }
 
 func getErrMsg(err validator.FieldError) string {
 	switch err.Tag() {
-	case "required":
+	// required_without is only used where the other field is optional,
+	// e.g. the name of a subscription which is not created from the catalog
+	case "required", "required_without":
 		return "this field is required"
 	case "email":
 		return "invalid email format"
@@ -76,6 +219,20 @@ func getErrMsg(err validator.FieldError) string {
 		return "should be at most " + err.Param() + " length"
 	case "gte":
 		return "should be greater than or equal to " + err.Param()
+	case "lte":
+		return "should be less than or equal to " + err.Param()
+	case "required_with":
+		return "this field is required when " + strings.ToLower(err.Param()) + " is present"
+	case "url":
+		return "should be a valid url"
+	case "iso4217":
+		return "should be a valid ISO 4217 currency code"
+	case "oneof":
+		return "should be one of: " + strings.ReplaceAll(err.Param(), " ", ", ")
+	case "len":
+		return "should be exactly " + err.Param() + " length"
+	case "numeric":
+		return "should only contain digits"
 	default:
 		return "invalid value"
 	}
@@ -0,0 +1,40 @@
+// Package catalog holds the built-in list of well-known services and their default plans,
+// it is seeded into the catalog tables on startup and can be edited by admins afterwards
+package catalog
+
+import (
+	_ "embed"
+	"encoding/json"
+
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+)
+
+//go:embed catalog.json
+var catalogJSON []byte
+
+// Plan is a default plan of a service, amount is in minor units of currency
+type Plan struct {
+	Amount   *int64         `json:"amount"`
+	Slug     string         `json:"slug"`
+	Name     string         `json:"name"`
+	Currency string         `json:"currency"`
+	Duration enums.Duration `json:"duration"`
+}
+
+type Service struct {
+	Slug      string `json:"slug"`
+	Name      string `json:"name"`
+	LogoURL   string `json:"logo_url"`
+	CancelURL string `json:"cancel_url"`
+	Plans     []Plan `json:"plans"`
+}
+
+// Load parses the embedded catalog
+func Load() ([]*Service, error) {
+	var services []*Service
+	if err := json.Unmarshal(catalogJSON, &services); err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
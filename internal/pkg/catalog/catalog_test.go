@@ -0,0 +1,38 @@
+package catalog_test
+
+import (
+	"testing"
+
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/catalog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	services, err := catalog.Load()
+	require.NoError(t, err)
+	require.NotEmpty(t, services)
+
+	slugs := map[string]bool{}
+	for _, service := range services {
+		require.NotEmpty(t, service.Name)
+		require.NotEmpty(t, service.CancelURL)
+		require.False(t, slugs[service.Slug], "duplicated service slug %s", service.Slug)
+		slugs[service.Slug] = true
+
+		require.NotEmpty(t, service.Plans, service.Slug)
+
+		plans := map[string]bool{}
+		for _, plan := range service.Plans {
+			require.False(t, plans[plan.Slug], "duplicated plan slug %s/%s", service.Slug, plan.Slug)
+			plans[plan.Slug] = true
+
+			require.True(t, plan.Duration.IsValid(), plan.Slug)
+			require.Len(t, plan.Currency, 3, plan.Slug)
+			require.NotNil(t, plan.Amount, plan.Slug)
+			require.Positive(t, *plan.Amount, plan.Slug)
+
+			// the prefilled subscription name is "<service> <plan>" and subscription names are at most 50 chars
+			require.LessOrEqual(t, len(service.Name+" "+plan.Name), 50, plan.Slug)
+		}
+	}
+}
@@ -1,44 +1,155 @@
 package enums
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
 )
 
-type Duration int
+type IntervalUnit string
 
 const (
-	Weekly Duration = iota + 1
-	Monthly
-	SixMonths
-	Yearly
+	Day   IntervalUnit = "day"
+	Week  IntervalUnit = "week"
+	Month IntervalUnit = "month"
+	Year  IntervalUnit = "year"
+)
+
+var AllIntervalUnits = []IntervalUnit{Day, Week, Month, Year}
+
+// MaxIntervalCount is the biggest count allowed for any unit, e.g. every 365 days
+const MaxIntervalCount = 365
+
+// Duration is a billing interval made of a count and a unit,
+// e.g. every 3 months, every 2 weeks or every 45 days
+type Duration struct {
+	Unit  IntervalUnit
+	Count int
+}
+
+// Durations supported before intervals were introduced,
+// they are still marshaled to their legacy names to stay backwards compatible
+var (
+	Weekly    = Duration{Unit: Week, Count: 1}
+	Monthly   = Duration{Unit: Month, Count: 1}
+	SixMonths = Duration{Unit: Month, Count: 6}
+	Yearly    = Duration{Unit: Year, Count: 1}
 )
 
 var AllDurations = []string{"weekly", "monthly", "6 months", "yearly"}
 
+var legacyDurations = map[string]Duration{
+	"weekly":   Weekly,
+	"monthly":  Monthly,
+	"6 months": SixMonths,
+	"yearly":   Yearly,
+}
+
+// aliases are only accepted when parsing, they are never returned
+var durationAliases = map[string]Duration{
+	"daily":     {Unit: Day, Count: 1},
+	"biweekly":  {Unit: Week, Count: 2},
+	"bi-weekly": {Unit: Week, Count: 2},
+	"quarterly": {Unit: Month, Count: 3},
+	"annually":  Yearly,
+}
+
+var ErrInvalidDuration = apperror.NewAppError(http.StatusBadRequest, "invalid duration")
+
+// NewDuration creates a Duration and validates count and unit
+func NewDuration(count int, unit string) (Duration, error) {
+	d := Duration{Unit: IntervalUnit(strings.ToLower(unit)), Count: count}
+	if !d.IsValid() {
+		return Duration{}, ErrInvalidDuration
+	}
+
+	return d, nil
+}
+
+func (d *Duration) IsValid() bool {
+	if d.Count < 1 || d.Count > MaxIntervalCount {
+		return false
+	}
+
+	for _, unit := range AllIntervalUnits {
+		if d.Unit == unit {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (d *Duration) String() string {
-	return AllDurations[*d-1]
+	for name, duration := range legacyDurations {
+		if *d == duration {
+			return name
+		}
+	}
+
+	if d.Count == 1 {
+		return fmt.Sprintf("%d %s", d.Count, d.Unit)
+	}
+
+	return fmt.Sprintf("%d %ss", d.Count, d.Unit)
 }
 
+// ParseString2Duration accepts legacy names ("weekly", "6 months", ...),
+// aliases ("quarterly", "biweekly", ...) and "<count> <unit>" like "45 days" or "every 2 years"
 func ParseString2Duration(s string) (Duration, error) {
-	for i, duration := range AllDurations {
-		if s == duration {
-			return Duration(i + 1), nil
-		}
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if duration, ok := legacyDurations[s]; ok {
+		return duration, nil
+	}
+
+	if duration, ok := durationAliases[s]; ok {
+		return duration, nil
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(s, "every "))
+	if len(fields) != 2 {
+		return Duration{}, ErrInvalidDuration
 	}
 
-	return Duration(0), apperror.NewAppError(http.StatusBadRequest, "invalid duration")
+	count, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return Duration{}, ErrInvalidDuration
+	}
+
+	return NewDuration(count, strings.TrimSuffix(fields[1], "s"))
 }
 
 func (d *Duration) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + d.String() + `"`), nil
 }
 
-// this is also validate for duration
+// this is also validate for duration,
+// it accepts a string or an object like {"count": 45, "unit": "day"}
 func (d *Duration) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '{' {
+		var obj struct {
+			Unit  string `json:"unit"`
+			Count int    `json:"count"`
+		}
+		if err := json.Unmarshal(b, &obj); err != nil {
+			return ErrInvalidDuration
+		}
+
+		duration, err := NewDuration(obj.Count, obj.Unit)
+		if err != nil {
+			return err
+		}
+
+		*d = duration
+		return nil
+	}
+
 	s := string(b)
 	s = strings.Trim(s, `"`)
 
@@ -52,19 +163,61 @@ func (d *Duration) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// AddDurationToTime adds the duration to start, using the day of start as billing anchor day
 func (d *Duration) AddDurationToTime(start time.Time) time.Time {
-	var end time.Time
-
-	switch *d {
-	case Weekly:
-		end = start.AddDate(0, 0, 7)
-	case Monthly:
-		end = start.AddDate(0, 1, 0)
-	case SixMonths:
-		end = start.AddDate(0, 6, 0)
-	case Yearly:
-		end = start.AddDate(1, 0, 0)
+	return d.AddDurationToTimeWithAnchor(start, start.Day())
+}
+
+// AddDurationToTimeWithAnchor adds the duration to start,
+// monthly and yearly intervals land on anchorDay clamped to the last day of the month,
+// so Jan 31 renews on Feb 28 (or Feb 29) and snaps back to Mar 31 afterwards.
+//
+// anchorDay outside of 1..31 falls back to the day of start
+func (d *Duration) AddDurationToTimeWithAnchor(start time.Time, anchorDay int) time.Time {
+	if anchorDay < 1 || anchorDay > 31 {
+		anchorDay = start.Day()
 	}
 
-	return end
+	switch d.Unit {
+	case Day:
+		return start.AddDate(0, 0, d.Count)
+	case Week:
+		return start.AddDate(0, 0, 7*d.Count)
+	case Month:
+		return addMonthsWithAnchor(start, d.Count, anchorDay)
+	case Year:
+		return addMonthsWithAnchor(start, 12*d.Count, anchorDay)
+	}
+
+	return time.Time{}
+}
+
+// SubtractDurationFromTimeWithAnchor is the reverse of AddDurationToTimeWithAnchor,
+// it returns the start of the period ending at end, so Mar 31 - 1 month is Feb 29 in a leap year
+func (d *Duration) SubtractDurationFromTimeWithAnchor(end time.Time, anchorDay int) time.Time {
+	reversed := Duration{Unit: d.Unit, Count: -d.Count}
+
+	return reversed.AddDurationToTimeWithAnchor(end, anchorDay)
+}
+
+// addMonthsWithAnchor does not use time.AddDate because it normalizes overflowing days,
+// e.g. Jan 31 + 1 month is Mar 3 instead of Feb 28
+func addMonthsWithAnchor(start time.Time, months int, anchorDay int) time.Time {
+	// month of the first day is always valid, so AddDate can not overflow here
+	firstDay := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+	firstDay = firstDay.AddDate(0, months, 0)
+
+	// day 0 of next month is the last day of this month
+	lastDay := time.Date(firstDay.Year(), firstDay.Month()+1, 0, 0, 0, 0, 0, start.Location()).Day()
+
+	return time.Date(
+		firstDay.Year(),
+		firstDay.Month(),
+		min(anchorDay, lastDay),
+		start.Hour(),
+		start.Minute(),
+		start.Second(),
+		start.Nanosecond(),
+		start.Location(),
+	)
 }
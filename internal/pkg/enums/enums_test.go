@@ -0,0 +1,321 @@
+package enums_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseString2Duration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected enums.Duration
+		hasError bool
+	}{
+		{name: "Legacy weekly", input: "weekly", expected: enums.Weekly},
+		{name: "Legacy monthly", input: "monthly", expected: enums.Monthly},
+		{name: "Legacy 6 months", input: "6 months", expected: enums.SixMonths},
+		{name: "Legacy yearly", input: "yearly", expected: enums.Yearly},
+		{
+			name:     "Alias quarterly",
+			input:    "quarterly",
+			expected: enums.Duration{Unit: enums.Month, Count: 3},
+		},
+		{
+			name:     "Alias bi-weekly",
+			input:    "bi-weekly",
+			expected: enums.Duration{Unit: enums.Week, Count: 2},
+		},
+		{
+			name:     "Count and plural unit",
+			input:    "45 days",
+			expected: enums.Duration{Unit: enums.Day, Count: 45},
+		},
+		{
+			name:     "Count and unit with every prefix",
+			input:    "every 2 years",
+			expected: enums.Duration{Unit: enums.Year, Count: 2},
+		},
+		{
+			name:     "Count and singular unit",
+			input:    "1 Month",
+			expected: enums.Monthly,
+		},
+		{name: "Unknown unit", input: "3 decades", hasError: true},
+		{name: "Zero count", input: "0 days", hasError: true},
+		{name: "Count too big", input: "366 days", hasError: true},
+		{name: "Empty string", input: "", hasError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			duration, err := enums.ParseString2Duration(tc.input)
+
+			if tc.hasError {
+				require.ErrorIs(t, err, enums.ErrInvalidDuration)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, duration)
+		})
+	}
+}
+
+func TestDurationJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		output   string
+		expected enums.Duration
+	}{
+		{
+			name:     "Legacy string stays legacy",
+			input:    `"6 months"`,
+			output:   `"6 months"`,
+			expected: enums.SixMonths,
+		},
+		{
+			name:     "Alias is marshaled as count and unit",
+			input:    `"quarterly"`,
+			output:   `"3 months"`,
+			expected: enums.Duration{Unit: enums.Month, Count: 3},
+		},
+		{
+			name:     "Object with count and unit",
+			input:    `{"count": 45, "unit": "day"}`,
+			output:   `"45 days"`,
+			expected: enums.Duration{Unit: enums.Day, Count: 45},
+		},
+		{
+			name:     "Object matching a legacy duration",
+			input:    `{"count": 1, "unit": "year"}`,
+			output:   `"yearly"`,
+			expected: enums.Yearly,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var duration enums.Duration
+			err := json.Unmarshal([]byte(tc.input), &duration)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, duration)
+
+			b, err := json.Marshal(&duration)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.output, string(b))
+		})
+	}
+}
+
+func TestAddDurationToTime(t *testing.T) {
+	start := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		expected time.Time
+		name     string
+		duration enums.Duration
+	}{
+		{
+			name:     "Every 45 days",
+			duration: enums.Duration{Unit: enums.Day, Count: 45},
+			expected: time.Date(2025, time.April, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Bi-weekly",
+			duration: enums.Duration{Unit: enums.Week, Count: 2},
+			expected: time.Date(2025, time.March, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Quarterly",
+			duration: enums.Duration{Unit: enums.Month, Count: 3},
+			expected: time.Date(2025, time.June, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Every 2 years",
+			duration: enums.Duration{Unit: enums.Year, Count: 2},
+			expected: time.Date(2027, time.March, 10, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.duration.AddDurationToTime(start))
+		})
+	}
+}
+
+func TestAddDurationToTimeWithAnchor(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		start     time.Time
+		expected  time.Time
+		name      string
+		duration  enums.Duration
+		anchorDay int
+	}{
+		{
+			name:      "Jan 31 clamps to Feb 29 in leap year",
+			start:     date(2024, time.January, 31),
+			duration:  enums.Monthly,
+			anchorDay: 31,
+			expected:  date(2024, time.February, 29),
+		},
+		{
+			name:      "Jan 31 clamps to Feb 28 in common year",
+			start:     date(2025, time.January, 31),
+			duration:  enums.Monthly,
+			anchorDay: 31,
+			expected:  date(2025, time.February, 28),
+		},
+		{
+			name:      "Feb 29 snaps back to Mar 31",
+			start:     date(2024, time.February, 29),
+			duration:  enums.Monthly,
+			anchorDay: 31,
+			expected:  date(2024, time.March, 31),
+		},
+		{
+			name:      "Mar 31 clamps to Apr 30",
+			start:     date(2025, time.March, 31),
+			duration:  enums.Monthly,
+			anchorDay: 31,
+			expected:  date(2025, time.April, 30),
+		},
+		{
+			name:      "Anchor 30 snaps back from Feb 28 to Mar 30",
+			start:     date(2025, time.February, 28),
+			duration:  enums.Monthly,
+			anchorDay: 30,
+			expected:  date(2025, time.March, 30),
+		},
+		{
+			name:      "Quarterly Nov 30 clamps to Feb 29 across year in leap year",
+			start:     date(2023, time.November, 30),
+			duration:  enums.Duration{Unit: enums.Month, Count: 3},
+			anchorDay: 30,
+			expected:  date(2024, time.February, 29),
+		},
+		{
+			name:      "Six months Aug 31 clamps to Feb 28",
+			start:     date(2024, time.August, 31),
+			duration:  enums.SixMonths,
+			anchorDay: 31,
+			expected:  date(2025, time.February, 28),
+		},
+		{
+			name:      "Yearly Feb 29 clamps to Feb 28",
+			start:     date(2024, time.February, 29),
+			duration:  enums.Yearly,
+			anchorDay: 29,
+			expected:  date(2025, time.February, 28),
+		},
+		{
+			name:      "Yearly Feb 28 snaps back to Feb 29 in leap year",
+			start:     date(2027, time.February, 28),
+			duration:  enums.Yearly,
+			anchorDay: 29,
+			expected:  date(2028, time.February, 29),
+		},
+		{
+			name:      "Every 4 years Feb 29 stays on Feb 29",
+			start:     date(2024, time.February, 29),
+			duration:  enums.Duration{Unit: enums.Year, Count: 4},
+			anchorDay: 29,
+			expected:  date(2028, time.February, 29),
+		},
+		{
+			name:      "Century year 2100 is not a leap year",
+			start:     date(2099, time.February, 28),
+			duration:  enums.Yearly,
+			anchorDay: 29,
+			expected:  date(2100, time.February, 28),
+		},
+		{
+			name:      "Weekly ignores anchor",
+			start:     date(2024, time.February, 26),
+			duration:  enums.Weekly,
+			anchorDay: 31,
+			expected:  date(2024, time.March, 4),
+		},
+		{
+			name:      "Days ignore anchor",
+			start:     date(2024, time.January, 31),
+			duration:  enums.Duration{Unit: enums.Day, Count: 30},
+			anchorDay: 31,
+			expected:  date(2024, time.March, 1),
+		},
+		{
+			name:      "Invalid anchor falls back to start day",
+			start:     date(2025, time.January, 15),
+			duration:  enums.Monthly,
+			anchorDay: 0,
+			expected:  date(2025, time.February, 15),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				tc.expected,
+				tc.duration.AddDurationToTimeWithAnchor(tc.start, tc.anchorDay),
+			)
+		})
+	}
+}
+
+func TestAddDurationToTimeWithAnchorDoesNotDrift(t *testing.T) {
+	// renewals are chained from the previous end date like the daily job does
+	date := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	expected := []time.Time{
+		time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.May, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.July, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.August, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.September, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.October, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.November, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, want := range expected {
+		date = enums.Monthly.AddDurationToTimeWithAnchor(date, 31)
+		require.Equal(t, want, date)
+	}
+}
+
+func TestSubtractDurationFromTimeWithAnchor(t *testing.T) {
+	// periods are walked back from the current one like a ledger backfill does
+	date := time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	expected := []time.Time{
+		time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.November, 30, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, want := range expected {
+		date = enums.Monthly.SubtractDurationFromTimeWithAnchor(date, 31)
+		require.Equal(t, want, date)
+	}
+
+	weekly := enums.Weekly.SubtractDurationFromTimeWithAnchor(date, 31)
+	require.Equal(t, time.Date(2024, time.November, 23, 0, 0, 0, 0, time.UTC), weekly)
+}
@@ -0,0 +1,124 @@
+// Package ics encodes iCalendar (RFC 5545) feeds of all-day events
+package ics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+	// lines longer than this many octets are folded
+	maxLineOctets = 75
+)
+
+type Calendar struct {
+	// ProdID identifies the product which created the calendar, e.g. "-//Subdub//Renewals//EN"
+	ProdID string
+	// Name is shown by calendar clients which support the X-WR-CALNAME extension
+	Name   string
+	Events []*Event
+}
+
+// Event is an all-day event on Date
+type Event struct {
+	Date time.Time
+	// Stamp is when the event was generated
+	Stamp time.Time
+	// UID must stay the same across feed refreshes so clients update the event instead of duplicating it
+	UID         string
+	Summary     string
+	Description string
+	Alarms      []*Alarm
+}
+
+// Alarm displays Description DaysBefore days before the start of its event
+type Alarm struct {
+	Description string
+	DaysBefore  int
+}
+
+// Marshal encodes the calendar with CRLF line endings and lines folded at 75 octets
+func (c *Calendar) Marshal() []byte {
+	w := &writer{}
+
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", c.ProdID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		w.line("X-WR-CALNAME", escapeText(c.Name))
+	}
+
+	for _, event := range c.Events {
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", event.UID)
+		w.line("DTSTAMP", event.Stamp.UTC().Format(dateTimeLayout))
+		w.line("DTSTART;VALUE=DATE", event.Date.Format(dateLayout))
+		w.line("DTEND;VALUE=DATE", event.Date.AddDate(0, 0, 1).Format(dateLayout))
+		w.line("SUMMARY", escapeText(event.Summary))
+		if event.Description != "" {
+			w.line("DESCRIPTION", escapeText(event.Description))
+		}
+		// renewals do not block time in the user's schedule
+		w.line("TRANSP", "TRANSPARENT")
+
+		for _, alarm := range event.Alarms {
+			w.line("BEGIN", "VALARM")
+			w.line("ACTION", "DISPLAY")
+			w.line("DESCRIPTION", escapeText(alarm.Description))
+			w.line("TRIGGER", fmt.Sprintf("-P%dD", alarm.DaysBefore))
+			w.line("END", "VALARM")
+		}
+
+		w.line("END", "VEVENT")
+	}
+
+	w.line("END", "VCALENDAR")
+
+	return []byte(w.String())
+}
+
+type writer struct {
+	strings.Builder
+}
+
+// line writes a content line, folding it by inserting CRLF and a space
+// without splitting a multi-byte character
+func (w *writer) line(name, value string) {
+	line := name + ":" + value
+
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space of a continuation line counts towards its length
+		limit = maxLineOctets - 1
+	}
+
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// escapeText escapes a TEXT value
+func escapeText(value string) string {
+	return textEscaper.Replace(value)
+}
@@ -0,0 +1,95 @@
+package ics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/ics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	stamp := time.Date(2025, time.May, 1, 8, 30, 0, 0, time.UTC)
+	date := time.Date(2025, time.May, 31, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		checkOutput func(*testing.T, string)
+		event       *ics.Event
+		name        string
+	}{
+		{
+			name: "All-day event with alarms",
+			event: &ics.Event{
+				UID:     "1@subdub",
+				Stamp:   stamp,
+				Date:    date,
+				Summary: "Netflix renews",
+				Alarms: []*ics.Alarm{
+					{DaysBefore: 7, Description: "Netflix renews in 7 days"},
+					{DaysBefore: 1, Description: "Netflix renews tomorrow"},
+				},
+			},
+			checkOutput: func(t *testing.T, output string) {
+				require.Contains(t, output, "\r\nBEGIN:VEVENT\r\nUID:1@subdub\r\n")
+				require.Contains(t, output, "\r\nDTSTAMP:20250501T083000Z\r\n")
+				require.Contains(t, output, "\r\nDTSTART;VALUE=DATE:20250531\r\n")
+				require.Contains(t, output, "\r\nDTEND;VALUE=DATE:20250601\r\n")
+				require.Contains(t, output, "\r\nSUMMARY:Netflix renews\r\n")
+				require.NotContains(t, output, "\r\nDESCRIPTION:Netflix renews\r\n")
+				require.Equal(t, 2, strings.Count(output, "BEGIN:VALARM"))
+				require.Contains(t, output, "\r\nTRIGGER:-P7D\r\n")
+				require.Contains(t, output, "\r\nTRIGGER:-P1D\r\n")
+			},
+		},
+		{
+			name: "Text is escaped",
+			event: &ics.Event{
+				UID:         "2@subdub",
+				Stamp:       stamp,
+				Date:        date,
+				Summary:     `Music; family, plan\`,
+				Description: "first line\nsecond line",
+			},
+			checkOutput: func(t *testing.T, output string) {
+				require.Contains(t, output, "\r\nSUMMARY:Music\\; family\\, plan\\\\\r\n")
+				require.Contains(t, output, "\r\nDESCRIPTION:first line\\nsecond line\r\n")
+			},
+		},
+		{
+			name: "Long lines are folded without splitting characters",
+			event: &ics.Event{
+				UID:     "3@subdub",
+				Stamp:   stamp,
+				Date:    date,
+				Summary: strings.Repeat("é", 60),
+			},
+			checkOutput: func(t *testing.T, output string) {
+				for _, line := range strings.Split(output, "\r\n") {
+					require.LessOrEqual(t, len(line), 75)
+				}
+
+				unfolded := strings.ReplaceAll(output, "\r\n ", "")
+				require.Contains(t, unfolded, "\r\nSUMMARY:"+strings.Repeat("é", 60)+"\r\n")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calendar := &ics.Calendar{
+				ProdID: "-//Subdub//Renewals//EN",
+				Name:   "Renewals",
+				Events: []*ics.Event{tc.event},
+			}
+
+			output := string(calendar.Marshal())
+
+			require.True(t, strings.HasPrefix(output, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+			require.True(t, strings.HasSuffix(output, "\r\nEND:VCALENDAR\r\n"))
+			require.Contains(t, output, "\r\nX-WR-CALNAME:Renewals\r\n")
+
+			tc.checkOutput(t, output)
+		})
+	}
+}
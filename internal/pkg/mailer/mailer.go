@@ -25,6 +25,11 @@ type MailTemplateOption int
 
 const (
 	RemindTemplate MailTemplateOption = iota
+	TrialRemindTemplate
+	ExportReadyTemplate
+	PriceIncreaseTemplate
+	BudgetAlertTemplate
+	CardExpiryTemplate
 )
 
 type RemindData struct {
@@ -33,3 +38,65 @@ type RemindData struct {
 	Email       string
 	NumDays     int
 }
+
+type TrialRemindData struct {
+	TrialEndDate time.Time
+	Name         string
+	Email        string
+	// PostTrialPrice is the formatted price charged when the trial converts,
+	// it is empty when the price is unknown
+	PostTrialPrice string
+	NumDays        int
+}
+
+// PriceIncreaseData warns before a renewal charged at a higher price than the previous period,
+// prices are formatted like "15.99 USD"
+type PriceIncreaseData struct {
+	RenewalDate time.Time
+	Name        string
+	Email       string
+	OldPrice    string
+	NewPrice    string
+	NumDays     int
+}
+
+// BudgetAlertData warns that the committed spend of the month reached Threshold percent of a budget,
+// amounts are formatted like "15.99 USD"
+type BudgetAlertData struct {
+	Email string
+	// Month is formatted like "May 2025"
+	Month string
+	// Scope is "all" or the duration of the subscriptions covered by the budget
+	Scope     string
+	Budget    string
+	Committed string
+	// Remaining is what is left of the budget, or the overspend when Overspent is true
+	Remaining string
+	Threshold int
+	Overspent bool
+}
+
+// CardExpiryData warns that a payment method expires soon with the subscriptions it pays
+type CardExpiryData struct {
+	ExpiresAt time.Time
+	Email     string
+	// Card describes the payment method like "Personal card (visa ending in 4242)"
+	Card string
+	// Expiry is formatted like "08/2027"
+	Expiry        string
+	Subscriptions []CardExpirySubscription
+}
+
+// CardExpirySubscription is a subscription paid with an expiring card,
+// NextRenewal is formatted like "2006-01-02"
+type CardExpirySubscription struct {
+	Name        string
+	NextRenewal string
+}
+
+type ExportReadyData struct {
+	Email string
+	// Link downloads the export until it expires
+	Link           string
+	ExpiresInHours int
+}
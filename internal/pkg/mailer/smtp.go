@@ -96,6 +96,33 @@ func getData(opt MailTemplateOption, data any) (any, error) {
 			return data, nil
 		}
 		return nil, apperror.ErrInvalidEmailData
+	case TrialRemindTemplate:
+		if data, ok := data.(TrialRemindData); ok {
+			data.Name = strings.ToUpper(data.Name)
+			return data, nil
+		}
+		return nil, apperror.ErrInvalidEmailData
+	case PriceIncreaseTemplate:
+		if data, ok := data.(PriceIncreaseData); ok {
+			data.Name = strings.ToUpper(data.Name)
+			return data, nil
+		}
+		return nil, apperror.ErrInvalidEmailData
+	case BudgetAlertTemplate:
+		if data, ok := data.(BudgetAlertData); ok {
+			return data, nil
+		}
+		return nil, apperror.ErrInvalidEmailData
+	case CardExpiryTemplate:
+		if data, ok := data.(CardExpiryData); ok {
+			return data, nil
+		}
+		return nil, apperror.ErrInvalidEmailData
+	case ExportReadyTemplate:
+		if data, ok := data.(ExportReadyData); ok {
+			return data, nil
+		}
+		return nil, apperror.ErrInvalidEmailData
 	}
 
 	return nil, nil
@@ -106,6 +133,16 @@ func getMailTemplate(opt MailTemplateOption) *MailTemplate {
 	switch opt {
 	case RemindTemplate:
 		temp.Path = "remind-email.tmpl"
+	case TrialRemindTemplate:
+		temp.Path = "trial-remind-email.tmpl"
+	case PriceIncreaseTemplate:
+		temp.Path = "price-increase-email.tmpl"
+	case BudgetAlertTemplate:
+		temp.Path = "budget-alert-email.tmpl"
+	case CardExpiryTemplate:
+		temp.Path = "card-expiry-email.tmpl"
+	case ExportReadyTemplate:
+		temp.Path = "export-ready-email.tmpl"
 	}
 
 	return &temp
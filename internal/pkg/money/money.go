@@ -0,0 +1,79 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidAmount is returned by Parse for a malformed or negative amount,
+// or one with more decimal places than the currency has
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// currencies which do not use 2 decimal places for their minor unit
+var minorUnitDigits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// Digits returns the number of decimal places of the minor unit of currency
+func Digits(currency string) int {
+	if digits, ok := minorUnitDigits[strings.ToUpper(currency)]; ok {
+		return digits
+	}
+
+	return 2
+}
+
+// Format formats an amount stored in minor units, e.g. 999 USD is "9.99 USD"
+// and 100000 VND is "100000 VND"
+func Format(amount int64, currency string) string {
+	currency = strings.ToUpper(currency)
+	digits := Digits(currency)
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	if digits == 0 {
+		return fmt.Sprintf("%s%d %s", sign, amount, currency)
+	}
+
+	divisor := int64(1)
+	for range digits {
+		divisor *= 10
+	}
+
+	return fmt.Sprintf(
+		"%s%d.%0*d %s",
+		sign,
+		amount/divisor,
+		digits,
+		amount%divisor,
+		currency,
+	)
+}
+
+// Parse parses an amount written in major units like "9.99" into minor units of currency,
+// "9.99" USD is 999 and "100000" VND is 100000. A comma is accepted as decimal separator
+func Parse(value string, currency string) (int64, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+	digits := Digits(currency)
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" || len(fraction) > digits || strings.HasPrefix(whole, "-") {
+		return 0, ErrInvalidAmount
+	}
+	fraction += strings.Repeat("0", digits-len(fraction))
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || amount < 0 {
+		return 0, ErrInvalidAmount
+	}
+
+	return amount, nil
+}
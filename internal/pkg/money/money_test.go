@@ -0,0 +1,65 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/money"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		currency string
+		expected string
+		amount   int64
+	}{
+		{name: "Two decimals", amount: 999, currency: "USD", expected: "9.99 USD"},
+		{name: "Leading zero in minor unit", amount: 1005, currency: "eur", expected: "10.05 EUR"},
+		{name: "Less than one major unit", amount: 5, currency: "USD", expected: "0.05 USD"},
+		{name: "Zero decimals", amount: 100000, currency: "VND", expected: "100000 VND"},
+		{name: "Three decimals", amount: 1500, currency: "KWD", expected: "1.500 KWD"},
+		{name: "Negative amount", amount: -250, currency: "USD", expected: "-2.50 USD"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, money.Format(tc.amount, tc.currency))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		err      error
+		name     string
+		value    string
+		currency string
+		expected int64
+	}{
+		{name: "Two decimals", value: "9.99", currency: "USD", expected: 999},
+		{name: "No decimals", value: "15", currency: "USD", expected: 1500},
+		{name: "One decimal", value: "2.5", currency: "EUR", expected: 250},
+		{name: "Comma separator", value: " 4,20 ", currency: "EUR", expected: 420},
+		{name: "Zero decimals currency", value: "100000", currency: "VND", expected: 100000},
+		{name: "Three decimals", value: "1.5", currency: "KWD", expected: 1500},
+		{name: "Too many decimals", value: "9.999", currency: "USD", err: money.ErrInvalidAmount},
+		{name: "Decimals for zero decimals currency", value: "1.5", currency: "JPY", err: money.ErrInvalidAmount},
+		{name: "Negative", value: "-1.00", currency: "USD", err: money.ErrInvalidAmount},
+		{name: "Not a number", value: "ten", currency: "USD", err: money.ErrInvalidAmount},
+		{name: "Empty", value: "", currency: "USD", err: money.ErrInvalidAmount},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			amount, err := money.Parse(tc.value, tc.currency)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, amount)
+		})
+	}
+}
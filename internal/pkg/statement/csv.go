@@ -0,0 +1,263 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// header names of each column, matched case-insensitively, banks name their columns differently
+var (
+	csvDateColumns = []string{
+		"date", "transaction date", "posted date", "posting date", "booking date", "value date",
+	}
+	csvDescriptionColumns = []string{
+		"description", "payee", "merchant", "name", "details", "narrative", "memo",
+	}
+	csvAmountColumns   = []string{"amount", "transaction amount"}
+	csvDebitColumns    = []string{"debit", "withdrawal", "withdrawals", "money out", "paid out"}
+	csvCreditColumns   = []string{"credit", "deposit", "deposits", "money in", "paid in"}
+	csvCurrencyColumns = []string{"currency"}
+)
+
+// date layouts tried in order, the first one reading every date of the file is used,
+// so a file with 13/01/2025 is read day first even when its other dates are ambiguous
+var csvDateLayouts = []string{
+	"2006-01-02",
+	"01/02/2006",
+	"02/01/2006",
+	"2006/01/02",
+	"02.01.2006",
+	"02-01-2006",
+	"01/02/06",
+	"02/01/06",
+	"20060102",
+	"Jan 2, 2006",
+	"2 Jan 2006",
+	"02 Jan 2006",
+}
+
+type csvColumns struct {
+	date        int
+	description int
+	amount      int
+	debit       int
+	credit      int
+	currency    int
+}
+
+type csvRecord struct {
+	values []string
+	line   int
+}
+
+func parseCSV(data []byte) ([]*Transaction, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = sniffDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, ErrUnknownFormat
+	}
+
+	columns, ok := findCSVColumns(header)
+	if !ok {
+		return nil, ErrUnknownFormat
+	}
+
+	records := []*csvRecord{}
+	for {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			line, _ := reader.FieldPos(0)
+			return nil, &ParseError{Line: line, Err: err}
+		}
+
+		if isBlank(values) {
+			continue
+		}
+
+		if len(records) == MaxTransactions {
+			return nil, ErrTooManyTransactions
+		}
+
+		line, _ := reader.FieldPos(0)
+		records = append(records, &csvRecord{values: values, line: line})
+	}
+
+	layout, line := detectDateLayout(records, columns.date)
+	if layout == "" {
+		return nil, &ParseError{Line: line, Err: errors.New("invalid date")}
+	}
+
+	transactions := make([]*Transaction, 0, len(records))
+	for _, record := range records {
+		transaction, err := parseCSVRecord(record.values, columns, layout)
+		if err != nil {
+			return nil, &ParseError{Line: record.line, Err: err}
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+func parseCSVRecord(values []string, columns *csvColumns, layout string) (*Transaction, error) {
+	value := func(i int) string {
+		if i < 0 || i >= len(values) {
+			return ""
+		}
+
+		return strings.TrimSpace(values[i])
+	}
+
+	date, err := time.Parse(layout, value(columns.date))
+	if err != nil {
+		return nil, errors.New("invalid date")
+	}
+
+	currency := strings.ToUpper(value(columns.currency))
+
+	var amount int64
+	if columns.amount >= 0 {
+		amount, err = parseAmount(value(columns.amount), currency)
+		if err != nil {
+			return nil, errors.New("invalid amount")
+		}
+	} else {
+		// debits are written as positive numbers in their own column
+		if debit := value(columns.debit); debit != "" {
+			amount, err = parseAmount(debit, currency)
+			if err != nil {
+				return nil, errors.New("invalid debit")
+			}
+			if amount > 0 {
+				amount = -amount
+			}
+		} else if credit := value(columns.credit); credit != "" {
+			amount, err = parseAmount(credit, currency)
+			if err != nil {
+				return nil, errors.New("invalid credit")
+			}
+		}
+	}
+
+	return &Transaction{
+		Date:        date,
+		Description: value(columns.description),
+		Currency:    currency,
+		Amount:      amount,
+	}, nil
+}
+
+// findCSVColumns finds the columns of a header, a date, a description and
+// either an amount or a debit column are required
+func findCSVColumns(header []string) (*csvColumns, bool) {
+	names := make([]string, len(header))
+	for i, name := range header {
+		names[i] = strings.Join(strings.Fields(strings.ToLower(strings.Trim(name, ` "`))), " ")
+	}
+
+	find := func(candidates []string) int {
+		for _, candidate := range candidates {
+			for i, name := range names {
+				if name == candidate {
+					return i
+				}
+			}
+		}
+
+		return -1
+	}
+
+	columns := &csvColumns{
+		date:        find(csvDateColumns),
+		description: find(csvDescriptionColumns),
+		amount:      find(csvAmountColumns),
+		debit:       find(csvDebitColumns),
+		credit:      find(csvCreditColumns),
+		currency:    find(csvCurrencyColumns),
+	}
+
+	ok := columns.date >= 0 && columns.description >= 0 && (columns.amount >= 0 || columns.debit >= 0)
+
+	return columns, ok
+}
+
+// detectDateLayout returns the first layout reading every date,
+// or the line of the first date no layout reads
+func detectDateLayout(records []*csvRecord, column int) (string, int) {
+	parses := func(layout string, record *csvRecord) bool {
+		if column >= len(record.values) {
+			return false
+		}
+
+		_, err := time.Parse(layout, strings.TrimSpace(record.values[column]))
+		return err == nil
+	}
+
+	for _, layout := range csvDateLayouts {
+		ok := true
+		for _, record := range records {
+			if !parses(layout, record) {
+				ok = false
+				break
+			}
+		}
+
+		if ok {
+			return layout, 0
+		}
+	}
+
+	for _, record := range records {
+		ok := false
+		for _, layout := range csvDateLayouts {
+			if parses(layout, record) {
+				ok = true
+				break
+			}
+		}
+
+		if !ok {
+			return "", record.line
+		}
+	}
+
+	// every date is read by some layout, but not all by the same one
+	return "", records[0].line
+}
+
+// sniffDelimiter picks the most frequent of comma, semicolon and tab in the first line,
+// many european banks export semicolon separated files
+func sniffDelimiter(data []byte) rune {
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+
+	delimiter, count := ',', bytes.Count(firstLine, []byte(","))
+	for _, d := range []rune{';', '\t'} {
+		if c := bytes.Count(firstLine, []byte(string(d))); c > count {
+			delimiter, count = d, c
+		}
+	}
+
+	return delimiter
+}
+
+func isBlank(values []string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
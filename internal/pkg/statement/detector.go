@@ -0,0 +1,180 @@
+package statement
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+)
+
+// Candidate is a series of charges of the same amount by the same merchant at a regular cadence
+type Candidate struct {
+	FirstDate time.Time
+	LastDate  time.Time
+	// NextDate is when the next charge is expected
+	NextDate time.Time
+	// Merchant is the normalized merchant the charges are grouped by
+	Merchant string
+	// Name is Merchant in title case, to be used as subscription name
+	Name     string
+	Currency string
+	Duration enums.Duration
+	// Amount is the charged amount in minor units, it is positive
+	Amount      int64
+	Occurrences int
+}
+
+// cadence is a billing duration recognized from the days between charges
+type cadence struct {
+	duration       enums.Duration
+	minDays        int
+	maxDays        int
+	minOccurrences int
+}
+
+// days between charges vary with the length of months and with weekends and bank holidays,
+// two charges of the same amount a month apart are often a coincidence so a few more are needed
+var cadences = []cadence{
+	{duration: enums.Weekly, minDays: 6, maxDays: 8, minOccurrences: 3},
+	{duration: enums.Monthly, minDays: 27, maxDays: 34, minOccurrences: 3},
+	{duration: enums.Yearly, minDays: 355, maxDays: 375, minOccurrences: 2},
+}
+
+// words of card descriptors which do not name the merchant
+var merchantNoise = map[string]bool{
+	"POS": true, "DEBIT": true, "CARD": true, "PURCHASE": true, "PAYMENT": true, "RECURRING": true,
+	"DIRECT": true, "DD": true, "SEPA": true, "ACH": true, "VISA": true, "MASTERCARD": true,
+	"ONLINE": true, "WWW": true, "INC": true, "LLC": true, "LTD": true, "COM": true,
+}
+
+var (
+	// payment processors prefix the merchant, e.g. "PAYPAL *NETFLIX" or "SQ *COFFEE"
+	processorPrefix = regexp.MustCompile(`^(PAYPAL|SQ|TST|SP|GOOGLE)\s*\*\s*`)
+	nonAlphanumeric = regexp.MustCompile(`[^A-Z0-9&]+`)
+	hasDigit        = regexp.MustCompile(`[0-9]`)
+)
+
+// NormalizeMerchant reduces a transaction description to the merchant it names,
+// so "NETFLIX.COM 866-579-7172" and "Netflix.com 1234567" are both "NETFLIX"
+func NormalizeMerchant(description string) string {
+	s := strings.ToUpper(strings.TrimSpace(description))
+	s = processorPrefix.ReplaceAllString(s, "")
+	s = nonAlphanumeric.ReplaceAllString(s, " ")
+
+	var words []string
+	for _, word := range strings.Fields(s) {
+		// reference numbers, dates and store numbers change between charges
+		if hasDigit.MatchString(word) || merchantNoise[word] {
+			continue
+		}
+
+		words = append(words, word)
+	}
+
+	return strings.Join(words, " ")
+}
+
+type chargeKey struct {
+	merchant string
+	currency string
+	amount   int64
+}
+
+// Detect groups the charges of transactions by normalized merchant and amount and returns
+// the groups charged at a weekly, monthly or yearly cadence, ordered by merchant.
+// Incoming money is ignored
+func Detect(transactions []*Transaction) []*Candidate {
+	groups := map[chargeKey][]time.Time{}
+
+	for _, t := range transactions {
+		if t.Amount >= 0 {
+			continue
+		}
+
+		merchant := NormalizeMerchant(t.Description)
+		if merchant == "" {
+			continue
+		}
+
+		key := chargeKey{merchant: merchant, currency: t.Currency, amount: -t.Amount}
+		groups[key] = append(groups[key], t.Date)
+	}
+
+	candidates := []*Candidate{}
+	for key, dates := range groups {
+		dates = uniqueSortedDates(dates)
+
+		c, ok := detectCadence(dates)
+		if !ok {
+			continue
+		}
+
+		last := dates[len(dates)-1]
+		candidates = append(candidates, &Candidate{
+			Merchant:    key.merchant,
+			Name:        titleCase(key.merchant),
+			Currency:    key.currency,
+			Amount:      key.amount,
+			Duration:    c.duration,
+			Occurrences: len(dates),
+			FirstDate:   dates[0],
+			LastDate:    last,
+			NextDate:    c.duration.AddDurationToTime(last),
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Merchant != candidates[j].Merchant {
+			return candidates[i].Merchant < candidates[j].Merchant
+		}
+		if candidates[i].Currency != candidates[j].Currency {
+			return candidates[i].Currency < candidates[j].Currency
+		}
+		return candidates[i].Amount < candidates[j].Amount
+	})
+
+	return candidates
+}
+
+// detectCadence returns the cadence every gap between dates falls in
+func detectCadence(dates []time.Time) (cadence, bool) {
+	for _, c := range cadences {
+		if len(dates) < c.minOccurrences {
+			continue
+		}
+
+		ok := true
+		for i := 1; i < len(dates); i++ {
+			days := int(dates[i].Sub(dates[i-1]).Hours() / 24)
+			if days < c.minDays || days > c.maxDays {
+				ok = false
+				break
+			}
+		}
+
+		if ok {
+			return c, true
+		}
+	}
+
+	return cadence{}, false
+}
+
+// a merchant charging twice the same day is counted once
+func uniqueSortedDates(dates []time.Time) []time.Time {
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	return slices.CompactFunc(dates, func(a, b time.Time) bool { return a.Equal(b) })
+}
+
+func titleCase(s string) string {
+	words := strings.Fields(strings.ToLower(s))
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,85 @@
+package statement_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeMerchant(t *testing.T) {
+	testCases := []struct {
+		description string
+		expected    string
+	}{
+		{description: "NETFLIX.COM 866-579-7172", expected: "NETFLIX"},
+		{description: "Netflix.com 1234567", expected: "NETFLIX"},
+		{description: "PAYPAL *SPOTIFY P2B4C", expected: "SPOTIFY"},
+		{description: "POS PURCHASE 01/15 PLANET FITNESS #123", expected: "PLANET FITNESS"},
+		{description: "1234 5678", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, statement.NormalizeMerchant(tc.description))
+		})
+	}
+}
+
+func charges(description string, amount int64, dates ...string) []*statement.Transaction {
+	transactions := make([]*statement.Transaction, 0, len(dates))
+	for _, d := range dates {
+		transactions = append(transactions, &statement.Transaction{
+			Date:        date(d),
+			Description: description,
+			Currency:    "USD",
+			Amount:      amount,
+		})
+	}
+
+	return transactions
+}
+
+func TestDetect(t *testing.T) {
+	var transactions []*statement.Transaction
+	// monthly with a shorter February and a changing reference number
+	transactions = append(transactions, charges("NETFLIX.COM 111", -1549, "2025-01-15", "2025-03-17")...)
+	transactions = append(transactions, charges("NETFLIX.COM 222", -1549, "2025-02-14")...)
+	transactions = append(transactions, charges("GYM WEEKLY", -1000, "2025-01-06", "2025-01-13", "2025-01-20")...)
+	transactions = append(transactions, charges("DOMAIN RENEWAL", -1200, "2024-02-01", "2025-02-03")...)
+	// same merchant at irregular dates
+	transactions = append(transactions, charges("GROCERY", -4599, "2025-01-02", "2025-01-19", "2025-03-01")...)
+	// only twice a month apart is not enough
+	transactions = append(transactions, charges("BOOKSTORE", -2000, "2025-01-10", "2025-02-10")...)
+	// incoming money is never a subscription
+	transactions = append(transactions, charges("SALARY", 250000, "2025-01-01", "2025-02-01", "2025-03-01")...)
+	// charged twice the same day
+	transactions = append(transactions, charges("GYM WEEKLY", -1000, "2025-01-13")...)
+
+	candidates := statement.Detect(transactions)
+	require.Len(t, candidates, 3)
+
+	require.Equal(t, "DOMAIN RENEWAL", candidates[0].Merchant)
+	require.Equal(t, enums.Yearly, candidates[0].Duration)
+	require.Equal(t, 2, candidates[0].Occurrences)
+
+	require.Equal(t, "GYM WEEKLY", candidates[1].Merchant)
+	require.Equal(t, enums.Weekly, candidates[1].Duration)
+	require.Equal(t, 3, candidates[1].Occurrences)
+	require.Equal(t, date("2025-01-27"), candidates[1].NextDate)
+
+	netflix := candidates[2]
+	require.Equal(t, &statement.Candidate{
+		Merchant:    "NETFLIX",
+		Name:        "Netflix",
+		Currency:    "USD",
+		Amount:      1549,
+		Duration:    enums.Monthly,
+		Occurrences: 3,
+		FirstDate:   date("2025-01-15"),
+		LastDate:    date("2025-03-17"),
+		NextDate:    time.Date(2025, time.April, 17, 0, 0, 0, 0, time.UTC),
+	}, netflix)
+}
@@ -0,0 +1,110 @@
+package statement
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+)
+
+func isOFX(data []byte) bool {
+	head := bytes.ToUpper(data[:min(len(data), 1024)])
+
+	return bytes.Contains(head, []byte("OFXHEADER")) || bytes.Contains(head, []byte("<OFX>"))
+}
+
+// parseOFX reads the STMTTRN elements of an OFX or QFX file.
+// OFX 1.x is SGML which does not close its leaf elements, so a value ends at the next tag
+// and both the SGML and the XML flavors are read the same way
+func parseOFX(data []byte) ([]*Transaction, error) {
+	content := string(data)
+	upper := strings.ToUpper(content)
+	currency := ofxValue(content, upper, "CURDEF")
+
+	transactions := []*Transaction{}
+	for offset := 0; ; {
+		start := strings.Index(upper[offset:], "<STMTTRN>")
+		if start < 0 {
+			break
+		}
+		start += offset
+
+		end := strings.Index(upper[start:], "</STMTTRN>")
+		if end < 0 {
+			end = len(upper)
+		} else {
+			end += start
+		}
+		offset = end
+
+		line := strings.Count(content[:start], "\n") + 1
+		transaction, err := parseOFXTransaction(content[start:end], upper[start:end], currency)
+		if err != nil {
+			return nil, &ParseError{Line: line, Err: err}
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+func parseOFXTransaction(content, upper, currency string) (*Transaction, error) {
+	posted := ofxValue(content, upper, "DTPOSTED")
+	// dates are YYYYMMDD followed by an optional time and time zone
+	if len(posted) < 8 {
+		return nil, errors.New("invalid DTPOSTED")
+	}
+	date, err := time.Parse("20060102", posted[:8])
+	if err != nil {
+		return nil, errors.New("invalid DTPOSTED")
+	}
+
+	// the amount of a transaction in a foreign currency is in CURRENCY > CURSYM,
+	// while ORIGCURRENCY only tells the currency before conversion
+	if i := strings.Index(upper, "<CURRENCY>"); i >= 0 {
+		if sym := ofxValue(content[i:], upper[i:], "CURSYM"); sym != "" {
+			currency = sym
+		}
+	}
+	currency = strings.ToUpper(currency)
+
+	amount, err := parseAmount(ofxValue(content, upper, "TRNAMT"), currency)
+	if err != nil {
+		return nil, errors.New("invalid TRNAMT")
+	}
+
+	description := ofxValue(content, upper, "NAME")
+	if description == "" {
+		description = ofxValue(content, upper, "MEMO")
+	}
+
+	return &Transaction{
+		Date:        date,
+		Description: description,
+		Currency:    currency,
+		Amount:      amount,
+	}, nil
+}
+
+// ofxValue returns the value of the first element named tag, upper is content in upper case
+func ofxValue(content, upper, tag string) string {
+	i := strings.Index(upper, "<"+tag+">")
+	if i < 0 {
+		return ""
+	}
+	i += len(tag) + 2
+
+	end := strings.IndexByte(content[i:], '<')
+	if end < 0 {
+		end = len(content) - i
+	}
+
+	return unescapeOFX(strings.TrimSpace(content[i : i+end]))
+}
+
+var ofxUnescaper = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&", "&nbsp;", " ")
+
+func unescapeOFX(s string) string {
+	return ofxUnescaper.Replace(s)
+}
@@ -0,0 +1,132 @@
+// Package statement reads bank statements and detects the recurring charges in them
+package statement
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/money"
+)
+
+// MaxTransactions is the number of transactions a single statement can have
+const MaxTransactions = 10000
+
+var (
+	// ErrUnknownFormat is returned by Parse for a file which is neither OFX/QFX nor csv
+	ErrUnknownFormat = errors.New("unknown statement format")
+	// ErrTooManyTransactions is returned by Parse for a statement of more than MaxTransactions
+	ErrTooManyTransactions = errors.New("too many transactions")
+)
+
+// ParseError reports the transaction of a statement which could not be read
+type ParseError struct {
+	Err error
+	// Line is the line of the transaction in the file, starting at 1
+	Line int
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Transaction is a line of a bank statement
+type Transaction struct {
+	Date        time.Time
+	Description string
+	// Currency is empty when the statement does not tell it
+	Currency string
+	// Amount is in minor units, it is negative for money leaving the account
+	Amount int64
+}
+
+// Parse reads the transactions of an OFX/QFX or csv statement, the format is detected from the content
+func Parse(r io.Reader) ([]*Transaction, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// spreadsheet applications often start utf-8 files with a byte order mark
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	var transactions []*Transaction
+	if isOFX(data) {
+		transactions, err = parseOFX(data)
+	} else {
+		transactions, err = parseCSV(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transactions) > MaxTransactions {
+		return nil, ErrTooManyTransactions
+	}
+
+	return transactions, nil
+}
+
+// parseAmount parses a signed amount written in major units into minor units of currency.
+// Currency symbols and thousands separators are ignored and parentheses mean a negative amount,
+// so "(1,234.50)" and "-1.234,50 €" are both -123450 in EUR and "1,234" is 1234 in VND
+func parseAmount(value string, currency string) (int64, error) {
+	value = strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == ',' || r == '-' || r == '+' || r == '(' {
+			return r
+		}
+		return -1
+	}, value)
+
+	negative := false
+	if strings.HasPrefix(value, "(") {
+		negative = true
+		value = strings.TrimPrefix(value, "(")
+	}
+	if strings.HasPrefix(value, "-") {
+		negative = true
+		value = value[1:]
+	} else if strings.HasSuffix(value, "-") {
+		negative = true
+		value = strings.TrimSuffix(value, "-")
+	}
+	value = strings.TrimPrefix(value, "+")
+
+	dot, comma := strings.LastIndex(value, "."), strings.LastIndex(value, ",")
+	switch {
+	case dot >= 0 && comma >= 0:
+		// with both separators the last one is the decimal separator
+		if dot > comma {
+			value = strings.ReplaceAll(value, ",", "")
+		} else {
+			value = strings.ReplaceAll(value, ".", "")
+		}
+	case dot >= 0 || comma >= 0:
+		// with a single kind of separator it groups thousands when it is repeated or when it is
+		// followed by 3 digits the currency does not have, so "-1,234" is -123400 in USD
+		last := max(dot, comma)
+		separator := value[last : last+1]
+		if strings.Count(value, separator) > 1 ||
+			(len(value)-last-1 == 3 && money.Digits(currency) < 3) {
+			value = strings.ReplaceAll(value, separator, "")
+		}
+	}
+
+	amount, err := money.Parse(value, currency)
+	if err != nil {
+		return 0, err
+	}
+
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
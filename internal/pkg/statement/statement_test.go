@@ -0,0 +1,165 @@
+package statement_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+const sgmlOFX = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>USD
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20250115120000.000[-5:EST]
+<TRNAMT>-15.49
+<FITID>1
+<NAME>NETFLIX.COM
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20250120
+<TRNAMT>2500.00
+<FITID>2
+<MEMO>Salary &amp; bonus
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20250121
+<TRNAMT>-9.99
+<FITID>3
+<NAME>SPOTIFY
+<CURRENCY><CURRATE>1.1<CURSYM>EUR</CURRENCY>
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const xmlOFX = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>GBP</CURDEF><BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20250301</DTPOSTED><TRNAMT>-7.99</TRNAMT><NAME>Disney Plus</NAME></STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>
+`
+
+func date(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		err      error
+		name     string
+		content  string
+		expected []*statement.Transaction
+	}{
+		{
+			name:    "SGML OFX",
+			content: sgmlOFX,
+			expected: []*statement.Transaction{
+				{Date: date("2025-01-15"), Description: "NETFLIX.COM", Currency: "USD", Amount: -1549},
+				{Date: date("2025-01-20"), Description: "Salary & bonus", Currency: "USD", Amount: 250000},
+				{Date: date("2025-01-21"), Description: "SPOTIFY", Currency: "EUR", Amount: -999},
+			},
+		},
+		{
+			name:    "XML OFX",
+			content: xmlOFX,
+			expected: []*statement.Transaction{
+				{Date: date("2025-03-01"), Description: "Disney Plus", Currency: "GBP", Amount: -799},
+			},
+		},
+		{
+			name: "CSV with amount and currency",
+			content: "\ufeffDate,Description,Amount,Currency\n" +
+				"2025-01-15,NETFLIX.COM,-15.49,usd\n" +
+				"\n" +
+				`2025-01-20,Salary,"2,500.00",USD` + "\n",
+			expected: []*statement.Transaction{
+				{Date: date("2025-01-15"), Description: "NETFLIX.COM", Currency: "USD", Amount: -1549},
+				{Date: date("2025-01-20"), Description: "Salary", Currency: "USD", Amount: 250000},
+			},
+		},
+		{
+			name: "Semicolon CSV with debit and credit columns and day first dates",
+			content: "Booking Date;Payee;Debit;Credit\n" +
+				"05/01/2025;Spotify AB;9,99;\n" +
+				"13/01/2025;Employer;;1.250,00\n",
+			expected: []*statement.Transaction{
+				{Date: date("2025-01-05"), Description: "Spotify AB", Amount: -999},
+				{Date: date("2025-01-13"), Description: "Employer", Amount: 125000},
+			},
+		},
+		{
+			name:    "CSV with parentheses for negative amounts",
+			content: "Transaction Date,Merchant,Amount\n01/31/2025,Gym,($30.00)\n",
+			expected: []*statement.Transaction{
+				{Date: date("2025-01-31"), Description: "Gym", Amount: -3000},
+			},
+		},
+		{
+			name: "CSV with a comma or dot only grouping thousands",
+			content: "Date,Description,Amount,Currency\n" +
+				`2025-02-01,Rent,"-1,234",USD` + "\n" +
+				`2025-02-02,Internet,"-1,234",VND` + "\n" +
+				`2025-02-03,Bonus,"1,234,567",VND` + "\n" +
+				`2025-02-04,Salary,"2.500.000",VND` + "\n" +
+				`2025-02-05,Refund,"12,50",EUR` + "\n" +
+				`2025-02-06,Transfer,"-1,234",KWD` + "\n",
+			expected: []*statement.Transaction{
+				{Date: date("2025-02-01"), Description: "Rent", Currency: "USD", Amount: -123400},
+				{Date: date("2025-02-02"), Description: "Internet", Currency: "VND", Amount: -1234},
+				{Date: date("2025-02-03"), Description: "Bonus", Currency: "VND", Amount: 1234567},
+				{Date: date("2025-02-04"), Description: "Salary", Currency: "VND", Amount: 2500000},
+				{Date: date("2025-02-05"), Description: "Refund", Currency: "EUR", Amount: 1250},
+				{Date: date("2025-02-06"), Description: "Transfer", Currency: "KWD", Amount: -1234},
+			},
+		},
+		{
+			name:    "Unknown format",
+			content: "hello world\nnot a statement\n",
+			err:     statement.ErrUnknownFormat,
+		},
+		{
+			name:    "Invalid amount",
+			content: "Date,Description,Amount\n2025-01-15,Netflix,-15.49\n2025-02-15,Netflix,abc\n",
+			err:     &statement.ParseError{Line: 3},
+		},
+		{
+			name:    "Invalid date",
+			content: "Date,Description,Amount\n2025-01-15,Netflix,-15.49\nyesterday,Netflix,-15.49\n",
+			err:     &statement.ParseError{Line: 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transactions, err := statement.Parse(strings.NewReader(tc.content))
+
+			if parseError, ok := tc.err.(*statement.ParseError); ok {
+				var actual *statement.ParseError
+				require.ErrorAs(t, err, &actual)
+				require.Equal(t, parseError.Line, actual.Line)
+				return
+			}
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, transactions)
+		})
+	}
+}
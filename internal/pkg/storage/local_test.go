@@ -0,0 +1,50 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	s := storage.NewLocalStorage(t.TempDir())
+
+	err := s.Put(ctx, "user/receipt", strings.NewReader("receipt"))
+	require.NoError(t, err)
+
+	file, err := s.Open(ctx, "user/receipt")
+	require.NoError(t, err)
+
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.Equal(t, "receipt", string(content))
+
+	require.NoError(t, s.Delete(ctx, "user/receipt"))
+	// deleting twice is not an error
+	require.NoError(t, s.Delete(ctx, "user/receipt"))
+
+	_, err = s.Open(ctx, "user/receipt")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestLocalStorageInvalidKey(t *testing.T) {
+	ctx := context.Background()
+	s := storage.NewLocalStorage(t.TempDir())
+
+	for _, key := range []string{"", ".", "../receipt", "/receipt", "user/../../receipt"} {
+		err := s.Put(ctx, key, strings.NewReader("receipt"))
+		require.ErrorIs(t, err, storage.ErrInvalidKey, key)
+
+		_, err = s.Open(ctx, key)
+		require.ErrorIs(t, err, storage.ErrInvalidKey, key)
+
+		err = s.Delete(ctx, key)
+		require.ErrorIs(t, err, storage.ErrInvalidKey, key)
+	}
+}
@@ -0,0 +1,25 @@
+// Package storage keeps blobs like the files attached to subscriptions,
+// the local file system is the only implementation for now
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	// ErrNotFound is returned by Open when there is no blob with the key
+	ErrNotFound = errors.New("blob not found")
+	// ErrInvalidKey is returned for a key which is not a clean slash separated path like "a/b"
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Storage is a blob storage, keys are slash separated paths like "<user id>/<attachment id>"
+type Storage interface {
+	// Put writes the blob, a blob only becomes visible once it is completely written
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete deletes the blob, deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+}
@@ -1,6 +1,11 @@
 package validator
 
-import "github.com/go-playground/validator/v10"
+import (
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+)
 
 type Validator interface {
 	Validate(object any) error
@@ -11,11 +16,26 @@ type appValidator struct {
 }
 
 func NewAppValidator() *appValidator {
+	v := validator.New()
+
+	// validator does not know how to check if a struct is empty,
+	// so duration is validated as its string value and a zero duration as ""
+	v.RegisterCustomTypeFunc(durationValue, enums.Duration{})
+
 	return &appValidator{
-		v: validator.New(),
+		v: v,
 	}
 }
 
 func (av *appValidator) Validate(object any) error {
 	return av.v.Struct(object)
 }
+
+func durationValue(field reflect.Value) any {
+	duration, ok := field.Interface().(enums.Duration)
+	if !ok || duration == (enums.Duration{}) {
+		return ""
+	}
+
+	return duration.String()
+}
@@ -0,0 +1,189 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+)
+
+type AnalyticsRepo interface {
+	GetSpendByDuration(ctx context.Context, userID uuid.UUID) ([]*SpendByDurationRow, error)
+	GetTopSpendSubscriptions(
+		ctx context.Context,
+		userID uuid.UUID,
+		limit int,
+	) ([]*TopSpendSubscriptionRow, error)
+	CountUnpricedSubscriptions(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+type analyticsRepo struct {
+	db *sql.DB
+}
+
+func NewAnalyticsRepo(db *sql.DB) *analyticsRepo {
+	return &analyticsRepo{db}
+}
+
+// recurringAmountExpr is the price billed every period,
+// a subscription in its free trial is billed its post trial price once the trial ends
+const recurringAmountExpr = `CASE
+	WHEN trial_end_date IS NOT NULL AND end_date <= trial_end_date THEN COALESCE(post_trial_amount, amount)
+	ELSE amount
+END`
+
+// monthlyAmountExpr normalizes recurringAmountExpr to an average month, the same way enums.Duration
+// adds its unit to a date: a year is 12 months, 52 weeks or 365 days.
+// It is numeric so sums are only rounded once
+var monthlyAmountExpr = fmt.Sprintf(`(%s) * CASE interval_unit
+	WHEN '%s' THEN 365.0 / 12
+	WHEN '%s' THEN 52.0 / 12
+	WHEN '%s' THEN 1.0
+	WHEN '%s' THEN 1.0 / 12
+END / interval_count`, recurringAmountExpr, enums.Day, enums.Week, enums.Month, enums.Year)
+
+// spendFilter matches the subscriptions which will keep being billed
+const spendFilter = activeSubscriptionFilter + ` AND cancel_at_period_end = false`
+
+// SpendByDurationRow sums the subscriptions of a currency billed with the same duration,
+// amounts are in minor units of Currency
+type SpendByDurationRow struct {
+	Currency     string
+	Duration     enums.Duration
+	Count        int
+	Total        int64
+	MonthlyTotal int64
+	YearlyTotal  int64
+}
+
+func (repo *analyticsRepo) GetSpendByDuration(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*SpendByDurationRow, error) {
+	query := fmt.Sprintf(`
+		SELECT currency, interval_count, interval_unit, COUNT(*),
+			SUM(%[1]s), ROUND(SUM(%[2]s))::bigint, ROUND(SUM(%[2]s) * 12)::bigint
+		FROM subscriptions
+		WHERE user_id = $1 AND %[3]s AND (%[1]s) IS NOT NULL AND currency IS NOT NULL
+		GROUP BY currency, interval_count, interval_unit
+		ORDER BY currency, interval_unit, interval_count
+	`, recurringAmountExpr, monthlyAmountExpr, spendFilter)
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*SpendByDurationRow
+	for rows.Next() {
+		var row SpendByDurationRow
+		err := rows.Scan(
+			&row.Currency,
+			&row.Duration.Count,
+			&row.Duration.Unit,
+			&row.Count,
+			&row.Total,
+			&row.MonthlyTotal,
+			&row.YearlyTotal,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, &row)
+	}
+
+	return res, rows.Err()
+}
+
+type TopSpendSubscriptionRow struct {
+	Currency      string
+	Name          string
+	Duration      enums.Duration
+	Amount        int64
+	MonthlyAmount int64
+	YearlyAmount  int64
+	ID            uuid.UUID
+}
+
+// GetTopSpendSubscriptions returns the limit most expensive subscriptions of every currency,
+// ordered by currency and then by monthly amount, most expensive first
+func (repo *analyticsRepo) GetTopSpendSubscriptions(
+	ctx context.Context,
+	userID uuid.UUID,
+	limit int,
+) ([]*TopSpendSubscriptionRow, error) {
+	query := fmt.Sprintf(`
+		SELECT id, name, currency, interval_count, interval_unit, amount, monthly_amount, yearly_amount
+		FROM (
+			SELECT id, name, currency, interval_count, interval_unit,
+				%[1]s AS amount,
+				ROUND(%[2]s)::bigint AS monthly_amount,
+				ROUND(%[2]s * 12)::bigint AS yearly_amount,
+				ROW_NUMBER() OVER (PARTITION BY currency ORDER BY %[2]s DESC, id) AS spend_rank
+			FROM subscriptions
+			WHERE user_id = $1 AND %[3]s AND (%[1]s) IS NOT NULL AND currency IS NOT NULL
+		) ranked
+		WHERE spend_rank <= $2
+		ORDER BY currency, spend_rank
+	`, recurringAmountExpr, monthlyAmountExpr, spendFilter)
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*TopSpendSubscriptionRow
+	for rows.Next() {
+		var row TopSpendSubscriptionRow
+		err := rows.Scan(
+			&row.ID,
+			&row.Name,
+			&row.Currency,
+			&row.Duration.Count,
+			&row.Duration.Unit,
+			&row.Amount,
+			&row.MonthlyAmount,
+			&row.YearlyAmount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, &row)
+	}
+
+	return res, rows.Err()
+}
+
+// CountUnpricedSubscriptions counts the billed subscriptions left out of spend because their price is unknown
+func (repo *analyticsRepo) CountUnpricedSubscriptions(
+	ctx context.Context,
+	userID uuid.UUID,
+) (int, error) {
+	query := fmt.Sprintf(`
+		SELECT COUNT(*) FROM subscriptions
+		WHERE user_id = $1 AND %s AND ((%s) IS NULL OR currency IS NULL)
+	`, spendFilter, recurringAmountExpr)
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var count int
+	err := repo.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,219 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+)
+
+type AttachmentRepo interface {
+	GetAttachments(ctx context.Context, subscriptionID uuid.UUID) ([]*models.Attachment, error)
+	GetAttachment(ctx context.Context, id, userID uuid.UUID) (*models.Attachment, error)
+	GetUserAttachments(ctx context.Context, userID uuid.UUID) ([]*models.Attachment, error)
+	GetUserAttachmentsSize(ctx context.Context, userID uuid.UUID) (int64, error)
+	GetOrphanAttachments(ctx context.Context) ([]*models.Attachment, error)
+	CreateAttachment(ctx context.Context, arg *CreateAttachmentParams) (*models.Attachment, error)
+	DeleteAttachment(ctx context.Context, id, userID uuid.UUID) error
+}
+
+type attachmentRepo struct {
+	db *sql.DB
+}
+
+func NewAttachmentRepo(db *sql.DB) *attachmentRepo {
+	return &attachmentRepo{db}
+}
+
+const attachmentColumns = `id, user_id, subscription_id, file_name, content_type, size, storage_key, created_at`
+
+func scanAttachment(row rowScanner) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.UserID,
+		&attachment.SubscriptionID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.StorageKey,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (repo *attachmentRepo) queryAttachments(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]*models.Attachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []*models.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+// GetAttachments returns the attachments of a subscription, newest first
+func (repo *attachmentRepo) GetAttachments(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+) ([]*models.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + ` FROM attachments WHERE subscription_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	return repo.queryAttachments(ctx, query, subscriptionID)
+}
+
+// GetAttachment returns an attachment of userID,
+// the attachments of a subscription in the trash can not be read until it is restored
+func (repo *attachmentRepo) GetAttachment(
+	ctx context.Context,
+	id, userID uuid.UUID,
+) (*models.Attachment, error) {
+	query := `
+		SELECT a.id, a.user_id, a.subscription_id, a.file_name, a.content_type, a.size,
+			a.storage_key, a.created_at
+		FROM attachments a
+		JOIN subscriptions s ON s.id = a.subscription_id
+		WHERE a.id = $1 AND a.user_id = $2 AND s.deleted_at IS NULL
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	return scanAttachment(repo.db.QueryRowContext(ctx, query, id, userID))
+}
+
+// GetUserAttachments returns every attachment of a user, most recent first
+func (repo *attachmentRepo) GetUserAttachments(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + ` FROM attachments WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	return repo.queryAttachments(ctx, query, userID)
+}
+
+// GetUserAttachmentsSize returns the total size in bytes of the attachments of a user
+func (repo *attachmentRepo) GetUserAttachmentsSize(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `SELECT COALESCE(SUM(size), 0) FROM attachments WHERE user_id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var size int64
+	err := repo.db.QueryRowContext(ctx, query, userID).Scan(&size)
+
+	return size, err
+}
+
+// GetOrphanAttachments returns the attachments whose subscription was purged
+func (repo *attachmentRepo) GetOrphanAttachments(ctx context.Context) ([]*models.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE subscription_id IS NULL`
+
+	return repo.queryAttachments(ctx, query)
+}
+
+type CreateAttachmentParams struct {
+	FileName       string
+	ContentType    string
+	StorageKey     string
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	SubscriptionID uuid.UUID
+	Size           int64
+	// Quota is the total size in bytes the attachments of the user may take, including this one
+	Quota int64
+}
+
+// CreateAttachment creates an attachment unless it takes the attachments of the user over arg.Quota,
+// in which case it returns sql.ErrNoRows.
+// It has to be called in a transaction: the row of the user stays locked until the transaction ends,
+// so concurrent uploads of a user are checked against the quota one after another
+func (repo *attachmentRepo) CreateAttachment(
+	ctx context.Context,
+	arg *CreateAttachmentParams,
+) (*models.Attachment, error) {
+	ex := getExcutor(ctx, repo.db)
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	// NO KEY UPDATE does not block the inserts referencing the user, e.g. a new subscription
+	_, err := ex.ExecContext(ctx, `SELECT 1 FROM users WHERE id = $1 FOR NO KEY UPDATE`, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO attachments (id, user_id, subscription_id, file_name, content_type, size, storage_key)
+		SELECT $1, $2, $3, $4, $5, $6, $7
+		WHERE (SELECT COALESCE(SUM(size), 0) FROM attachments WHERE user_id = $2) + $6 <= $8
+		RETURNING ` + attachmentColumns
+
+	row := ex.QueryRowContext(
+		ctx,
+		query,
+		arg.ID,
+		arg.UserID,
+		arg.SubscriptionID,
+		arg.FileName,
+		arg.ContentType,
+		arg.Size,
+		arg.StorageKey,
+		arg.Quota,
+	)
+
+	return scanAttachment(row)
+}
+
+// DeleteAttachment deletes an attachment of userID, its content is left in the blob storage.
+// It returns sql.ErrNoRows when there is no such attachment
+func (repo *attachmentRepo) DeleteAttachment(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM attachments WHERE id = $1 AND user_id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := repo.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if num == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -13,6 +13,7 @@ type AuthProviderRepo interface {
 		ctx context.Context,
 		arg *CreateAuthProviderParams,
 	) (*models.AuthProvider, error)
+	GetAuthProvidersByUserID(ctx context.Context, userID uuid.UUID) ([]*models.AuthProvider, error)
 }
 
 type authProviderRepo struct {
@@ -57,3 +58,42 @@ func (repo *authProviderRepo) CreateAuthProvider(
 
 	return &authProvider, nil
 }
+
+func (repo *authProviderRepo) GetAuthProvidersByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.AuthProvider, error) {
+	query := `
+		SELECT id, user_id, provider, provider_id, created_at
+		FROM auth_providers WHERE user_id = $1
+		ORDER BY created_at ASC
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	authProviders := []*models.AuthProvider{}
+	for rows.Next() {
+		var authProvider models.AuthProvider
+		err := rows.Scan(
+			&authProvider.ID,
+			&authProvider.UserID,
+			&authProvider.Provider,
+			&authProvider.ProviderID,
+			&authProvider.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		authProviders = append(authProviders, &authProvider)
+	}
+
+	return authProviders, rows.Err()
+}
@@ -0,0 +1,225 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+)
+
+type BudgetRepo interface {
+	GetBudgets(ctx context.Context, userID uuid.UUID) ([]*models.Budget, error)
+	GetBudgetUserIDs(ctx context.Context) ([]uuid.UUID, error)
+	SetBudget(ctx context.Context, arg *SetBudgetParams) (*models.Budget, error)
+	DeleteBudget(ctx context.Context, id, userID uuid.UUID) error
+	CreateBudgetAlert(ctx context.Context, budgetID uuid.UUID, month time.Time, threshold int) (bool, error)
+	DeleteBudgetAlert(ctx context.Context, budgetID uuid.UUID, month time.Time, threshold int) error
+}
+
+type budgetRepo struct {
+	db *sql.DB
+}
+
+func NewBudgetRepo(db *sql.DB) *budgetRepo {
+	return &budgetRepo{db}
+}
+
+const budgetColumns = `id, user_id, interval_count, interval_unit, amount, currency, created_at, updated_at`
+
+func scanBudget(row rowScanner) (*models.Budget, error) {
+	var budget models.Budget
+	var count sql.NullInt32
+	var unit sql.NullString
+	err := row.Scan(
+		&budget.ID,
+		&budget.UserID,
+		&count,
+		&unit,
+		&budget.Amount,
+		&budget.Currency,
+		&budget.CreatedAt,
+		&budget.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if count.Valid && unit.Valid {
+		budget.Duration = &enums.Duration{Unit: enums.IntervalUnit(unit.String), Count: int(count.Int32)}
+	}
+
+	return &budget, nil
+}
+
+// GetBudgets returns the budgets of a user ordered by currency,
+// the budget covering every subscription comes first
+func (repo *budgetRepo) GetBudgets(ctx context.Context, userID uuid.UUID) ([]*models.Budget, error) {
+	query := `
+		SELECT ` + budgetColumns + ` FROM budgets WHERE user_id = $1
+		ORDER BY currency ASC, interval_unit ASC NULLS FIRST, interval_count ASC
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	budgets := []*models.Budget{}
+	for rows.Next() {
+		budget, err := scanBudget(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		budgets = append(budgets, budget)
+	}
+
+	return budgets, rows.Err()
+}
+
+// GetBudgetUserIDs returns the users having at least one budget
+func (repo *budgetRepo) GetBudgetUserIDs(ctx context.Context) ([]uuid.UUID, error) {
+	query := `SELECT DISTINCT user_id FROM budgets`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+type SetBudgetParams struct {
+	// Duration is nil for the budget covering every subscription
+	Duration *enums.Duration
+	Currency string
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	Amount   int64
+}
+
+// SetBudget creates the budget of the currency and duration, or updates its amount when it exists.
+// arg.ID is only used when the budget is created
+func (repo *budgetRepo) SetBudget(ctx context.Context, arg *SetBudgetParams) (*models.Budget, error) {
+	query := `
+		INSERT INTO budgets (id, user_id, interval_count, interval_unit, amount, currency)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, currency, (COALESCE(interval_unit, '')), (COALESCE(interval_count, 0)))
+		DO UPDATE SET amount = EXCLUDED.amount, updated_at = NOW()
+		RETURNING ` + budgetColumns
+
+	var count *int
+	var unit *enums.IntervalUnit
+	if arg.Duration != nil {
+		count, unit = &arg.Duration.Count, &arg.Duration.Unit
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(
+		ctx,
+		query,
+		arg.ID,
+		arg.UserID,
+		count,
+		unit,
+		arg.Amount,
+		arg.Currency,
+	)
+
+	return scanBudget(row)
+}
+
+// DeleteBudget deletes a budget with its alerts.
+// It returns sql.ErrNoRows when there is no such budget for userID
+func (repo *budgetRepo) DeleteBudget(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM budgets WHERE id = $1 AND user_id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := repo.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if num == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CreateBudgetAlert records that a threshold of the budget was alerted for the month,
+// it returns false when it was already alerted
+func (repo *budgetRepo) CreateBudgetAlert(
+	ctx context.Context,
+	budgetID uuid.UUID,
+	month time.Time,
+	threshold int,
+) (bool, error) {
+	query := `
+		INSERT INTO budget_alerts (budget_id, month, threshold) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := repo.db.ExecContext(ctx, query, budgetID, month, threshold)
+	if err != nil {
+		return false, err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return num > 0, nil
+}
+
+// DeleteBudgetAlert forgets that a threshold of the budget was alerted for the month,
+// so it is alerted again
+func (repo *budgetRepo) DeleteBudgetAlert(
+	ctx context.Context,
+	budgetID uuid.UUID,
+	month time.Time,
+	threshold int,
+) error {
+	query := `DELETE FROM budget_alerts WHERE budget_id = $1 AND month = $2 AND threshold = $3`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	_, err := repo.db.ExecContext(ctx, query, budgetID, month, threshold)
+
+	return err
+}
@@ -0,0 +1,394 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+)
+
+type CatalogRepo interface {
+	SearchCatalog(ctx context.Context, query string, limit int) ([]*models.CatalogEntry, error)
+	GetCatalogEntry(ctx context.Context, id uuid.UUID) (*models.CatalogEntry, error)
+	GetCatalogPlan(ctx context.Context, id uuid.UUID) (*CatalogPlanRow, error)
+	CreateCatalogEntry(ctx context.Context, arg *SaveCatalogEntryParams) (bool, error)
+	SeedCatalogEntry(ctx context.Context, arg *SaveCatalogEntryParams) (bool, error)
+	UpdateCatalogEntry(ctx context.Context, arg *SaveCatalogEntryParams) error
+	SaveCatalogPlan(ctx context.Context, arg *SaveCatalogPlanParams) error
+	DeleteCatalogPlans(ctx context.Context, serviceID uuid.UUID, keepSlugs []string) error
+	DeleteCatalogEntry(ctx context.Context, id uuid.UUID) error
+}
+
+type catalogRepo struct {
+	db *sql.DB
+}
+
+func NewCatalogRepo(db *sql.DB) *catalogRepo {
+	return &catalogRepo{db}
+}
+
+const catalogEntryColumns = `id, slug, name, logo_url, cancel_url, created_at, updated_at`
+
+const catalogPlanColumns = `id, service_id, slug, name, amount, currency, interval_count, interval_unit`
+
+func scanCatalogEntry(row rowScanner) (*models.CatalogEntry, error) {
+	var entry models.CatalogEntry
+	err := row.Scan(
+		&entry.ID,
+		&entry.Slug,
+		&entry.Name,
+		&entry.LogoURL,
+		&entry.CancelURL,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Plans = []*models.CatalogPlan{}
+
+	return &entry, nil
+}
+
+func scanCatalogPlan(row rowScanner, dest ...any) (*models.CatalogPlan, error) {
+	var plan models.CatalogPlan
+	err := row.Scan(append([]any{
+		&plan.ID,
+		&plan.ServiceID,
+		&plan.Slug,
+		&plan.Name,
+		&plan.Amount,
+		&plan.Currency,
+		&plan.Duration.Count,
+		&plan.Duration.Unit,
+	}, dest...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+// SearchCatalog returns at most limit services whose name or slug contains query,
+// names starting with query come first, an empty query returns every service by name
+func (repo *catalogRepo) SearchCatalog(
+	ctx context.Context,
+	query string,
+	limit int,
+) ([]*models.CatalogEntry, error) {
+	sqlQuery := `
+		SELECT ` + catalogEntryColumns + ` FROM catalog_services
+		WHERE name ILIKE $1 ESCAPE '\' OR slug ILIKE $1 ESCAPE '\'
+		ORDER BY name ILIKE $2 ESCAPE '\' DESC, name ASC
+		LIMIT $3
+	`
+
+	pattern := escapeLike(query)
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, sqlQuery, "%"+pattern+"%", pattern+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*models.CatalogEntry{}
+	for rows.Next() {
+		entry, err := scanCatalogEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, repo.loadCatalogPlans(ctx, entries)
+}
+
+func (repo *catalogRepo) GetCatalogEntry(
+	ctx context.Context,
+	id uuid.UUID,
+) (*models.CatalogEntry, error) {
+	query := `SELECT ` + catalogEntryColumns + ` FROM catalog_services WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	entry, err := scanCatalogEntry(repo.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, repo.loadCatalogPlans(ctx, []*models.CatalogEntry{entry})
+}
+
+// loadCatalogPlans sets the plans of entries ordered by duration then name
+func (repo *catalogRepo) loadCatalogPlans(ctx context.Context, entries []*models.CatalogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(entries))
+	byID := make(map[uuid.UUID]*models.CatalogEntry, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+		byID[entry.ID] = entry
+	}
+
+	query := `
+		SELECT ` + catalogPlanColumns + ` FROM catalog_plans
+		WHERE service_id = ANY($1::uuid[])
+		ORDER BY interval_unit ASC, interval_count ASC, amount ASC NULLS LAST, name ASC
+	`
+
+	rows, err := repo.db.QueryContext(ctx, query, uuidArray(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		plan, err := scanCatalogPlan(rows)
+		if err != nil {
+			return err
+		}
+
+		entry := byID[plan.ServiceID]
+		entry.Plans = append(entry.Plans, plan)
+	}
+
+	return rows.Err()
+}
+
+// CatalogPlanRow is a catalog plan with the name of its service
+type CatalogPlanRow struct {
+	Plan        *models.CatalogPlan
+	ServiceName string
+}
+
+func (repo *catalogRepo) GetCatalogPlan(ctx context.Context, id uuid.UUID) (*CatalogPlanRow, error) {
+	query := `
+		SELECT p.id, p.service_id, p.slug, p.name, p.amount, p.currency, p.interval_count,
+			p.interval_unit, s.name
+		FROM catalog_plans p JOIN catalog_services s ON s.id = p.service_id
+		WHERE p.id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var row CatalogPlanRow
+	plan, err := scanCatalogPlan(repo.db.QueryRowContext(ctx, query, id), &row.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+	row.Plan = plan
+
+	return &row, nil
+}
+
+type SaveCatalogEntryParams struct {
+	Slug      string
+	Name      string
+	LogoURL   string
+	CancelURL string
+	ID        uuid.UUID
+}
+
+// CreateCatalogEntry creates a catalog service, it returns false when a service with arg.Slug exists
+func (repo *catalogRepo) CreateCatalogEntry(
+	ctx context.Context,
+	arg *SaveCatalogEntryParams,
+) (bool, error) {
+	query := `
+		INSERT INTO catalog_services (id, slug, name, logo_url, cancel_url)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (slug) DO NOTHING
+	`
+
+	return repo.insertCatalogEntry(ctx, query, arg)
+}
+
+// SeedCatalogEntry creates a service of the built-in catalog, it returns false when a service with arg.Slug
+// exists or was deleted by an admin
+func (repo *catalogRepo) SeedCatalogEntry(
+	ctx context.Context,
+	arg *SaveCatalogEntryParams,
+) (bool, error) {
+	query := `
+		INSERT INTO catalog_services (id, slug, name, logo_url, cancel_url)
+		SELECT $1::uuid, $2::varchar, $3::varchar, $4::text, $5::text
+		WHERE NOT EXISTS (SELECT 1 FROM catalog_deleted_slugs WHERE slug = $2)
+		ON CONFLICT (slug) DO NOTHING
+	`
+
+	return repo.insertCatalogEntry(ctx, query, arg)
+}
+
+func (repo *catalogRepo) insertCatalogEntry(
+	ctx context.Context,
+	query string,
+	arg *SaveCatalogEntryParams,
+) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := getExcutor(ctx, repo.db).ExecContext(
+		ctx,
+		query,
+		arg.ID,
+		arg.Slug,
+		arg.Name,
+		arg.LogoURL,
+		arg.CancelURL,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return num > 0, nil
+}
+
+// UpdateCatalogEntry updates the name and urls of a catalog service, its slug never changes.
+// It returns sql.ErrNoRows when there is no such service
+func (repo *catalogRepo) UpdateCatalogEntry(ctx context.Context, arg *SaveCatalogEntryParams) error {
+	query := `
+		UPDATE catalog_services SET name = $1, logo_url = $2, cancel_url = $3, updated_at = NOW()
+		WHERE id = $4
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := getExcutor(ctx, repo.db).ExecContext(
+		ctx,
+		query,
+		arg.Name,
+		arg.LogoURL,
+		arg.CancelURL,
+		arg.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if num == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+type SaveCatalogPlanParams struct {
+	Amount    *int64
+	Currency  *string
+	Slug      string
+	Name      string
+	Duration  enums.Duration
+	ID        uuid.UUID
+	ServiceID uuid.UUID
+}
+
+// SaveCatalogPlan creates the plan of a service, or updates it when the service has a plan with arg.Slug.
+// arg.ID is only used when the plan is created
+func (repo *catalogRepo) SaveCatalogPlan(ctx context.Context, arg *SaveCatalogPlanParams) error {
+	query := `
+		INSERT INTO catalog_plans (id, service_id, slug, name, amount, currency, interval_count, interval_unit)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (service_id, slug) DO UPDATE SET
+			name = EXCLUDED.name,
+			amount = EXCLUDED.amount,
+			currency = EXCLUDED.currency,
+			interval_count = EXCLUDED.interval_count,
+			interval_unit = EXCLUDED.interval_unit
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	_, err := getExcutor(ctx, repo.db).ExecContext(
+		ctx,
+		query,
+		arg.ID,
+		arg.ServiceID,
+		arg.Slug,
+		arg.Name,
+		arg.Amount,
+		arg.Currency,
+		arg.Duration.Count,
+		arg.Duration.Unit,
+	)
+
+	return err
+}
+
+// DeleteCatalogPlans deletes the plans of a service except the ones with a slug in keepSlugs
+func (repo *catalogRepo) DeleteCatalogPlans(
+	ctx context.Context,
+	serviceID uuid.UUID,
+	keepSlugs []string,
+) error {
+	query := `DELETE FROM catalog_plans WHERE service_id = $1 AND NOT (slug = ANY($2::text[]))`
+
+	// a nil array is NULL in postgres and NOT (slug = ANY(NULL)) would keep every plan
+	if keepSlugs == nil {
+		keepSlugs = []string{}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	_, err := getExcutor(ctx, repo.db).ExecContext(ctx, query, serviceID, pq.StringArray(keepSlugs))
+
+	return err
+}
+
+// DeleteCatalogEntry deletes a catalog service with its plans and records its slug so it is not seeded again,
+// subscriptions created from them are kept as they are.
+// It returns sql.ErrNoRows when there is no such service
+func (repo *catalogRepo) DeleteCatalogEntry(ctx context.Context, id uuid.UUID) error {
+	query := `
+		WITH deleted AS (DELETE FROM catalog_services WHERE id = $1 RETURNING slug)
+		INSERT INTO catalog_deleted_slugs (slug) SELECT slug FROM deleted
+		ON CONFLICT (slug) DO UPDATE SET deleted_at = NOW()
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := repo.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if num == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package repo_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteCatalogEntry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %s", err)
+	}
+	defer db.Close()
+
+	id := uuid.New()
+
+	// the slug of the deleted service is recorded in the same statement
+	query := `WITH deleted AS \(DELETE FROM catalog_services WHERE id = \$1 RETURNING slug\)\s+` +
+		`INSERT INTO catalog_deleted_slugs \(slug\) SELECT slug FROM deleted`
+
+	testCases := []struct {
+		err      error
+		name     string
+		affected int64
+	}{
+		{name: "Deleted service is recorded", affected: 1},
+		{name: "No service found", affected: 0, err: sql.ErrNoRows},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock.ExpectExec(query).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, tc.affected))
+
+			err := repo.NewCatalogRepo(db).DeleteCatalogEntry(context.Background(), id)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSeedCatalogEntry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %s", err)
+	}
+	defer db.Close()
+
+	arg := &repo.SaveCatalogEntryParams{ID: uuid.New(), Slug: "netflix", Name: "Netflix"}
+
+	// a slug deleted by an admin is skipped like an existing one
+	query := `INSERT INTO catalog_services .+\s+` +
+		`WHERE NOT EXISTS \(SELECT 1 FROM catalog_deleted_slugs WHERE slug = \$2\)\s+` +
+		`ON CONFLICT \(slug\) DO NOTHING`
+
+	testCases := []struct {
+		name     string
+		affected int64
+		created  bool
+	}{
+		{name: "Missing service is created", affected: 1, created: true},
+		{name: "Existing or deleted service is skipped", affected: 0, created: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock.ExpectExec(query).
+				WithArgs(arg.ID, arg.Slug, arg.Name, arg.LogoURL, arg.CancelURL).
+				WillReturnResult(sqlmock.NewResult(0, tc.affected))
+
+			created, err := repo.NewCatalogRepo(db).SeedCatalogEntry(context.Background(), arg)
+			require.NoError(t, err)
+			require.Equal(t, tc.created, created)
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
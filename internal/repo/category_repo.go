@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+)
+
+type CategoryRepo interface {
+	GetAllCategories(ctx context.Context, userID uuid.UUID) ([]*models.Category, error)
+	GetCategoryByID(ctx context.Context, id uuid.UUID) (*models.Category, error)
+	GetCategoryByName(ctx context.Context, userID uuid.UUID, name string) (*models.Category, error)
+	CreateCategory(ctx context.Context, arg *CreateCategoryParams) (*models.Category, error)
+	UpdateCategory(ctx context.Context, arg *UpdateCategoryParams) (*models.Category, error)
+	DeleteCategory(ctx context.Context, id, userID uuid.UUID) error
+}
+
+type categoryRepo struct {
+	db *sql.DB
+}
+
+func NewCategoryRepo(db *sql.DB) *categoryRepo {
+	return &categoryRepo{db}
+}
+
+const categoryColumns = `id, user_id, name, created_at`
+
+func scanCategory(row rowScanner) (*models.Category, error) {
+	var category models.Category
+	err := row.Scan(
+		&category.ID,
+		&category.UserID,
+		&category.Name,
+		&category.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (repo *categoryRepo) GetAllCategories(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE user_id = $1 ORDER BY name ASC`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []*models.Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+func (repo *categoryRepo) GetCategoryByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	return scanCategory(repo.db.QueryRowContext(ctx, query, id))
+}
+
+func (repo *categoryRepo) GetCategoryByName(
+	ctx context.Context,
+	userID uuid.UUID,
+	name string,
+) (*models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE user_id = $1 AND name = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	return scanCategory(repo.db.QueryRowContext(ctx, query, userID, name))
+}
+
+type CreateCategoryParams struct {
+	Name   string
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (repo *categoryRepo) CreateCategory(
+	ctx context.Context,
+	arg *CreateCategoryParams,
+) (*models.Category, error) {
+	query := `
+		INSERT INTO categories (id, user_id, name) VALUES ($1, $2, $3)
+		RETURNING ` + categoryColumns
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(ctx, query, arg.ID, arg.UserID, arg.Name)
+
+	return scanCategory(row)
+}
+
+type UpdateCategoryParams struct {
+	Name   string
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+// UpdateCategory renames a category, the row is only updated when it belongs to arg.UserID
+func (repo *categoryRepo) UpdateCategory(
+	ctx context.Context,
+	arg *UpdateCategoryParams,
+) (*models.Category, error) {
+	query := `
+		UPDATE categories SET name = $1 WHERE id = $2 AND user_id = $3
+		RETURNING ` + categoryColumns
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(ctx, query, arg.Name, arg.ID, arg.UserID)
+
+	return scanCategory(row)
+}
+
+// DeleteCategory deletes a category, its subscriptions become uncategorized.
+// It returns sql.ErrNoRows when there is no such category for userID
+func (repo *categoryRepo) DeleteCategory(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM categories WHERE id = $1 AND user_id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := repo.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if num == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
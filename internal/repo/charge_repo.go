@@ -0,0 +1,167 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+)
+
+type ChargeRepo interface {
+	CreateCharge(ctx context.Context, arg *CreateChargeParams) (bool, error)
+	GetCharges(ctx context.Context, arg *GetChargesParams) ([]*models.Charge, error)
+}
+
+type chargeRepo struct {
+	db *sql.DB
+}
+
+func NewChargeRepo(db *sql.DB) *chargeRepo {
+	return &chargeRepo{db}
+}
+
+const chargeColumns = `id, user_id, subscription_id, subscription_name, period_start, period_end,
+	amount, currency, created_at`
+
+func scanCharge(row rowScanner) (*models.Charge, error) {
+	var charge models.Charge
+	var periodStart, periodEnd time.Time
+	err := row.Scan(
+		&charge.ID,
+		&charge.UserID,
+		&charge.SubscriptionID,
+		&charge.SubscriptionName,
+		&periodStart,
+		&periodEnd,
+		&charge.Amount,
+		&charge.Currency,
+		&charge.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	charge.PeriodStart = models.SubscriptionTime(periodStart)
+	charge.PeriodEnd = models.SubscriptionTime(periodEnd)
+
+	return &charge, nil
+}
+
+type CreateChargeParams struct {
+	PeriodStart      time.Time
+	PeriodEnd        time.Time
+	Amount           *int64
+	Currency         *string
+	SubscriptionName string
+	ID               uuid.UUID
+	UserID           uuid.UUID
+	SubscriptionID   uuid.UUID
+}
+
+// CreateCharge books the charge of a period, it returns false without changing anything
+// when the period of the subscription is already booked
+func (repo *chargeRepo) CreateCharge(ctx context.Context, arg *CreateChargeParams) (bool, error) {
+	query := `
+		INSERT INTO charges (id, user_id, subscription_id, subscription_name, period_start, period_end,
+			amount, currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (subscription_id, period_start) DO NOTHING
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	// a backfill books many periods in a single transaction
+	res, err := getExcutor(ctx, repo.db).ExecContext(
+		ctx,
+		query,
+		arg.ID,
+		arg.UserID,
+		arg.SubscriptionID,
+		arg.SubscriptionName,
+		arg.PeriodStart,
+		arg.PeriodEnd,
+		arg.Amount,
+		arg.Currency,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return num > 0, nil
+}
+
+type GetChargesParams struct {
+	SubscriptionID *uuid.UUID
+	// From and To filter on the period start, both are inclusive
+	From     *time.Time
+	To       *time.Time
+	Currency string
+	UserID   uuid.UUID
+}
+
+// GetCharges returns the charges of a user, most recent period first
+func (repo *chargeRepo) GetCharges(
+	ctx context.Context,
+	arg *GetChargesParams,
+) ([]*models.Charge, error) {
+	whereClauses := []string{"user_id = $1"}
+	args := []any{arg.UserID}
+	argIndex := 2
+
+	if arg.SubscriptionID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("subscription_id = $%d", argIndex))
+		args = append(args, *arg.SubscriptionID)
+		argIndex++
+	}
+
+	if arg.Currency != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("currency = $%d", argIndex))
+		args = append(args, arg.Currency)
+		argIndex++
+	}
+
+	if arg.From != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("period_start >= $%d", argIndex))
+		args = append(args, *arg.From)
+		argIndex++
+	}
+
+	if arg.To != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("period_start <= $%d", argIndex))
+		args = append(args, *arg.To)
+	}
+
+	query := `SELECT ` + chargeColumns + ` FROM charges
+		WHERE ` + strings.Join(whereClauses, " AND ") + `
+		ORDER BY period_start DESC, id DESC`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	charges := []*models.Charge{}
+	for rows.Next() {
+		charge, err := scanCharge(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		charges = append(charges, charge)
+	}
+
+	return charges, rows.Err()
+}
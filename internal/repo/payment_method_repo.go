@@ -0,0 +1,256 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+)
+
+type PaymentMethodRepo interface {
+	GetPaymentMethods(ctx context.Context, userID uuid.UUID) ([]*models.PaymentMethod, error)
+	GetPaymentMethod(ctx context.Context, id, userID uuid.UUID) (*models.PaymentMethod, error)
+	GetExpiringPaymentMethods(ctx context.Context, from, to time.Time) ([]*models.PaymentMethod, error)
+	CreatePaymentMethod(ctx context.Context, arg *SavePaymentMethodParams) (*models.PaymentMethod, error)
+	UpdatePaymentMethod(ctx context.Context, arg *SavePaymentMethodParams) (*models.PaymentMethod, error)
+	MarkPaymentMethodReminded(ctx context.Context, id uuid.UUID) (bool, error)
+	UnmarkPaymentMethodReminded(ctx context.Context, id uuid.UUID) error
+	DeletePaymentMethod(ctx context.Context, id, userID uuid.UUID) error
+}
+
+type paymentMethodRepo struct {
+	db *sql.DB
+}
+
+func NewPaymentMethodRepo(db *sql.DB) *paymentMethodRepo {
+	return &paymentMethodRepo{db}
+}
+
+const paymentMethodColumns = `id, user_id, label, brand, last4, expiry_month, expiry_year, created_at, updated_at`
+
+// paymentMethodExpiresAt is the start of the month after the expiry month of a card
+const paymentMethodExpiresAt = `(make_date(expiry_year, expiry_month, 1) + INTERVAL '1 month')`
+
+func scanPaymentMethod(row rowScanner) (*models.PaymentMethod, error) {
+	var method models.PaymentMethod
+	err := row.Scan(
+		&method.ID,
+		&method.UserID,
+		&method.Label,
+		&method.Brand,
+		&method.Last4,
+		&method.ExpiryMonth,
+		&method.ExpiryYear,
+		&method.CreatedAt,
+		&method.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &method, nil
+}
+
+func (repo *paymentMethodRepo) queryPaymentMethods(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]*models.PaymentMethod, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	methods := []*models.PaymentMethod{}
+	for rows.Next() {
+		method, err := scanPaymentMethod(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		methods = append(methods, method)
+	}
+
+	return methods, rows.Err()
+}
+
+// GetPaymentMethods returns the payment methods of a user ordered by label
+func (repo *paymentMethodRepo) GetPaymentMethods(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.PaymentMethod, error) {
+	query := `
+		SELECT ` + paymentMethodColumns + ` FROM payment_methods WHERE user_id = $1
+		ORDER BY label, created_at
+	`
+
+	return repo.queryPaymentMethods(ctx, query, userID)
+}
+
+// GetPaymentMethod returns a payment method of userID
+func (repo *paymentMethodRepo) GetPaymentMethod(
+	ctx context.Context,
+	id, userID uuid.UUID,
+) (*models.PaymentMethod, error) {
+	query := `SELECT ` + paymentMethodColumns + ` FROM payment_methods WHERE id = $1 AND user_id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	return scanPaymentMethod(repo.db.QueryRowContext(ctx, query, id, userID))
+}
+
+// GetExpiringPaymentMethods returns the payment methods expiring after from and at the latest at to
+// whose owner was not warned yet
+func (repo *paymentMethodRepo) GetExpiringPaymentMethods(
+	ctx context.Context,
+	from, to time.Time,
+) ([]*models.PaymentMethod, error) {
+	query := `
+		SELECT ` + paymentMethodColumns + ` FROM payment_methods
+		WHERE expiry_reminded_at IS NULL
+		AND ` + paymentMethodExpiresAt + ` > $1 AND ` + paymentMethodExpiresAt + ` <= $2
+		ORDER BY user_id, id
+	`
+
+	return repo.queryPaymentMethods(ctx, query, from, to)
+}
+
+type SavePaymentMethodParams struct {
+	Label       string
+	Brand       string
+	Last4       string
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	ExpiryMonth int
+	ExpiryYear  int
+}
+
+func (repo *paymentMethodRepo) CreatePaymentMethod(
+	ctx context.Context,
+	arg *SavePaymentMethodParams,
+) (*models.PaymentMethod, error) {
+	query := `
+		INSERT INTO payment_methods (id, user_id, label, brand, last4, expiry_month, expiry_year)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + paymentMethodColumns
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(
+		ctx,
+		query,
+		arg.ID,
+		arg.UserID,
+		arg.Label,
+		arg.Brand,
+		arg.Last4,
+		arg.ExpiryMonth,
+		arg.ExpiryYear,
+	)
+
+	return scanPaymentMethod(row)
+}
+
+// UpdatePaymentMethod replaces a payment method of arg.UserID,
+// the owner is warned again when the expiry changed
+func (repo *paymentMethodRepo) UpdatePaymentMethod(
+	ctx context.Context,
+	arg *SavePaymentMethodParams,
+) (*models.PaymentMethod, error) {
+	query := `
+		UPDATE payment_methods
+		SET label = $1, brand = $2, last4 = $3, expiry_month = $4, expiry_year = $5,
+			expiry_reminded_at = CASE
+				WHEN expiry_month = $4 AND expiry_year = $5 THEN expiry_reminded_at
+			END,
+			updated_at = NOW()
+		WHERE id = $6 AND user_id = $7
+		RETURNING ` + paymentMethodColumns
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(
+		ctx,
+		query,
+		arg.Label,
+		arg.Brand,
+		arg.Last4,
+		arg.ExpiryMonth,
+		arg.ExpiryYear,
+		arg.ID,
+		arg.UserID,
+	)
+
+	return scanPaymentMethod(row)
+}
+
+// MarkPaymentMethodReminded records that the owner was warned about the expiry of a payment method,
+// it returns false when they were already warned
+func (repo *paymentMethodRepo) MarkPaymentMethodReminded(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE payment_methods SET expiry_reminded_at = NOW()
+		WHERE id = $1 AND expiry_reminded_at IS NULL
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := repo.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return num > 0, nil
+}
+
+// UnmarkPaymentMethodReminded forgets that the owner was warned about the expiry of a payment method,
+// so they are warned again
+func (repo *paymentMethodRepo) UnmarkPaymentMethodReminded(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE payment_methods SET expiry_reminded_at = NULL WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	_, err := repo.db.ExecContext(ctx, query, id)
+
+	return err
+}
+
+// DeletePaymentMethod deletes a payment method of userID, its subscriptions are unlinked.
+// It returns sql.ErrNoRows when there is no such payment method
+func (repo *paymentMethodRepo) DeletePaymentMethod(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM payment_methods WHERE id = $1 AND user_id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := repo.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if num == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+)
+
+type ReminderLogRepo interface {
+	CreateReminderLog(ctx context.Context, arg *CreateReminderLogParams) error
+	GetUserReminderLogs(ctx context.Context, userID uuid.UUID) ([]*models.ReminderLog, error)
+}
+
+type reminderLogRepo struct {
+	db *sql.DB
+}
+
+func NewReminderLogRepo(db *sql.DB) *reminderLogRepo {
+	return &reminderLogRepo{db}
+}
+
+type CreateReminderLogParams struct {
+	SubscriptionName string
+	Kind             string
+	ID               uuid.UUID
+	UserID           uuid.UUID
+	SubscriptionID   uuid.UUID
+	DaysBefore       int
+}
+
+func (repo *reminderLogRepo) CreateReminderLog(
+	ctx context.Context,
+	arg *CreateReminderLogParams,
+) error {
+	query := `
+		INSERT INTO reminder_logs (id, user_id, subscription_id, subscription_name, kind, days_before)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	_, err := repo.db.ExecContext(
+		ctx,
+		query,
+		arg.ID,
+		arg.UserID,
+		arg.SubscriptionID,
+		arg.SubscriptionName,
+		arg.Kind,
+		arg.DaysBefore,
+	)
+
+	return err
+}
+
+// GetUserReminderLogs returns the reminders sent to the user, most recent first
+func (repo *reminderLogRepo) GetUserReminderLogs(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.ReminderLog, error) {
+	query := `
+		SELECT id, user_id, subscription_id, subscription_name, kind, days_before, sent_at
+		FROM reminder_logs WHERE user_id = $1
+		ORDER BY sent_at DESC, id DESC
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := []*models.ReminderLog{}
+	for rows.Next() {
+		var log models.ReminderLog
+		err := rows.Scan(
+			&log.ID,
+			&log.UserID,
+			&log.SubscriptionID,
+			&log.SubscriptionName,
+			&log.Kind,
+			&log.DaysBefore,
+			&log.SentAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, &log)
+	}
+
+	return logs, rows.Err()
+}
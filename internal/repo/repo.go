@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // QueryTimeOut defines the standard timeout for database operations
@@ -11,21 +14,43 @@ const QueryTimeOut = time.Second * 10
 
 // Repo contains all repository interfaces
 type Repo struct {
-	User         UserRepo
-	Subscription SubscriptionRepo
-	Session      SessionRepo
-	AuthProvider AuthProviderRepo
-	Transaction  TransactionManager
+	User          UserRepo
+	Subscription  SubscriptionRepo
+	Session       SessionRepo
+	AuthProvider  AuthProviderRepo
+	Category      CategoryRepo
+	Tag           TagRepo
+	Analytics     AnalyticsRepo
+	ReminderLog   ReminderLogRepo
+	Price         SubscriptionPriceRepo
+	Charge        ChargeRepo
+	Budget        BudgetRepo
+	Member        SubscriptionMemberRepo
+	Catalog       CatalogRepo
+	Attachment    AttachmentRepo
+	PaymentMethod PaymentMethodRepo
+	Transaction   TransactionManager
 }
 
 // NewRepo creates a new repository instance with all dependencies
 func NewRepo(db *sql.DB) *Repo {
 	return &Repo{
-		User:         NewUserRepo(db),
-		Subscription: NewSubsciptionRepo(db),
-		Session:      NewSessionRepo(db),
-		AuthProvider: NewAuthProviderRepo(db),
-		Transaction:  NewTransactionManager(db),
+		User:          NewUserRepo(db),
+		Subscription:  NewSubsciptionRepo(db),
+		Session:       NewSessionRepo(db),
+		AuthProvider:  NewAuthProviderRepo(db),
+		Category:      NewCategoryRepo(db),
+		Tag:           NewTagRepo(db),
+		Analytics:     NewAnalyticsRepo(db),
+		ReminderLog:   NewReminderLogRepo(db),
+		Price:         NewSubscriptionPriceRepo(db),
+		Charge:        NewChargeRepo(db),
+		Budget:        NewBudgetRepo(db),
+		Member:        NewSubscriptionMemberRepo(db),
+		Catalog:       NewCatalogRepo(db),
+		Attachment:    NewAttachmentRepo(db),
+		PaymentMethod: NewPaymentMethodRepo(db),
+		Transaction:   NewTransactionManager(db),
 	}
 }
 
@@ -34,6 +59,21 @@ type Executor interface {
 	ExecContext(ctx context.Context, query string, params ...any) (sql.Result, error)
 }
 
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// uuidArray converts ids to a postgres array parameter, e.g. for "id = ANY($1::uuid[])"
+func uuidArray(ids []uuid.UUID) any {
+	arr := make([]string, 0, len(ids))
+	for _, id := range ids {
+		arr = append(arr, id.String())
+	}
+
+	return pq.StringArray(arr)
+}
+
 // TransactionManager defines the interface for transaction operations
 type TransactionManager interface {
 	WithTx(ctx context.Context, f func(txContext context.Context) error) error
@@ -53,6 +93,12 @@ func (t *transactionManager) WithTx(
 	ctx context.Context,
 	f func(txContext context.Context) error,
 ) error {
+	// a nested call joins the transaction it is called in,
+	// e.g. an import creates many subscriptions which each write their price
+	if tx, ok := ctx.Value(TxKey{}).(*sql.Tx); ok && tx != nil {
+		return f(ctx)
+	}
+
 	tx, err := t.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -14,6 +14,7 @@ type SessionRepo interface {
 	CreateSession(ctx context.Context, arg *CreateSessionParams) (*models.Session, error)
 	RevokeSession(ctx context.Context, id uuid.UUID) error
 	DeleteSession(ctx context.Context, id uuid.UUID) error
+	GetSessionsByUserEmail(ctx context.Context, email string) ([]*models.Session, error)
 }
 
 type sessionRepo struct {
@@ -105,3 +106,44 @@ func (repo *sessionRepo) DeleteSession(ctx context.Context, id uuid.UUID) error
 
 	return err
 }
+
+// GetSessionsByUserEmail returns the sessions of a user, newest first.
+// Refresh tokens are secrets and are not selected, so RefreshToken is always empty
+func (repo *sessionRepo) GetSessionsByUserEmail(
+	ctx context.Context,
+	email string,
+) ([]*models.Session, error) {
+	query := `
+		SELECT id, user_email, COALESCE(is_revoked, false), created_at, expires_at
+		FROM sessions WHERE user_email = $1
+		ORDER BY created_at DESC
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*models.Session{}
+	for rows.Next() {
+		var session models.Session
+		err := rows.Scan(
+			&session.ID,
+			&session.UserEmail,
+			&session.IsRevoked,
+			&session.CreatedAt,
+			&session.ExpiresAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, &session)
+	}
+
+	return sessions, rows.Err()
+}
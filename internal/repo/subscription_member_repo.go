@@ -0,0 +1,310 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+)
+
+type SubscriptionMemberRepo interface {
+	CreateMember(ctx context.Context, arg *CreateMemberParams) (*models.SubscriptionMember, error)
+	GetMembers(ctx context.Context, subscriptionID uuid.UUID) ([]*models.SubscriptionMember, error)
+	GetUserMemberships(ctx context.Context, userID uuid.UUID) ([]*models.SubscriptionMember, error)
+	GetInvitations(ctx context.Context, userID uuid.UUID) ([]*models.SubscriptionInvitation, error)
+	RespondInvitation(
+		ctx context.Context,
+		id, userID uuid.UUID,
+		status string,
+	) (*models.SubscriptionMember, error)
+	DeleteMember(ctx context.Context, id, subscriptionID uuid.UUID) error
+	GetSharedMembers(ctx context.Context, userID uuid.UUID) ([]*SharedMemberRow, error)
+}
+
+type subscriptionMemberRepo struct {
+	db *sql.DB
+}
+
+func NewSubscriptionMemberRepo(db *sql.DB) *subscriptionMemberRepo {
+	return &subscriptionMemberRepo{db}
+}
+
+// memberColumns are selected from subscription_members aliased m joined with the users of members aliased u
+const memberColumns = `m.id, m.subscription_id, m.user_id, u.email, m.share_percent, m.share_amount,
+	m.status, m.invited_at, m.responded_at`
+
+func scanMember(row rowScanner, dest ...any) (*models.SubscriptionMember, error) {
+	var member models.SubscriptionMember
+	err := row.Scan(append([]any{
+		&member.ID,
+		&member.SubscriptionID,
+		&member.UserID,
+		&member.Email,
+		&member.SharePercent,
+		&member.ShareAmount,
+		&member.Status,
+		&member.InvitedAt,
+		&member.RespondedAt,
+	}, dest...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+type CreateMemberParams struct {
+	SharePercent   *int
+	ShareAmount    *int64
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	UserID         uuid.UUID
+}
+
+// CreateMember invites a user to a subscription, a member who declined is invited again with the new share.
+// It returns sql.ErrNoRows when the user is already a pending or accepted member
+func (repo *subscriptionMemberRepo) CreateMember(
+	ctx context.Context,
+	arg *CreateMemberParams,
+) (*models.SubscriptionMember, error) {
+	query := `
+		WITH m AS (
+			INSERT INTO subscription_members (id, subscription_id, user_id, share_percent, share_amount)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (subscription_id, user_id) DO UPDATE SET
+				share_percent = EXCLUDED.share_percent,
+				share_amount = EXCLUDED.share_amount,
+				status = 'pending',
+				invited_at = NOW(),
+				responded_at = NULL
+			WHERE subscription_members.status = 'declined'
+			RETURNING *
+		)
+		SELECT ` + memberColumns + ` FROM m JOIN users u ON u.id = m.user_id
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(
+		ctx,
+		query,
+		arg.ID,
+		arg.SubscriptionID,
+		arg.UserID,
+		arg.SharePercent,
+		arg.ShareAmount,
+	)
+
+	return scanMember(row)
+}
+
+// GetMembers returns every member of a subscription whatever their status, in invitation order
+func (repo *subscriptionMemberRepo) GetMembers(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+) ([]*models.SubscriptionMember, error) {
+	query := `
+		SELECT ` + memberColumns + `
+		FROM subscription_members m JOIN users u ON u.id = m.user_id
+		WHERE m.subscription_id = $1
+		ORDER BY m.invited_at ASC, m.id ASC
+	`
+
+	return repo.queryMembers(ctx, query, subscriptionID)
+}
+
+// GetUserMemberships returns the memberships of a user and the members of the subscriptions they own,
+// whatever their status, in invitation order
+func (repo *subscriptionMemberRepo) GetUserMemberships(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.SubscriptionMember, error) {
+	query := `
+		SELECT ` + memberColumns + `
+		FROM subscription_members m
+		JOIN users u ON u.id = m.user_id
+		JOIN subscriptions s ON s.id = m.subscription_id
+		WHERE m.user_id = $1 OR s.user_id = $1
+		ORDER BY m.invited_at ASC, m.id ASC
+	`
+
+	return repo.queryMembers(ctx, query, userID)
+}
+
+func (repo *subscriptionMemberRepo) queryMembers(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]*models.SubscriptionMember, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []*models.SubscriptionMember{}
+	for rows.Next() {
+		member, err := scanMember(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+// GetInvitations returns the pending invitations of a user to subscriptions which are not in trash,
+// most recent first
+func (repo *subscriptionMemberRepo) GetInvitations(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.SubscriptionInvitation, error) {
+	query := `
+		SELECT m.id, m.subscription_id, s.name, owner.email, s.amount, s.currency,
+			s.interval_count, s.interval_unit, m.share_percent, m.share_amount, m.invited_at
+		FROM subscription_members m
+		JOIN subscriptions s ON s.id = m.subscription_id
+		JOIN users owner ON owner.id = s.user_id
+		WHERE m.user_id = $1 AND m.status = 'pending' AND s.deleted_at IS NULL
+		ORDER BY m.invited_at DESC, m.id DESC
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []*models.SubscriptionInvitation{}
+	for rows.Next() {
+		var invitation models.SubscriptionInvitation
+		err := rows.Scan(
+			&invitation.ID,
+			&invitation.SubscriptionID,
+			&invitation.SubscriptionName,
+			&invitation.OwnerEmail,
+			&invitation.Amount,
+			&invitation.Currency,
+			&invitation.Duration.Count,
+			&invitation.Duration.Unit,
+			&invitation.SharePercent,
+			&invitation.ShareAmount,
+			&invitation.InvitedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		invitations = append(invitations, &invitation)
+	}
+
+	return invitations, rows.Err()
+}
+
+// RespondInvitation accepts or declines a pending invitation of userID.
+// It returns sql.ErrNoRows when there is no such pending invitation
+func (repo *subscriptionMemberRepo) RespondInvitation(
+	ctx context.Context,
+	id, userID uuid.UUID,
+	status string,
+) (*models.SubscriptionMember, error) {
+	query := `
+		WITH m AS (
+			UPDATE subscription_members SET status = $1, responded_at = NOW()
+			WHERE id = $2 AND user_id = $3 AND status = 'pending'
+			RETURNING *
+		)
+		SELECT ` + memberColumns + ` FROM m JOIN users u ON u.id = m.user_id
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	return scanMember(repo.db.QueryRowContext(ctx, query, status, id, userID))
+}
+
+// DeleteMember removes a member from a subscription.
+// It returns sql.ErrNoRows when there is no such member
+func (repo *subscriptionMemberRepo) DeleteMember(ctx context.Context, id, subscriptionID uuid.UUID) error {
+	query := `DELETE FROM subscription_members WHERE id = $1 AND subscription_id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := repo.db.ExecContext(ctx, query, id, subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if num == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SharedMemberRow is an accepted member with the owner of the subscription it shares
+type SharedMemberRow struct {
+	Member           *models.SubscriptionMember
+	OwnerEmail       string
+	SubscriptionName string
+	OwnerID          uuid.UUID
+}
+
+// GetSharedMembers returns the accepted members of every subscription the user owns or is an accepted member of,
+// grouped by subscription
+func (repo *subscriptionMemberRepo) GetSharedMembers(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*SharedMemberRow, error) {
+	query := `
+		SELECT ` + memberColumns + `, s.user_id, owner.email, s.name
+		FROM subscription_members m
+		JOIN users u ON u.id = m.user_id
+		JOIN subscriptions s ON s.id = m.subscription_id
+		JOIN users owner ON owner.id = s.user_id
+		WHERE m.status = 'accepted' AND (
+			s.user_id = $1 OR s.id IN (
+				SELECT subscription_id FROM subscription_members WHERE user_id = $1 AND status = 'accepted'
+			)
+		)
+		ORDER BY s.id ASC, m.invited_at ASC, m.id ASC
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []*SharedMemberRow{}
+	for rows.Next() {
+		var row SharedMemberRow
+		row.Member, err = scanMember(rows, &row.OwnerID, &row.OwnerEmail, &row.SubscriptionName)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, &row)
+	}
+
+	return res, rows.Err()
+}
@@ -0,0 +1,195 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+)
+
+type SubscriptionPriceRepo interface {
+	CreateSubscriptionPrice(
+		ctx context.Context,
+		arg *CreateSubscriptionPriceParams,
+	) (*models.SubscriptionPrice, error)
+	GetSubscriptionPrices(
+		ctx context.Context,
+		subscriptionID uuid.UUID,
+	) ([]*models.SubscriptionPrice, error)
+	GetSubscriptionPriceAt(
+		ctx context.Context,
+		subscriptionID uuid.UUID,
+		at time.Time,
+	) (*models.SubscriptionPrice, error)
+	GetScheduledSubscriptionPrices(
+		ctx context.Context,
+		userID uuid.UUID,
+	) ([]*models.SubscriptionPrice, error)
+	GetUserSubscriptionPrices(
+		ctx context.Context,
+		userID uuid.UUID,
+	) ([]*models.SubscriptionPrice, error)
+}
+
+type subscriptionPriceRepo struct {
+	db *sql.DB
+}
+
+func NewSubscriptionPriceRepo(db *sql.DB) *subscriptionPriceRepo {
+	return &subscriptionPriceRepo{db}
+}
+
+const subscriptionPriceColumns = `id, subscription_id, amount, currency, effective_from, created_at`
+
+func scanSubscriptionPrice(row rowScanner) (*models.SubscriptionPrice, error) {
+	var price models.SubscriptionPrice
+	var effectiveFrom time.Time
+	err := row.Scan(
+		&price.ID,
+		&price.SubscriptionID,
+		&price.Amount,
+		&price.Currency,
+		&effectiveFrom,
+		&price.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	price.EffectiveFrom = models.SubscriptionTime(effectiveFrom)
+
+	return &price, nil
+}
+
+type CreateSubscriptionPriceParams struct {
+	EffectiveFrom  time.Time
+	Currency       string
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	Amount         int64
+}
+
+// CreateSubscriptionPrice adds a price to the history of a subscription,
+// it replaces the price of the subscription effective from the same day
+func (repo *subscriptionPriceRepo) CreateSubscriptionPrice(
+	ctx context.Context,
+	arg *CreateSubscriptionPriceParams,
+) (*models.SubscriptionPrice, error) {
+	query := `
+		INSERT INTO subscription_prices (id, subscription_id, amount, currency, effective_from)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (subscription_id, effective_from)
+		DO UPDATE SET amount = EXCLUDED.amount, currency = EXCLUDED.currency, created_at = NOW()
+		RETURNING ` + subscriptionPriceColumns
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	// prices are written in the transaction changing the subscription
+	row := getExcutor(ctx, repo.db).QueryRowContext(
+		ctx,
+		query,
+		arg.ID,
+		arg.SubscriptionID,
+		arg.Amount,
+		arg.Currency,
+		arg.EffectiveFrom,
+	)
+
+	return scanSubscriptionPrice(row)
+}
+
+// GetSubscriptionPrices returns the price history of a subscription, oldest first
+func (repo *subscriptionPriceRepo) GetSubscriptionPrices(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+) ([]*models.SubscriptionPrice, error) {
+	query := `
+		SELECT ` + subscriptionPriceColumns + ` FROM subscription_prices
+		WHERE subscription_id = $1
+		ORDER BY effective_from ASC
+	`
+
+	return repo.queryPrices(ctx, query, subscriptionID)
+}
+
+// GetScheduledSubscriptionPrices returns the prices of the subscriptions of the user taking effect
+// after their current period started, by subscription and oldest first
+func (repo *subscriptionPriceRepo) GetScheduledSubscriptionPrices(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.SubscriptionPrice, error) {
+	query := `
+		SELECT ` + subscriptionPriceColumns + ` FROM subscription_prices p
+		WHERE EXISTS (
+			SELECT 1 FROM subscriptions s
+			WHERE s.id = p.subscription_id AND s.user_id = $1 AND p.effective_from > s.start_date
+		)
+		ORDER BY subscription_id ASC, effective_from ASC
+	`
+
+	return repo.queryPrices(ctx, query, userID)
+}
+
+// GetUserSubscriptionPrices returns the price history of every subscription of the user,
+// by subscription and oldest first
+func (repo *subscriptionPriceRepo) GetUserSubscriptionPrices(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.SubscriptionPrice, error) {
+	query := `
+		SELECT ` + subscriptionPriceColumns + ` FROM subscription_prices p
+		WHERE EXISTS (SELECT 1 FROM subscriptions s WHERE s.id = p.subscription_id AND s.user_id = $1)
+		ORDER BY subscription_id ASC, effective_from ASC
+	`
+
+	return repo.queryPrices(ctx, query, userID)
+}
+
+func (repo *subscriptionPriceRepo) queryPrices(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]*models.SubscriptionPrice, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := []*models.SubscriptionPrice{}
+	for rows.Next() {
+		price, err := scanSubscriptionPrice(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		prices = append(prices, price)
+	}
+
+	return prices, rows.Err()
+}
+
+// GetSubscriptionPriceAt returns the price of a subscription in effect at a date,
+// it returns sql.ErrNoRows when the subscription had no known price then
+func (repo *subscriptionPriceRepo) GetSubscriptionPriceAt(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	at time.Time,
+) (*models.SubscriptionPrice, error) {
+	query := `
+		SELECT ` + subscriptionPriceColumns + ` FROM subscription_prices
+		WHERE subscription_id = $1 AND effective_from <= $2
+		ORDER BY effective_from DESC
+		LIMIT 1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	return scanSubscriptionPrice(repo.db.QueryRowContext(ctx, query, subscriptionID, at))
+}
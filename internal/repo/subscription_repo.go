@@ -4,10 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sangtandoan/subscription_tracker/internal/models"
 	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
 )
@@ -21,8 +25,37 @@ type SubscriptionRepo interface {
 		ctx context.Context,
 		arg CreateSubscriptionParams,
 	) (*SubscriptionRow, error)
+	GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*SubscriptionRow, error)
+	GetOwnedOrSharedSubscription(ctx context.Context, id, userID uuid.UUID) (*SubscriptionRow, error)
+	UpdateSubscription(ctx context.Context, arg *UpdateSubscriptionParams) (*SubscriptionRow, error)
+	CancelSubscription(ctx context.Context, arg *CancelSubscriptionParams) (*SubscriptionRow, error)
+	ReactivateSubscription(ctx context.Context, id, userID uuid.UUID) (*SubscriptionRow, error)
+	UpdateSubscriptionCategory(
+		ctx context.Context,
+		arg *UpdateSubscriptionCategoryParams,
+	) (*SubscriptionRow, error)
+	UpdateSubscriptionPaymentMethod(
+		ctx context.Context,
+		arg *UpdateSubscriptionPaymentMethodParams,
+	) (*SubscriptionRow, error)
+	GetPaymentMethodSubscriptions(
+		ctx context.Context,
+		paymentMethodID uuid.UUID,
+	) ([]*SubscriptionRow, error)
 	GetSubscriptionsBeforeNumDays(ctx context.Context, num int) ([]*SubscriptionRow, error)
+	GetUserSubscriptions(ctx context.Context, userID uuid.UUID) ([]*SubscriptionRow, error)
+	CountUserSubscriptions(ctx context.Context, userID uuid.UUID) (int, error)
+	GetUpcomingSubscriptions(
+		ctx context.Context,
+		userID uuid.UUID,
+		before time.Time,
+	) ([]*SubscriptionRow, error)
 	GetSubscriptionsNeedUpdateStartAndEndDate(ctx context.Context) ([]*SubscriptionRow, error)
+	ConvertTrialSubscription(ctx context.Context, arg *ConvertTrialSubscriptionParams) error
+	EndCancelledSubscriptions(ctx context.Context) (int64, error)
+	DeleteSubscription(ctx context.Context, id, userID uuid.UUID) error
+	RestoreSubscription(ctx context.Context, id, userID uuid.UUID) (*SubscriptionRow, error)
+	PurgeDeletedSubscriptions(ctx context.Context, before time.Time) (int64, error)
 	UpdateSubscriptionStartAndEndDate(
 		ctx context.Context,
 		arg *UpdateSubscriptionStartAndEndDateParams,
@@ -41,13 +74,102 @@ func NewSubsciptionRepo(db *sql.DB) *subscriptionRepo {
 // because models.Subscription has a custom type SubscriptionTime
 // which postgres driver can not scan directly to it
 type SubscriptionRow struct {
-	StartDate   time.Time
-	EndDate     time.Time
-	Name        string
-	Duration    string
-	ID          uuid.UUID
-	UserID      uuid.UUID
-	IsCancelled bool
+	CreatedAt         time.Time
+	StartDate         time.Time
+	EndDate           time.Time
+	Amount            *int64
+	Currency          *string
+	TrialEndDate      *time.Time
+	PostTrialAmount   *int64
+	CategoryID        *uuid.UUID
+	Notes             *string
+	PaymentMethodID   *uuid.UUID
+	CancelledAt       *time.Time
+	EndedAt           *time.Time
+	DeletedAt         *time.Time
+	Name              string
+	Duration          enums.Duration
+	TagIDs            []uuid.UUID
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	BillingAnchorDay  int
+	IsCancelled       bool
+	CancelAtPeriodEnd bool
+}
+
+// subscriptionColumns is the list of columns selected for every SubscriptionRow,
+// the order must match the order of fields scanned in scanSubscriptionRow.
+//
+// Tag ids are aggregated with a sub query so every query returning subscriptions also returns their tags
+const subscriptionColumns = `id, user_id, name, start_date, end_date, interval_count, interval_unit, is_cancelled, amount, currency,
+	cancelled_at, cancel_at_period_end, ended_at, deleted_at, billing_anchor_day,
+	trial_end_date, post_trial_amount, category_id, created_at, notes, payment_method_id,
+	ARRAY(
+		SELECT tag_id::text FROM subscription_tags
+		WHERE subscription_tags.subscription_id = subscriptions.id ORDER BY tag_id
+	)`
+
+// scanSubscriptionRow scans a row selected with subscriptionColumns into a SubscriptionRow,
+// it works for both *sql.Row and *sql.Rows
+func scanSubscriptionRow(row rowScanner) (*SubscriptionRow, error) {
+	var sub SubscriptionRow
+	var tagIDs pq.StringArray
+	err := row.Scan(
+		&sub.ID,
+		&sub.UserID,
+		&sub.Name,
+		&sub.StartDate,
+		&sub.EndDate,
+		&sub.Duration.Count,
+		&sub.Duration.Unit,
+		&sub.IsCancelled,
+		&sub.Amount,
+		&sub.Currency,
+		&sub.CancelledAt,
+		&sub.CancelAtPeriodEnd,
+		&sub.EndedAt,
+		&sub.DeletedAt,
+		&sub.BillingAnchorDay,
+		&sub.TrialEndDate,
+		&sub.PostTrialAmount,
+		&sub.CategoryID,
+		&sub.CreatedAt,
+		&sub.Notes,
+		&sub.PaymentMethodID,
+		&tagIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.TagIDs = make([]uuid.UUID, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		id, err := uuid.Parse(tagID)
+		if err != nil {
+			return nil, err
+		}
+
+		sub.TagIDs = append(sub.TagIDs, id)
+	}
+
+	return &sub, nil
+}
+
+// scanSubscriptionRows scans all rows selected with subscriptionColumns and closes rows
+func scanSubscriptionRows(rows *sql.Rows) ([]*SubscriptionRow, error) {
+	defer rows.Close()
+
+	var subs []*SubscriptionRow
+	for rows.Next() {
+		sub, err := scanSubscriptionRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
 }
 
 func (row *SubscriptionRow) MapToSubscriptionModel(sub *models.Subscription) error {
@@ -57,36 +179,139 @@ func (row *SubscriptionRow) MapToSubscriptionModel(sub *models.Subscription) err
 	temp.UserID = row.UserID
 	temp.Name = row.Name
 	temp.IsCancelled = row.IsCancelled
+	temp.Amount = row.Amount
+	temp.Currency = row.Currency
+	temp.CancelledAt = row.CancelledAt
+	temp.CancelAtPeriodEnd = row.CancelAtPeriodEnd
+	temp.EndedAt = row.EndedAt
+	temp.DeletedAt = row.DeletedAt
+	temp.BillingAnchorDay = row.BillingAnchorDay
+	temp.PostTrialAmount = row.PostTrialAmount
+	temp.CategoryID = row.CategoryID
+	temp.Notes = row.Notes
+	temp.PaymentMethodID = row.PaymentMethodID
+	temp.CreatedAt = row.CreatedAt
+	temp.TagIDs = row.TagIDs
+	temp.InTrial = row.InTrial()
+	if row.TrialEndDate != nil {
+		trialEndDate := models.SubscriptionTime(*row.TrialEndDate)
+		temp.TrialEndDate = &trialEndDate
+	}
+	temp.Status = row.Status()
 	temp.StartDate = models.SubscriptionTime(row.StartDate)
 	temp.EndDate = models.SubscriptionTime(row.EndDate)
 
-	duration, err := enums.ParseString2Duration(row.Duration)
-	if err != nil {
-		return err
+	if !row.Duration.IsValid() {
+		return enums.ErrInvalidDuration
 	}
-	temp.Duration = duration
+	temp.Duration = row.Duration
 
 	*sub = temp
 	return nil
 }
 
+// Status returns the lifecycle status of the subscription
+func (row *SubscriptionRow) Status() string {
+	switch {
+	case row.EndedAt != nil:
+		return models.SubscriptionStatusEnded
+	case row.IsCancelled:
+		return models.SubscriptionStatusCancelled
+	default:
+		return models.SubscriptionStatusActive
+	}
+}
+
+// InTrial reports whether the subscription is still in its free trial,
+// the current period of a trial subscription ends at its trial end date
+func (row *SubscriptionRow) InTrial() bool {
+	return row.TrialEndDate != nil && !row.EndDate.After(*row.TrialEndDate)
+}
+
+// activeSubscriptionFilter matches subscriptions which are still billed,
+// a subscription cancelled at period end is still active but will not be renewed
+const activeSubscriptionFilter = `ended_at IS NULL AND is_cancelled = false AND deleted_at IS NULL`
+
+// ownedOrSharedFilter matches the subscriptions of the user in $1
+// and the subscriptions other users shared with them
+const ownedOrSharedFilter = `(user_id = $1 OR id IN (
+		SELECT subscription_id FROM subscription_members WHERE user_id = $1 AND status = 'accepted'
+	))`
+
 type GetAllSubscriptionsParams struct {
 	IsCancelled *bool
-	UserID      uuid.UUID
-	Limit       int
-	Offset      int
+	CategoryID  *uuid.UUID
+	EndDateFrom *time.Time
+	EndDateTo   *time.Time
+	// Cursor is used instead of Offset for keyset pagination when it is not nil
+	Cursor *SubscriptionCursor
+	Status string
+	// Query is a case-insensitive search on name
+	Query string
+	// Sort is one of models.AllSubscriptionSorts and Order is asc or desc
+	Sort  string
+	Order string
+	// TagIDs only keeps subscriptions having all of these tags
+	TagIDs []uuid.UUID
+	UserID uuid.UUID
+	Limit  int
+	Offset int
+	// Deleted lists subscriptions in trash instead of the normal ones
+	Deleted bool
+}
+
+// SubscriptionCursor points at a row of the list ordered by GetAllSubscriptionsParams.Sort,
+// Value is the sort value of that row returned by SubscriptionRow.CursorValue
+type SubscriptionCursor struct {
+	Value string
+	ID    uuid.UUID
+	// Before returns the rows before the cursor instead of the rows after it
+	Before bool
 }
 
 func (repo *subscriptionRepo) GetAllSubscriptions(
 	ctx context.Context,
 	arg *GetAllSubscriptionsParams,
 ) ([]*SubscriptionRow, int, error) {
-	query := `
-		SELECT id, user_id, name, start_date, end_date, duration, is_cancelled FROM subscriptions`
+	// user_id is always required so it is the first where clause,
+	// subscriptions shared with the user are listed too but the trash only has the subscriptions of the user
+	whereClauses := []string{"user_id = $1"}
+	if !arg.Deleted {
+		whereClauses[0] = ownedOrSharedFilter
+	}
+	args := []any{arg.UserID}
+	argIndex := 2
+
+	if arg.Deleted {
+		whereClauses = append(whereClauses, "deleted_at IS NOT NULL")
+	} else {
+		whereClauses = append(whereClauses, "deleted_at IS NULL")
+	}
+
+	sortExpr, desc, err := subscriptionSortExpr(arg.Sort, arg.Order, arg.Deleted)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// optional query param q, backed by the trigram index on name
+	if arg.Query != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf(`name ILIKE $%d ESCAPE '\'`, argIndex))
+		args = append(args, "%"+escapeLike(arg.Query)+"%")
+		argIndex++
+	}
 
-	var whereClauses []string
-	var args []any
-	argIndex := 1
+	// optional query params end_date_from and end_date_to, both are inclusive
+	if arg.EndDateFrom != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("end_date >= $%d", argIndex))
+		args = append(args, *arg.EndDateFrom)
+		argIndex++
+	}
+
+	if arg.EndDateTo != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("end_date <= $%d", argIndex))
+		args = append(args, *arg.EndDateTo)
+		argIndex++
+	}
 
 	// optinal query param is_cancelled
 	if arg.IsCancelled != nil {
@@ -95,59 +320,96 @@ func (repo *subscriptionRepo) GetAllSubscriptions(
 		argIndex++
 	}
 
-	// add all optionals to query string
-	if len(whereClauses) > 0 {
-		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	// optional query param category
+	if arg.CategoryID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("category_id = $%d", argIndex))
+		args = append(args, *arg.CategoryID)
+		argIndex++
 	}
 
-	// add query userID to query string
-	query += fmt.Sprintf(" AND user_id = $%d", argIndex)
-	args = append(args, arg.UserID)
-	argIndex++
+	// optional query param tag, it can be repeated
+	for _, tagID := range arg.TagIDs {
+		whereClauses = append(whereClauses, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM subscription_tags
+			WHERE subscription_tags.subscription_id = subscriptions.id AND subscription_tags.tag_id = $%d
+		)`, argIndex))
+		args = append(args, tagID)
+		argIndex++
+	}
 
-	// add pagination to quer string
-	query += fmt.Sprintf(" ORDER BY start_date ASC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
-	args = append(args, arg.Limit, arg.Offset)
+	// optional query param status, it does not need any args
+	switch arg.Status {
+	case models.SubscriptionStatusActive:
+		whereClauses = append(whereClauses, activeSubscriptionFilter)
+	case models.SubscriptionStatusCancelled:
+		whereClauses = append(whereClauses, "ended_at IS NULL AND is_cancelled = true")
+	case models.SubscriptionStatusEnded:
+		whereClauses = append(whereClauses, "ended_at IS NOT NULL")
+	}
 
-	rows, err := repo.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, 0, err
+	where := " WHERE " + strings.Join(whereClauses, " AND ")
+
+	// the cursor only applies to the page, count still returns the total of filtered rows
+	pageWhere := where
+	pageArgs := slices.Clone(args)
+	offset := arg.Offset
+
+	// rows before the cursor are read in reverse order and reversed back after scanning
+	if arg.Cursor != nil && arg.Cursor.Before {
+		desc = !desc
 	}
 
-	var res []*SubscriptionRow
-	for rows.Next() {
-		var sub SubscriptionRow
-		err := rows.Scan(
-			&sub.ID,
-			&sub.UserID,
-			&sub.Name,
-			&sub.StartDate,
-			&sub.EndDate,
-			&sub.Duration,
-			&sub.IsCancelled,
-		)
+	if arg.Cursor != nil {
+		value, err := parseSubscriptionCursorValue(arg.Sort, arg.Cursor.Value)
 		if err != nil {
 			return nil, 0, err
 		}
 
-		res = append(res, &sub)
+		// (sort value, id) is unique, so rows are never duplicated or skipped between pages
+		cmp := ">"
+		if desc {
+			cmp = "<"
+		}
+
+		pageWhere += fmt.Sprintf(" AND (%s, id) %s ($%d, $%d)", sortExpr, cmp, argIndex, argIndex+1)
+		pageArgs = append(pageArgs, value, arg.Cursor.ID)
+		argIndex += 2
+		offset = 0
 	}
 
-	query = `SELECT COUNT(*) FROM subscriptions WHERE user_id = $1`
-	// This will create a new memory allocation for args
-	// => costs more memory
-	// args = []any{}
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
 
-	// This will clear the slice but keep the memory allocation
-	// => costs less memory
-	args = args[:0]
-	args = append(args, arg.UserID)
+	// add pagination to quer string
+	query := `SELECT ` + subscriptionColumns + ` FROM subscriptions` + pageWhere
+	query += fmt.Sprintf(
+		" ORDER BY %s %s, id %s LIMIT $%d OFFSET $%d",
+		sortExpr,
+		direction,
+		direction,
+		argIndex,
+		argIndex+1,
+	)
 
-	if arg.IsCancelled != nil {
-		query += " AND is_cancelled = $2"
-		args = append(args, *arg.IsCancelled)
+	rows, err := repo.db.QueryContext(ctx, query, append(pageArgs, arg.Limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res, err := scanSubscriptionRows(rows)
+	if err != nil {
+		return nil, 0, err
 	}
 
+	if arg.Cursor != nil && arg.Cursor.Before {
+		slices.Reverse(res)
+	}
+
+	// count query shares the same filters without pagination
+	query = `SELECT COUNT(*) FROM subscriptions` + where
+
 	var count int
 	err = repo.db.QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
@@ -157,13 +419,117 @@ func (repo *subscriptionRepo) GetAllSubscriptions(
 	return res, count, nil
 }
 
+// subscriptionSortColumns maps sort fields to columns,
+// only these columns can ever be interpolated into ORDER BY
+var subscriptionSortColumns = map[string]string{
+	models.SubscriptionSortName:      "name",
+	models.SubscriptionSortStartDate: "start_date",
+	models.SubscriptionSortEndDate:   "end_date",
+	models.SubscriptionSortPrice:     "amount",
+	models.SubscriptionSortCreatedAt: "created_at",
+}
+
+// subscriptionSortExpr returns the expression the list is ordered by and if it is descending,
+// the list is ordered by start date by default and trash by deletion time, newest first.
+// Order also applies to the default sort.
+//
+// Subscriptions without a price are always listed last, amount is coalesced instead of using NULLS LAST
+// so the same expression can be compared with a cursor
+func subscriptionSortExpr(sort, order string, deleted bool) (string, bool, error) {
+	var desc bool
+	switch order {
+	case "":
+		desc = sort == "" && deleted
+	case models.SortOrderAsc:
+	case models.SortOrderDesc:
+		desc = true
+	default:
+		return "", false, fmt.Errorf("unknown sort order %q", order)
+	}
+
+	if sort == "" {
+		if deleted {
+			return "deleted_at", desc, nil
+		}
+		return "start_date", desc, nil
+	}
+
+	column, ok := subscriptionSortColumns[sort]
+	if !ok {
+		return "", false, fmt.Errorf("unknown sort field %q", sort)
+	}
+
+	if column == "amount" {
+		if desc {
+			return "COALESCE(amount, -1)", true, nil
+		}
+		return fmt.Sprintf("COALESCE(amount, %d)", math.MaxInt64), false, nil
+	}
+
+	return column, desc, nil
+}
+
+// CursorValue returns the value of the row for the sort field, it is the Value of a SubscriptionCursor
+func (row *SubscriptionRow) CursorValue(sort, order string, deleted bool) string {
+	if sort == "" && deleted {
+		return row.DeletedAt.Format(time.RFC3339Nano)
+	}
+
+	switch sort {
+	case models.SubscriptionSortName:
+		return row.Name
+	case models.SubscriptionSortEndDate:
+		return row.EndDate.Format(time.RFC3339Nano)
+	case models.SubscriptionSortCreatedAt:
+		return row.CreatedAt.Format(time.RFC3339Nano)
+	case models.SubscriptionSortPrice:
+		// same value as the coalesced amount in subscriptionSortExpr
+		if row.Amount != nil {
+			return strconv.FormatInt(*row.Amount, 10)
+		}
+		if order == models.SortOrderDesc {
+			return "-1"
+		}
+		return strconv.FormatInt(math.MaxInt64, 10)
+	default:
+		return row.StartDate.Format(time.RFC3339Nano)
+	}
+}
+
+// parseSubscriptionCursorValue converts a cursor value back to the type of the sort expression,
+// sort must already be validated by subscriptionSortExpr
+func parseSubscriptionCursorValue(sort string, value string) (any, error) {
+	switch sort {
+	case models.SubscriptionSortName:
+		return value, nil
+	case models.SubscriptionSortPrice:
+		return strconv.ParseInt(value, 10, 64)
+	default:
+		return time.Parse(time.RFC3339Nano, value)
+	}
+}
+
+// escapeLike escapes wildcards so s is matched literally in a LIKE pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
 type CreateSubscriptionParams struct {
 	StartDate time.Time
 	EndDate   time.Time
-	Name      string
-	Duration  string
-	ID        uuid.UUID
-	UserID    uuid.UUID
+	Amount    *int64
+	Currency  *string
+	// TrialEndDate is nil when the subscription does not start with a free trial
+	TrialEndDate    *time.Time
+	PostTrialAmount *int64
+	CategoryID      *uuid.UUID
+	Notes           *string
+	Name            string
+	Duration        enums.Duration
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	// BillingAnchorDay is the day of month the subscription renews on
+	BillingAnchorDay int
 }
 
 func (repo *subscriptionRepo) CreateSubscription(
@@ -172,15 +538,16 @@ func (repo *subscriptionRepo) CreateSubscription(
 ) (*SubscriptionRow, error) {
 	query := `
 		INSERT INTO 
-		subscriptions (id, user_id, name, start_date, end_date, duration) 
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, user_id, name, start_date, end_date, duration, is_cancelled
-	`
+		subscriptions (id, user_id, name, start_date, end_date, interval_count, interval_unit, amount, currency,
+			billing_anchor_day, trial_end_date, post_trial_amount, category_id, notes) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING ` + subscriptionColumns
 
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
 	defer cancel()
 
-	row := repo.db.QueryRowContext(
+	// imports create many subscriptions in a single transaction
+	row := getExcutor(ctx, repo.db).QueryRowContext(
 		ctx,
 		query,
 		arg.ID,
@@ -188,24 +555,223 @@ func (repo *subscriptionRepo) CreateSubscription(
 		arg.Name,
 		arg.StartDate,
 		arg.EndDate,
-		arg.Duration,
+		arg.Duration.Count,
+		arg.Duration.Unit,
+		arg.Amount,
+		arg.Currency,
+		arg.BillingAnchorDay,
+		arg.TrialEndDate,
+		arg.PostTrialAmount,
+		arg.CategoryID,
+		arg.Notes,
 	)
 
-	var subcription SubscriptionRow
-	err := row.Scan(
-		&subcription.ID,
-		&subcription.UserID,
-		&subcription.Name,
-		&subcription.StartDate,
-		&subcription.EndDate,
-		&subcription.Duration,
-		&subcription.IsCancelled,
+	return scanSubscriptionRow(row)
+}
+
+func (repo *subscriptionRepo) GetSubscriptionByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*SubscriptionRow, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM subscriptions WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(ctx, query, id)
+
+	return scanSubscriptionRow(row)
+}
+
+// GetOwnedOrSharedSubscription returns a subscription the user owns or is an accepted member of,
+// it returns sql.ErrNoRows otherwise
+func (repo *subscriptionRepo) GetOwnedOrSharedSubscription(
+	ctx context.Context,
+	id, userID uuid.UUID,
+) (*SubscriptionRow, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM subscriptions WHERE id = $2 AND ` + ownedOrSharedFilter
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(ctx, query, userID, id)
+
+	return scanSubscriptionRow(row)
+}
+
+type UpdateSubscriptionParams struct {
+	StartDate        time.Time
+	EndDate          time.Time
+	Amount           *int64
+	Currency         *string
+	PostTrialAmount  *int64
+	Notes            *string
+	Name             string
+	Duration         enums.Duration
+	ID               uuid.UUID
+	UserID           uuid.UUID
+	BillingAnchorDay int
+}
+
+// UpdateSubscription updates the editable fields of a subscription,
+// the row is only updated when it belongs to arg.UserID
+func (repo *subscriptionRepo) UpdateSubscription(
+	ctx context.Context,
+	arg *UpdateSubscriptionParams,
+) (*SubscriptionRow, error) {
+	query := `
+		UPDATE subscriptions
+		SET name = $1, start_date = $2, end_date = $3, interval_count = $4, interval_unit = $5,
+			billing_anchor_day = $6, amount = $7, currency = $8, post_trial_amount = $9, notes = $10
+		WHERE id = $11 AND user_id = $12
+		RETURNING ` + subscriptionColumns
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	// a price change is written in the same transaction as its history
+	row := getExcutor(ctx, repo.db).QueryRowContext(
+		ctx,
+		query,
+		arg.Name,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Duration.Count,
+		arg.Duration.Unit,
+		arg.BillingAnchorDay,
+		arg.Amount,
+		arg.Currency,
+		arg.PostTrialAmount,
+		arg.Notes,
+		arg.ID,
+		arg.UserID,
+	)
+
+	return scanSubscriptionRow(row)
+}
+
+type CancelSubscriptionParams struct {
+	CancelledAt time.Time
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	AtPeriodEnd bool
+}
+
+// CancelSubscription cancels a subscription immediately,
+// or at the end of the current period when arg.AtPeriodEnd is true
+func (repo *subscriptionRepo) CancelSubscription(
+	ctx context.Context,
+	arg *CancelSubscriptionParams,
+) (*SubscriptionRow, error) {
+	query := `
+		UPDATE subscriptions
+		SET is_cancelled = NOT $1, cancel_at_period_end = $1, cancelled_at = $2
+		WHERE id = $3 AND user_id = $4
+		RETURNING ` + subscriptionColumns
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(
+		ctx,
+		query,
+		arg.AtPeriodEnd,
+		arg.CancelledAt,
+		arg.ID,
+		arg.UserID,
 	)
+
+	return scanSubscriptionRow(row)
+}
+
+func (repo *subscriptionRepo) ReactivateSubscription(
+	ctx context.Context,
+	id, userID uuid.UUID,
+) (*SubscriptionRow, error) {
+	query := `
+		UPDATE subscriptions
+		SET is_cancelled = false, cancel_at_period_end = false, cancelled_at = NULL
+		WHERE id = $1 AND user_id = $2
+		RETURNING ` + subscriptionColumns
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(ctx, query, id, userID)
+
+	return scanSubscriptionRow(row)
+}
+
+type UpdateSubscriptionCategoryParams struct {
+	// CategoryID is nil to make the subscription uncategorized
+	CategoryID *uuid.UUID
+	ID         uuid.UUID
+	UserID     uuid.UUID
+}
+
+func (repo *subscriptionRepo) UpdateSubscriptionCategory(
+	ctx context.Context,
+	arg *UpdateSubscriptionCategoryParams,
+) (*SubscriptionRow, error) {
+	query := `
+		UPDATE subscriptions SET category_id = $1
+		WHERE id = $2 AND user_id = $3
+		RETURNING ` + subscriptionColumns
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(ctx, query, arg.CategoryID, arg.ID, arg.UserID)
+
+	return scanSubscriptionRow(row)
+}
+
+type UpdateSubscriptionPaymentMethodParams struct {
+	// PaymentMethodID is nil to unlink the payment method of the subscription
+	PaymentMethodID *uuid.UUID
+	ID              uuid.UUID
+	UserID          uuid.UUID
+}
+
+func (repo *subscriptionRepo) UpdateSubscriptionPaymentMethod(
+	ctx context.Context,
+	arg *UpdateSubscriptionPaymentMethodParams,
+) (*SubscriptionRow, error) {
+	query := `
+		UPDATE subscriptions SET payment_method_id = $1
+		WHERE id = $2 AND user_id = $3
+		RETURNING ` + subscriptionColumns
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(ctx, query, arg.PaymentMethodID, arg.ID, arg.UserID)
+
+	return scanSubscriptionRow(row)
+}
+
+// GetPaymentMethodSubscriptions returns the subscriptions paid with a payment method
+// which will renew again, ordered by their next renewal
+func (repo *subscriptionRepo) GetPaymentMethodSubscriptions(
+	ctx context.Context,
+	paymentMethodID uuid.UUID,
+) ([]*SubscriptionRow, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions WHERE payment_method_id = $1
+		AND ` + activeSubscriptionFilter + ` AND cancel_at_period_end = false
+		ORDER BY end_date, id
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, paymentMethodID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &subcription, nil
+	return scanSubscriptionRows(rows)
 }
 
 func (repo *subscriptionRepo) GetSubscriptionsBeforeNumDays(
@@ -213,8 +779,9 @@ func (repo *subscriptionRepo) GetSubscriptionsBeforeNumDays(
 	num int,
 ) ([]*SubscriptionRow, error) {
 	query := `
-		SELECT id, user_id, name, start_date, end_date, duration, is_cancelled
+		SELECT ` + subscriptionColumns + `
 		FROM subscriptions WHERE end_date <= $1 AND end_date + INTERVAL '1 day' >= $1
+		AND ` + activeSubscriptionFilter + ` AND cancel_at_period_end = false
 	`
 
 	futureAfterNumDays := time.Now().AddDate(0, 0, num)
@@ -224,35 +791,87 @@ func (repo *subscriptionRepo) GetSubscriptionsBeforeNumDays(
 		return nil, err
 	}
 
-	var subs []*SubscriptionRow
-	for rows.Next() {
-		var sub SubscriptionRow
-		err := rows.Scan(
-			&sub.ID,
-			&sub.UserID,
-			&sub.Name,
-			&sub.StartDate,
-			&sub.EndDate,
-			&sub.Duration,
-			&sub.IsCancelled,
-		)
-		if err != nil {
-			return nil, err
-		}
+	return scanSubscriptionRows(rows)
+}
+
+// GetUserSubscriptions returns every subscription of the user including the ones in trash,
+// ordered by creation
+func (repo *subscriptionRepo) GetUserSubscriptions(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*SubscriptionRow, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions WHERE user_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanSubscriptionRows(rows)
+}
+
+// CountUserSubscriptions counts every subscription of the user including the ones in trash
+func (repo *subscriptionRepo) CountUserSubscriptions(
+	ctx context.Context,
+	userID uuid.UUID,
+) (int, error) {
+	query := `SELECT COUNT(*) FROM subscriptions WHERE user_id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var count int
+	err := repo.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetUpcomingSubscriptions returns the subscriptions of userID which will be renewed
+// at least once before the given time, ordered by end date
+func (repo *subscriptionRepo) GetUpcomingSubscriptions(
+	ctx context.Context,
+	userID uuid.UUID,
+	before time.Time,
+) ([]*SubscriptionRow, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE user_id = $1 AND end_date < $2
+		AND ` + activeSubscriptionFilter + ` AND cancel_at_period_end = false
+		ORDER BY end_date ASC, id ASC
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
 
-		subs = append(subs, &sub)
+	rows, err := repo.db.QueryContext(ctx, query, userID, before)
+	if err != nil {
+		return nil, err
 	}
 
-	return subs, nil
+	return scanSubscriptionRows(rows)
 }
 
+// GetSubscriptionsNeedUpdateStartAndEndDate returns the active subscriptions whose period ended,
+// including the ones a previous run failed to renew
 func (repo *subscriptionRepo) GetSubscriptionsNeedUpdateStartAndEndDate(
 	ctx context.Context,
 ) ([]*SubscriptionRow, error) {
 	query := `
-	    SELECT id, user_id, name, start_date, end_date, duration, is_cancelled 
+	    SELECT ` + subscriptionColumns + `
 		FROM subscriptions
-	    WHERE end_date <= $1 and end_date + INTERVAL '1 day' >= $1
+	    WHERE end_date <= $1
+		AND ` + activeSubscriptionFilter + ` AND cancel_at_period_end = false
 	`
 	now := time.Now()
 	rows, err := repo.db.QueryContext(ctx, query, now)
@@ -260,32 +879,101 @@ func (repo *subscriptionRepo) GetSubscriptionsNeedUpdateStartAndEndDate(
 		return nil, err
 	}
 
-	var subs []*SubscriptionRow
-	for rows.Next() {
-		var sub SubscriptionRow
-		err := rows.Scan(
-			&sub.ID,
-			&sub.UserID,
-			&sub.Name,
-			&sub.StartDate,
-			&sub.EndDate,
-			&sub.Duration,
-			&sub.IsCancelled,
-		)
-		if err != nil {
-			return nil, err
-		}
+	return scanSubscriptionRows(rows)
+}
+
+// EndCancelledSubscriptions moves every cancelled subscription which reached its end date
+// into the terminal ended state and returns the number of ended subscriptions
+func (repo *subscriptionRepo) EndCancelledSubscriptions(ctx context.Context) (int64, error) {
+	query := `
+		UPDATE subscriptions
+		SET ended_at = end_date, is_cancelled = true, cancel_at_period_end = false
+		WHERE ended_at IS NULL AND (is_cancelled = true OR cancel_at_period_end = true)
+		AND deleted_at IS NULL AND end_date <= $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := repo.db.ExecContext(ctx, query, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// DeleteSubscription moves a subscription to trash,
+// it returns sql.ErrNoRows when there is no such subscription for userID
+func (repo *subscriptionRepo) DeleteSubscription(ctx context.Context, id, userID uuid.UUID) error {
+	query := `
+		UPDATE subscriptions SET deleted_at = $1
+		WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := repo.db.ExecContext(ctx, query, time.Now(), id, userID)
+	if err != nil {
+		return err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if num == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// RestoreSubscription moves a subscription out of trash
+func (repo *subscriptionRepo) RestoreSubscription(
+	ctx context.Context,
+	id, userID uuid.UUID,
+) (*SubscriptionRow, error) {
+	query := `
+		UPDATE subscriptions SET deleted_at = NULL
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
+		RETURNING ` + subscriptionColumns
 
-		subs = append(subs, &sub)
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(ctx, query, id, userID)
+
+	return scanSubscriptionRow(row)
+}
+
+// PurgeDeletedSubscriptions permanently deletes subscriptions moved to trash before given time
+func (repo *subscriptionRepo) PurgeDeletedSubscriptions(
+	ctx context.Context,
+	before time.Time,
+) (int64, error) {
+	query := `DELETE FROM subscriptions WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := repo.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
 	}
 
-	return subs, nil
+	return res.RowsAffected()
 }
 
 type UpdateSubscriptionStartAndEndDateParams struct {
 	StartDate time.Time
 	EndDate   time.Time
-	ID        uuid.UUID
+	// Amount and Currency are the price of the new period, the current price is kept when they are nil
+	Amount   *int64
+	Currency *string
+	ID       uuid.UUID
 }
 
 func (reop *subscriptionRepo) UpdateSubscriptionStartAndEndDate(
@@ -294,14 +982,61 @@ func (reop *subscriptionRepo) UpdateSubscriptionStartAndEndDate(
 ) error {
 	query := `
 		UPDATE subscriptions 
-		SET start_date = $1, end_date = $2
-		WHERE id = $3
+		SET start_date = $1, end_date = $2, amount = COALESCE($3, amount), currency = COALESCE($4, currency)
+		WHERE id = $5
 	`
 
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
 	defer cancel()
 
-	_, err := reop.db.ExecContext(ctx, query, arg.StartDate, arg.EndDate, arg.ID)
+	// the period is booked in the same transaction
+	_, err := getExcutor(ctx, reop.db).ExecContext(
+		ctx,
+		query,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Amount,
+		arg.Currency,
+		arg.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type ConvertTrialSubscriptionParams struct {
+	StartDate time.Time
+	EndDate   time.Time
+	// Amount is the post trial price, the current amount is kept when it is nil
+	Amount *int64
+	ID     uuid.UUID
+}
+
+// ConvertTrialSubscription moves a subscription whose trial ended to its first paid period
+func (repo *subscriptionRepo) ConvertTrialSubscription(
+	ctx context.Context,
+	arg *ConvertTrialSubscriptionParams,
+) error {
+	query := `
+		UPDATE subscriptions
+		SET start_date = $1, end_date = $2, amount = COALESCE($3, amount)
+		WHERE id = $4
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	// the first paid period is booked in the same transaction
+	_, err := getExcutor(ctx, repo.db).ExecContext(
+		ctx,
+		query,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Amount,
+		arg.ID,
+	)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,200 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllSubscriptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %s", err)
+	}
+	defer db.Close()
+
+	userID := uuid.New()
+	from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	// subscriptions shared with the user are listed with their own
+	owned := `\(user_id = \$1 OR id IN \( SELECT subscription_id FROM subscription_members WHERE user_id = \$1 AND status = 'accepted' \)\)`
+
+	cursorID := uuid.New()
+	first := &repo.SubscriptionRow{ID: uuid.New(), UserID: userID, Name: "Spotify"}
+	second := &repo.SubscriptionRow{ID: uuid.New(), UserID: userID, Name: "Youtube"}
+
+	testCases := []struct {
+		arg        *repo.GetAllSubscriptionsParams
+		buildStubs func(sqlmock.Sqlmock)
+		checkRows  func(*testing.T, []*repo.SubscriptionRow)
+		name       string
+		hasError   bool
+	}{
+		{
+			name: "Default order by start date",
+			arg:  &repo.GetAllSubscriptionsParams{UserID: userID, Limit: 10},
+			buildStubs: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM subscriptions WHERE `+owned+` AND deleted_at IS NULL ORDER BY start_date ASC, id ASC LIMIT \$2 OFFSET \$3`).
+					WithArgs(userID, 10, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM subscriptions WHERE ` + owned + ` AND deleted_at IS NULL$`).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+		},
+		{
+			name: "Order applies to the default sort",
+			arg:  &repo.GetAllSubscriptionsParams{UserID: userID, Limit: 10, Order: "desc"},
+			buildStubs: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM subscriptions WHERE `+owned+` AND deleted_at IS NULL ORDER BY start_date DESC, id DESC LIMIT \$2 OFFSET \$3`).
+					WithArgs(userID, 10, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM subscriptions WHERE ` + owned + ` AND deleted_at IS NULL$`).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+		},
+		{
+			name: "Search, sort and end date range",
+			arg: &repo.GetAllSubscriptionsParams{
+				UserID:      userID,
+				Limit:       10,
+				Query:       "50%_off",
+				Sort:        "price",
+				Order:       "desc",
+				EndDateFrom: &from,
+				EndDateTo:   &to,
+			},
+			buildStubs: func(mock sqlmock.Sqlmock) {
+				where := `WHERE ` + owned + ` AND deleted_at IS NULL AND name ILIKE \$2 ESCAPE '\\' AND end_date >= \$3 AND end_date <= \$4`
+
+				mock.ExpectQuery(where+` ORDER BY COALESCE\(amount, -1\) DESC, id DESC LIMIT \$5 OFFSET \$6`).
+					WithArgs(userID, `%50\%\_off%`, from, to, 10, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM subscriptions `+where+`$`).
+					WithArgs(userID, `%50\%\_off%`, from, to).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+		},
+		{
+			name: "Rows after cursor",
+			arg: &repo.GetAllSubscriptionsParams{
+				UserID: userID,
+				Limit:  2,
+				Offset: 20,
+				Sort:   "name",
+				Cursor: &repo.SubscriptionCursor{Value: "Netflix", ID: cursorID},
+			},
+			buildStubs: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`WHERE `+owned+` AND deleted_at IS NULL AND \(name, id\) > \(\$2, \$3\) ORDER BY name ASC, id ASC LIMIT \$4 OFFSET \$5`).
+					WithArgs(userID, "Netflix", cursorID, 2, 0).
+					WillReturnRows(subscriptionRows(first, second))
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM subscriptions WHERE ` + owned + ` AND deleted_at IS NULL$`).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			checkRows: func(t *testing.T, rows []*repo.SubscriptionRow) {
+				require.Len(t, rows, 2)
+				require.Equal(t, first.ID, rows[0].ID)
+				require.Equal(t, second.ID, rows[1].ID)
+			},
+		},
+		{
+			name: "Rows before cursor are read backwards and reversed",
+			arg: &repo.GetAllSubscriptionsParams{
+				UserID: userID,
+				Limit:  2,
+				Sort:   "created_at",
+				Order:  "desc",
+				Cursor: &repo.SubscriptionCursor{
+					Value:  to.Format(time.RFC3339Nano),
+					ID:     cursorID,
+					Before: true,
+				},
+			},
+			buildStubs: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`WHERE `+owned+` AND deleted_at IS NULL AND \(created_at, id\) > \(\$2, \$3\) ORDER BY created_at ASC, id ASC LIMIT \$4 OFFSET \$5`).
+					WithArgs(userID, to, cursorID, 2, 0).
+					WillReturnRows(subscriptionRows(second, first))
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM subscriptions WHERE ` + owned + ` AND deleted_at IS NULL$`).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			checkRows: func(t *testing.T, rows []*repo.SubscriptionRow) {
+				require.Len(t, rows, 2)
+				require.Equal(t, first.ID, rows[0].ID)
+				require.Equal(t, second.ID, rows[1].ID)
+			},
+		},
+		{
+			name: "Trash only has the subscriptions of the user",
+			arg:  &repo.GetAllSubscriptionsParams{UserID: userID, Limit: 10, Deleted: true},
+			buildStubs: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM subscriptions WHERE user_id = \$1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC LIMIT \$2 OFFSET \$3`).
+					WithArgs(userID, 10, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM subscriptions WHERE user_id = \$1 AND deleted_at IS NOT NULL$`).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+		},
+		{
+			name: "Unknown sort field never reaches the database",
+			arg: &repo.GetAllSubscriptionsParams{
+				UserID: userID,
+				Limit:  10,
+				Sort:   "name; DROP TABLE subscriptions",
+			},
+			buildStubs: func(mock sqlmock.Sqlmock) {},
+			hasError:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStubs(mock)
+
+			repo := repo.NewSubsciptionRepo(db)
+			rows, count, err := repo.GetAllSubscriptions(context.Background(), tc.arg)
+
+			if tc.hasError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Zero(t, count)
+			}
+
+			if tc.checkRows != nil {
+				tc.checkRows(t, rows)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// subscriptionRows returns mock rows with every column selected for a SubscriptionRow
+func subscriptionRows(subs ...*repo.SubscriptionRow) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "name", "start_date", "end_date", "interval_count", "interval_unit",
+		"is_cancelled", "amount", "currency", "cancelled_at", "cancel_at_period_end", "ended_at",
+		"deleted_at", "billing_anchor_day", "trial_end_date", "post_trial_amount", "category_id",
+		"created_at", "notes", "payment_method_id", "tag_ids",
+	})
+
+	for _, sub := range subs {
+		rows.AddRow(
+			sub.ID, sub.UserID, sub.Name, sub.StartDate, sub.EndDate, 1, "month",
+			false, nil, nil, nil, false, nil,
+			nil, 1, nil, nil, nil,
+			sub.CreatedAt, nil, nil, "{}",
+		)
+	}
+
+	return rows
+}
@@ -0,0 +1,215 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+)
+
+type TagRepo interface {
+	GetAllTags(ctx context.Context, userID uuid.UUID) ([]*models.Tag, error)
+	GetTagByID(ctx context.Context, id uuid.UUID) (*models.Tag, error)
+	GetTagByName(ctx context.Context, userID uuid.UUID, name string) (*models.Tag, error)
+	CreateTag(ctx context.Context, arg *CreateTagParams) (*models.Tag, error)
+	UpdateTag(ctx context.Context, arg *UpdateTagParams) (*models.Tag, error)
+	DeleteTag(ctx context.Context, id, userID uuid.UUID) error
+	CountUserTags(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error)
+	SetSubscriptionTags(ctx context.Context, subscriptionID uuid.UUID, tagIDs []uuid.UUID) error
+}
+
+type tagRepo struct {
+	db *sql.DB
+}
+
+func NewTagRepo(db *sql.DB) *tagRepo {
+	return &tagRepo{db}
+}
+
+const tagColumns = `id, user_id, name, created_at`
+
+func scanTag(row rowScanner) (*models.Tag, error) {
+	var tag models.Tag
+	err := row.Scan(
+		&tag.ID,
+		&tag.UserID,
+		&tag.Name,
+		&tag.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (repo *tagRepo) GetAllTags(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE user_id = $1 ORDER BY name ASC`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []*models.Tag{}
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+func (repo *tagRepo) GetTagByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*models.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	return scanTag(repo.db.QueryRowContext(ctx, query, id))
+}
+
+func (repo *tagRepo) GetTagByName(
+	ctx context.Context,
+	userID uuid.UUID,
+	name string,
+) (*models.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE user_id = $1 AND name = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	return scanTag(repo.db.QueryRowContext(ctx, query, userID, name))
+}
+
+type CreateTagParams struct {
+	Name   string
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (repo *tagRepo) CreateTag(
+	ctx context.Context,
+	arg *CreateTagParams,
+) (*models.Tag, error) {
+	query := `
+		INSERT INTO tags (id, user_id, name) VALUES ($1, $2, $3)
+		RETURNING ` + tagColumns
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(ctx, query, arg.ID, arg.UserID, arg.Name)
+
+	return scanTag(row)
+}
+
+type UpdateTagParams struct {
+	Name   string
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+// UpdateTag renames a tag, the row is only updated when it belongs to arg.UserID
+func (repo *tagRepo) UpdateTag(
+	ctx context.Context,
+	arg *UpdateTagParams,
+) (*models.Tag, error) {
+	query := `
+		UPDATE tags SET name = $1 WHERE id = $2 AND user_id = $3
+		RETURNING ` + tagColumns
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(ctx, query, arg.Name, arg.ID, arg.UserID)
+
+	return scanTag(row)
+}
+
+// DeleteTag deletes a tag and removes it from its subscriptions.
+// It returns sql.ErrNoRows when there is no such tag for userID
+func (repo *tagRepo) DeleteTag(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM tags WHERE id = $1 AND user_id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := repo.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if num == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CountUserTags counts how many of given tags belong to userID
+func (repo *tagRepo) CountUserTags(
+	ctx context.Context,
+	userID uuid.UUID,
+	ids []uuid.UUID,
+) (int, error) {
+	query := `SELECT COUNT(*) FROM tags WHERE user_id = $1 AND id = ANY($2::uuid[])`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var count int
+	err := repo.db.QueryRowContext(ctx, query, userID, uuidArray(ids)).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// SetSubscriptionTags replaces the tags of a subscription with tagIDs,
+// tags which are not in tagIDs anymore are removed and new ones are added
+func (repo *tagRepo) SetSubscriptionTags(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	tagIDs []uuid.UUID,
+) error {
+	// the deleted and inserted tags never overlap,
+	// so both can run in one statement without conflicting on the primary key
+	query := `
+		WITH removed AS (
+			DELETE FROM subscription_tags
+			WHERE subscription_id = $1 AND NOT (tag_id = ANY($2::uuid[]))
+		)
+		INSERT INTO subscription_tags (subscription_id, tag_id)
+		SELECT $1, tag_id FROM unnest($2::uuid[]) AS tag_id
+		ON CONFLICT DO NOTHING
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	_, err := repo.db.ExecContext(ctx, query, subscriptionID, uuidArray(tagIDs))
+
+	return err
+}
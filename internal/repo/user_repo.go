@@ -13,6 +13,8 @@ type UserRepo interface {
 	GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 	CreateUser(ctx context.Context, arg *CreateUserParams) (*models.User, error)
+	GetUserByCalendarTokenHash(ctx context.Context, tokenHash string) (*models.User, error)
+	UpdateCalendarTokenHash(ctx context.Context, id uuid.UUID, tokenHash string) error
 }
 
 type userRepo struct {
@@ -24,7 +26,7 @@ func NewUserRepo(db *sql.DB) *userRepo {
 }
 
 func (repo *userRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
-	query := "SELECT id, email, password, created_at FROM users WHERE id = $1"
+	query := "SELECT id, email, password, is_admin, created_at FROM users WHERE id = $1"
 
 	timeOutCtx, cancel := context.WithTimeout(ctx, QueryTimeOut)
 	defer cancel()
@@ -35,7 +37,7 @@ func (repo *userRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*models.Us
 }
 
 func (repo *userRepo) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
-	query := "SELECT id, email, password, created_at FROM users WHERE email = $1"
+	query := "SELECT id, email, password, is_admin, created_at FROM users WHERE email = $1"
 
 	timeOutCtx, cancel := context.WithTimeout(ctx, QueryTimeOut)
 	defer cancel()
@@ -56,15 +58,16 @@ type UserRow struct {
 	Password  *string
 	Email     string
 	ID        uuid.UUID
+	IsAdmin   bool
 }
 
 func (repo *userRepo) CreateUser(ctx context.Context, arg *CreateUserParams) (*models.User, error) {
 	ex := getExcutor(ctx, repo.db)
 
-	query := "INSERT INTO users (id, email, password) VALUES ($1, $2, $3) RETURNING id, email, password, created_at"
+	query := "INSERT INTO users (id, email, password) VALUES ($1, $2, $3) RETURNING id, email, password, is_admin, created_at"
 
 	if arg.Password == "" {
-		query = "INSERT INTO users (id, email) VALUES ($1, $2) RETURNING id, email, password, created_at"
+		query = "INSERT INTO users (id, email) VALUES ($1, $2) RETURNING id, email, password, is_admin, created_at"
 	}
 
 	timeOutCtx, cancel := context.WithTimeout(ctx, QueryTimeOut)
@@ -80,12 +83,57 @@ func (repo *userRepo) CreateUser(ctx context.Context, arg *CreateUserParams) (*m
 	return scanUser(row)
 }
 
+func (repo *userRepo) GetUserByCalendarTokenHash(
+	ctx context.Context,
+	tokenHash string,
+) (*models.User, error) {
+	query := "SELECT id, email, password, is_admin, created_at FROM users WHERE calendar_token_hash = $1"
+
+	timeOutCtx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := repo.db.QueryRowContext(timeOutCtx, query, tokenHash)
+
+	return scanUser(row)
+}
+
+// UpdateCalendarTokenHash replaces the calendar feed token of the user,
+// the feed is no longer reachable with the previous token.
+// It returns sql.ErrNoRows when there is no such user
+func (repo *userRepo) UpdateCalendarTokenHash(
+	ctx context.Context,
+	id uuid.UUID,
+	tokenHash string,
+) error {
+	query := "UPDATE users SET calendar_token_hash = $1 WHERE id = $2"
+
+	timeOutCtx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := repo.db.ExecContext(timeOutCtx, query, tokenHash, id)
+	if err != nil {
+		return err
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if num == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
 func toUser(user *UserRow, password string) *models.User {
 	return &models.User{
 		ID:        user.ID,
 		Email:     user.Email,
 		CreatedAt: user.CreatedAt,
 		Password:  password,
+		IsAdmin:   user.IsAdmin,
 	}
 }
 
@@ -93,7 +141,7 @@ func toUser(user *UserRow, password string) *models.User {
 // and also handles the case where the password is nil
 func scanUser(row *sql.Row) (*models.User, error) {
 	var user UserRow
-	err := row.Scan(&user.ID, &user.Email, &user.Password, &user.CreatedAt)
+	err := row.Scan(&user.ID, &user.Email, &user.Password, &user.IsAdmin, &user.CreatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,7 @@ func TestGetUserByID(t *testing.T) {
 			name:   "Get user by ID successfully",
 			userID: userID,
 			buildStubs: func(mock sqlmock.Sqlmock) {
-				query := `SELECT id, email, password, created_at FROM users WHERE id = \$1`
+				query := `SELECT id, email, password, is_admin, created_at FROM users WHERE id = \$1`
 				// ExpectQuery need regex string to match the query needed to be tested
 				mock.ExpectQuery(query).WithArgs(userID).WillReturnRows(rows)
 			},
@@ -50,6 +50,7 @@ func TestGetUserByID(t *testing.T) {
 				require.Equal(t, user.ID, response.ID)
 				require.Equal(t, user.Email, response.Email)
 				require.Equal(t, user.Password, response.Password)
+				require.Equal(t, user.IsAdmin, response.IsAdmin)
 				require.Equal(t, user.CreatedAt, response.CreatedAt)
 			},
 		},
@@ -57,7 +58,7 @@ func TestGetUserByID(t *testing.T) {
 			name:   "Get user by ID not found",
 			userID: userID,
 			buildStubs: func(mock sqlmock.Sqlmock) {
-				query := `SELECT id, email, password, created_at FROM users WHERE id = \$1`
+				query := `SELECT id, email, password, is_admin, created_at FROM users WHERE id = \$1`
 				mock.ExpectQuery(query).WithArgs(userID).WillReturnError(sql.ErrNoRows)
 			},
 			checkResponse: func(t *testing.T, response *models.User, err error) {
@@ -88,6 +89,6 @@ func TestGetUserByID(t *testing.T) {
 func randomRow(user *models.User) *sqlmock.Rows {
 	// AddRows will return *sql.Rows for array of rows
 	// AddRow will return *sql.Row for single row
-	return sqlmock.NewRows([]string{"id", "email", "password", "created_at"}).
-		AddRow(user.ID, user.Email, user.Password, user.CreatedAt)
+	return sqlmock.NewRows([]string{"id", "email", "password", "is_admin", "created_at"}).
+		AddRow(user.ID, user.Email, user.Password, user.IsAdmin, user.CreatedAt)
 }
@@ -44,11 +44,27 @@ func (r *router) Setup() http.Handler {
 		{
 			r.setupOAuthRoutes(v1)
 			r.setupAuthRoutes(v1)
+			// calendar clients can not send the Bearer header, the feed is protected by its token
+			r.setupCalendarFeedRoutes(v1)
+			// download links are emailed, the export is protected by the token in the link
+			r.setupExportDownloadRoutes(v1)
 
 			// protected routes
 			v1.Use(middlewares.AuthMiddleware(r.auth))
 			r.setupUserRoutes(v1)
 			r.setupSubscriptionRoutes(v1)
+			r.setupCategoryRoutes(v1)
+			r.setupTagRoutes(v1)
+			r.setupAnalyticsRoutes(v1)
+			r.setupCalendarRoutes(v1)
+			r.setupExportRoutes(v1)
+			r.setupChargeRoutes(v1)
+			r.setupBudgetRoutes(v1)
+			r.setupInvitationRoutes(v1)
+			r.setupBalanceRoutes(v1)
+			r.setupCatalogRoutes(v1)
+			r.setupAttachmentRoutes(v1)
+			r.setupPaymentMethodRoutes(v1)
 		}
 	}
 
@@ -66,9 +82,133 @@ func (r *router) setupSubscriptionRoutes(group *gin.RouterGroup) {
 
 	sub.POST("", r.handler.Subscription.CreateSubscriptionHandler)
 	sub.GET("", r.handler.Subscription.GetAllSubscriptionsHandler)
+	sub.GET("/trash", r.handler.Subscription.GetDeletedSubscriptionsHandler)
+	sub.GET("/upcoming", r.handler.Subscription.GetUpcomingRenewalsHandler)
+	sub.POST("/import", r.handler.Import.ImportSubscriptionsHandler)
+	sub.POST("/statements/detect", r.handler.Statement.DetectSubscriptionsHandler)
+	sub.POST("/statements/confirm", r.handler.Statement.ConfirmSubscriptionsHandler)
+	sub.GET("/:id", r.handler.Subscription.GetSubscriptionHandler)
+	sub.GET("/:id/prices", r.handler.Subscription.GetSubscriptionPricesHandler)
+	sub.PATCH("/:id", r.handler.Subscription.UpdateSubscriptionHandler)
+	sub.POST("/:id/cancel", r.handler.Subscription.CancelSubscriptionHandler)
+	sub.POST("/:id/reactivate", r.handler.Subscription.ReactivateSubscriptionHandler)
+	sub.PUT("/:id/category", r.handler.Subscription.SetSubscriptionCategoryHandler)
+	sub.PUT("/:id/tags", r.handler.Subscription.SetSubscriptionTagsHandler)
+	sub.DELETE("/:id", r.handler.Subscription.DeleteSubscriptionHandler)
+	sub.POST("/:id/restore", r.handler.Subscription.RestoreSubscriptionHandler)
+	sub.POST("/:id/members", r.handler.Member.InviteMemberHandler)
+	sub.GET("/:id/members", r.handler.Member.GetMembersHandler)
+	sub.DELETE("/:id/members/:member_id", r.handler.Member.RemoveMemberHandler)
+	sub.POST("/:id/attachments", r.handler.Attachment.UploadAttachmentHandler)
+	sub.GET("/:id/attachments", r.handler.Attachment.GetAttachmentsHandler)
+	sub.PUT("/:id/payment-method", r.handler.PaymentMethod.SetSubscriptionPaymentMethodHandler)
 	// sub.GET("", r.handler.Subscription.GetSubscriptionsBeforeNumDays)
 }
 
+func (r *router) setupCategoryRoutes(group *gin.RouterGroup) {
+	categories := group.Group("/categories")
+
+	categories.GET("", r.handler.Category.GetAllCategoriesHandler)
+	categories.POST("", r.handler.Category.CreateCategoryHandler)
+	categories.PATCH("/:id", r.handler.Category.UpdateCategoryHandler)
+	categories.DELETE("/:id", r.handler.Category.DeleteCategoryHandler)
+}
+
+func (r *router) setupTagRoutes(group *gin.RouterGroup) {
+	tags := group.Group("/tags")
+
+	tags.GET("", r.handler.Tag.GetAllTagsHandler)
+	tags.POST("", r.handler.Tag.CreateTagHandler)
+	tags.PATCH("/:id", r.handler.Tag.UpdateTagHandler)
+	tags.DELETE("/:id", r.handler.Tag.DeleteTagHandler)
+}
+
+func (r *router) setupAnalyticsRoutes(group *gin.RouterGroup) {
+	analytics := group.Group("/analytics")
+
+	analytics.GET("/spend", r.handler.Analytics.GetSpendHandler)
+}
+
+func (r *router) setupCalendarFeedRoutes(group *gin.RouterGroup) {
+	calendar := group.Group("/calendar")
+
+	calendar.GET("/:file", r.handler.Calendar.GetCalendarFeedHandler)
+}
+
+func (r *router) setupCalendarRoutes(group *gin.RouterGroup) {
+	calendar := group.Group("/calendar")
+
+	calendar.POST("/token", r.handler.Calendar.RotateCalendarTokenHandler)
+}
+
+func (r *router) setupExportDownloadRoutes(group *gin.RouterGroup) {
+	export := group.Group("/export")
+
+	export.GET("/:file", r.handler.Export.DownloadExportHandler)
+}
+
+func (r *router) setupExportRoutes(group *gin.RouterGroup) {
+	export := group.Group("/export")
+
+	export.GET("", r.handler.Export.ExportUserDataHandler)
+	export.POST("", r.handler.Export.QueueExportHandler)
+}
+
+func (r *router) setupChargeRoutes(group *gin.RouterGroup) {
+	charges := group.Group("/charges")
+
+	charges.GET("", r.handler.Charge.GetChargesHandler)
+	charges.POST("/backfill", r.handler.Charge.BackfillChargesHandler)
+}
+
+func (r *router) setupBudgetRoutes(group *gin.RouterGroup) {
+	budgets := group.Group("/budgets")
+
+	budgets.GET("", r.handler.Budget.GetBudgetsHandler)
+	budgets.PUT("", r.handler.Budget.SetBudgetHandler)
+	budgets.DELETE("/:id", r.handler.Budget.DeleteBudgetHandler)
+}
+
+func (r *router) setupInvitationRoutes(group *gin.RouterGroup) {
+	invitations := group.Group("/invitations")
+
+	invitations.GET("", r.handler.Member.GetInvitationsHandler)
+	invitations.POST("/:id/accept", r.handler.Member.AcceptInvitationHandler)
+	invitations.POST("/:id/decline", r.handler.Member.DeclineInvitationHandler)
+}
+
+func (r *router) setupBalanceRoutes(group *gin.RouterGroup) {
+	balances := group.Group("/balances")
+
+	balances.GET("", r.handler.Member.GetBalancesHandler)
+}
+
+func (r *router) setupCatalogRoutes(group *gin.RouterGroup) {
+	catalog := group.Group("/catalog")
+
+	catalog.GET("", r.handler.Catalog.SearchCatalogHandler)
+	catalog.GET("/:id", r.handler.Catalog.GetCatalogEntryHandler)
+	catalog.POST("", r.handler.Catalog.CreateCatalogEntryHandler)
+	catalog.PUT("/:id", r.handler.Catalog.UpdateCatalogEntryHandler)
+	catalog.DELETE("/:id", r.handler.Catalog.DeleteCatalogEntryHandler)
+}
+
+func (r *router) setupAttachmentRoutes(group *gin.RouterGroup) {
+	attachments := group.Group("/attachments")
+
+	attachments.GET("/:id", r.handler.Attachment.DownloadAttachmentHandler)
+	attachments.DELETE("/:id", r.handler.Attachment.DeleteAttachmentHandler)
+}
+
+func (r *router) setupPaymentMethodRoutes(group *gin.RouterGroup) {
+	methods := group.Group("/payment-methods")
+
+	methods.GET("", r.handler.PaymentMethod.GetPaymentMethodsHandler)
+	methods.POST("", r.handler.PaymentMethod.CreatePaymentMethodHandler)
+	methods.PUT("/:id", r.handler.PaymentMethod.UpdatePaymentMethodHandler)
+	methods.DELETE("/:id", r.handler.PaymentMethod.DeletePaymentMethodHandler)
+}
+
 func (r *router) setupAuthRoutes(group *gin.RouterGroup) {
 	auth := group.Group("/auth")
 
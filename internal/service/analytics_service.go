@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+type AnalyticsService interface {
+	GetSpend(ctx context.Context, req *GetSpendRequest) (*GetSpendResponse, error)
+}
+
+type analyticsService struct {
+	repo repo.AnalyticsRepo
+}
+
+func NewAnalyticsService(repo repo.AnalyticsRepo) *analyticsService {
+	return &analyticsService{repo}
+}
+
+type GetSpendRequest struct {
+	Top    int
+	UserID uuid.UUID
+}
+
+// amounts are in minor units of the currency they are grouped by,
+// different currencies are never added together
+type GetSpendResponse struct {
+	Currencies    []*CurrencySpend `json:"currencies"`
+	UnpricedCount int              `json:"unpriced_count" example:"2"`
+}
+
+type CurrencySpend struct {
+	Currency     string           `json:"currency"      example:"USD"`
+	ByDuration   []*DurationSpend `json:"by_duration"`
+	Top          []*TopSpend      `json:"top"`
+	MonthlyTotal int64            `json:"monthly_total" example:"4297"`
+	YearlyTotal  int64            `json:"yearly_total"  example:"51564"`
+}
+
+type DurationSpend struct {
+	Duration     string `json:"duration"      example:"monthly"`
+	Count        int    `json:"count"         example:"3"`
+	Total        int64  `json:"total"         example:"2997"`
+	MonthlyTotal int64  `json:"monthly_total" example:"2997"`
+	YearlyTotal  int64  `json:"yearly_total"  example:"35964"`
+}
+
+type TopSpend struct {
+	Name          string    `json:"name"           example:"Netflix"`
+	Duration      string    `json:"duration"       example:"monthly"`
+	Amount        int64     `json:"amount"         example:"1599"`
+	MonthlyAmount int64     `json:"monthly_amount" example:"1599"`
+	YearlyAmount  int64     `json:"yearly_amount"  example:"19188"`
+	ID            uuid.UUID `json:"id"`
+}
+
+// GetSpend returns the recurring spend of subscriptions which will keep being billed,
+// normalized to a month and a year and grouped by currency
+func (s *analyticsService) GetSpend(
+	ctx context.Context,
+	req *GetSpendRequest,
+) (*GetSpendResponse, error) {
+	durations, err := s.repo.GetSpendByDuration(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	top, err := s.repo.GetTopSpendSubscriptions(ctx, req.UserID, req.Top)
+	if err != nil {
+		return nil, err
+	}
+
+	unpriced, err := s.repo.CountUnpricedSubscriptions(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &GetSpendResponse{
+		Currencies:    []*CurrencySpend{},
+		UnpricedCount: unpriced,
+	}
+
+	// rows of both queries are ordered by currency
+	currencies := make(map[string]*CurrencySpend)
+	getCurrency := func(currency string) *CurrencySpend {
+		spend, ok := currencies[currency]
+		if !ok {
+			spend = &CurrencySpend{
+				Currency:   currency,
+				ByDuration: []*DurationSpend{},
+				Top:        []*TopSpend{},
+			}
+			currencies[currency] = spend
+			res.Currencies = append(res.Currencies, spend)
+		}
+
+		return spend
+	}
+
+	for _, row := range durations {
+		spend := getCurrency(row.Currency)
+		spend.MonthlyTotal += row.MonthlyTotal
+		spend.YearlyTotal += row.YearlyTotal
+		spend.ByDuration = append(spend.ByDuration, &DurationSpend{
+			Duration:     row.Duration.String(),
+			Count:        row.Count,
+			Total:        row.Total,
+			MonthlyTotal: row.MonthlyTotal,
+			YearlyTotal:  row.YearlyTotal,
+		})
+	}
+
+	for _, row := range top {
+		spend := getCurrency(row.Currency)
+		spend.Top = append(spend.Top, &TopSpend{
+			ID:            row.ID,
+			Name:          row.Name,
+			Duration:      row.Duration.String(),
+			Amount:        row.Amount,
+			MonthlyAmount: row.MonthlyAmount,
+			YearlyAmount:  row.YearlyAmount,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,146 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetSpend(t *testing.T) {
+	userID := uuid.New()
+
+	durations := []*repo.SpendByDurationRow{
+		{
+			Currency:     "EUR",
+			Duration:     enums.SixMonths,
+			Count:        1,
+			Total:        5994,
+			MonthlyTotal: 999,
+			YearlyTotal:  11988,
+		},
+		{
+			Currency:     "USD",
+			Duration:     enums.Weekly,
+			Count:        1,
+			Total:        999,
+			MonthlyTotal: 4329,
+			YearlyTotal:  51948,
+		},
+		{
+			Currency:     "USD",
+			Duration:     enums.Monthly,
+			Count:        2,
+			Total:        2598,
+			MonthlyTotal: 2598,
+			YearlyTotal:  31176,
+		},
+	}
+	top := []*repo.TopSpendSubscriptionRow{
+		{
+			ID:            uuid.New(),
+			Currency:      "EUR",
+			Name:          "Gym",
+			Duration:      enums.SixMonths,
+			Amount:        5994,
+			MonthlyAmount: 999,
+			YearlyAmount:  11988,
+		},
+		{
+			ID:            uuid.New(),
+			Currency:      "USD",
+			Name:          "Meal kit",
+			Duration:      enums.Weekly,
+			Amount:        999,
+			MonthlyAmount: 4329,
+			YearlyAmount:  51948,
+		},
+	}
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockAnalyticsRepo)
+		checkResponse func(*testing.T, *service.GetSpendResponse, error)
+		name          string
+	}{
+		{
+			name: "Spend grouped by currency",
+			buildStubs: func(repo *mocks.MockAnalyticsRepo) {
+				repo.EXPECT().GetSpendByDuration(gomock.Any(), userID).Times(1).Return(durations, nil)
+				repo.EXPECT().GetTopSpendSubscriptions(gomock.Any(), userID, 5).Times(1).Return(top, nil)
+				repo.EXPECT().CountUnpricedSubscriptions(gomock.Any(), userID).Times(1).Return(3, nil)
+			},
+			checkResponse: func(t *testing.T, res *service.GetSpendResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 3, res.UnpricedCount)
+				require.Len(t, res.Currencies, 2)
+
+				eur := res.Currencies[0]
+				require.Equal(t, "EUR", eur.Currency)
+				require.Equal(t, int64(999), eur.MonthlyTotal)
+				require.Equal(t, int64(11988), eur.YearlyTotal)
+				require.Len(t, eur.ByDuration, 1)
+				require.Equal(t, enums.SixMonths.String(), eur.ByDuration[0].Duration)
+				require.Len(t, eur.Top, 1)
+				require.Equal(t, top[0].ID, eur.Top[0].ID)
+
+				usd := res.Currencies[1]
+				require.Equal(t, "USD", usd.Currency)
+				require.Equal(t, int64(4329+2598), usd.MonthlyTotal)
+				require.Equal(t, int64(51948+31176), usd.YearlyTotal)
+				require.Len(t, usd.ByDuration, 2)
+				require.Equal(t, 2, usd.ByDuration[1].Count)
+				require.Len(t, usd.Top, 1)
+				require.Equal(t, "Meal kit", usd.Top[0].Name)
+				require.Equal(t, int64(4329), usd.Top[0].MonthlyAmount)
+			},
+		},
+		{
+			name: "No priced subscriptions",
+			buildStubs: func(repo *mocks.MockAnalyticsRepo) {
+				repo.EXPECT().GetSpendByDuration(gomock.Any(), userID).Times(1).Return(nil, nil)
+				repo.EXPECT().GetTopSpendSubscriptions(gomock.Any(), userID, 5).Times(1).Return(nil, nil)
+				repo.EXPECT().CountUnpricedSubscriptions(gomock.Any(), userID).Times(1).Return(0, nil)
+			},
+			checkResponse: func(t *testing.T, res *service.GetSpendResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res.Currencies)
+				require.Empty(t, res.Currencies)
+			},
+		},
+		{
+			name: "Internal error",
+			buildStubs: func(repo *mocks.MockAnalyticsRepo) {
+				repo.EXPECT().GetSpendByDuration(gomock.Any(), userID).Times(1).Return(nil, sql.ErrConnDone)
+				repo.EXPECT().GetTopSpendSubscriptions(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *service.GetSpendResponse, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+				require.Nil(t, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAnalyticsRepo(ctrl)
+			tc.buildStubs(repo)
+
+			s := service.NewAnalyticsService(repo)
+			res, err := s.GetSpend(context.Background(), &service.GetSpendRequest{
+				UserID: userID,
+				Top:    5,
+			})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/config"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/storage"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+// sniffLen is the number of bytes http.DetectContentType looks at
+const sniffLen = 512
+
+const maxAttachmentFileNameLen = 255
+
+// attachmentContentTypes are the content types which can be attached, as sniffed from the content
+var attachmentContentTypes = []string{
+	"application/pdf",
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"text/plain; charset=utf-8",
+}
+
+type AttachmentService interface {
+	UploadAttachment(
+		ctx context.Context,
+		req *UploadAttachmentRequest,
+	) (*models.Attachment, error)
+	GetAttachments(
+		ctx context.Context,
+		subscriptionID uuid.UUID,
+		userID uuid.UUID,
+	) ([]*models.Attachment, error)
+	OpenAttachment(ctx context.Context, id, userID uuid.UUID) (*AttachmentFile, error)
+	DeleteAttachment(ctx context.Context, id, userID uuid.UUID) error
+}
+
+type attachmentService struct {
+	attachmentRepo   repo.AttachmentRepo
+	subscriptionRepo repo.SubscriptionRepo
+	storage          storage.Storage
+	transaction      repo.TransactionManager
+	config           *config.AttachmentConfig
+}
+
+func NewAttachmentService(
+	attachmentRepo repo.AttachmentRepo,
+	subscriptionRepo repo.SubscriptionRepo,
+	storage storage.Storage,
+	transaction repo.TransactionManager,
+	config *config.AttachmentConfig,
+) *attachmentService {
+	return &attachmentService{attachmentRepo, subscriptionRepo, storage, transaction, config}
+}
+
+type UploadAttachmentRequest struct {
+	File           io.Reader
+	FileName       string
+	SubscriptionID uuid.UUID
+	UserID         uuid.UUID
+	// Size is the size of File in bytes
+	Size int64
+}
+
+// UploadAttachment attaches a file to a subscription of the user.
+// The content type is sniffed from the content, the one sent by the client is ignored
+func (s *attachmentService) UploadAttachment(
+	ctx context.Context,
+	req *UploadAttachmentRequest,
+) (*models.Attachment, error) {
+	_, err := s.getUserSubscription(ctx, req.SubscriptionID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Size <= 0 {
+		return nil, apperror.ErrAttachmentFileRequired
+	}
+
+	if req.Size > s.config.MaxFileSize {
+		return nil, apperror.ErrAttachmentTooLarge
+	}
+
+	// an early answer before the content is stored, CreateAttachment enforces the quota
+	used, err := s.attachmentRepo.GetUserAttachmentsSize(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if used+req.Size > s.config.UserQuota {
+		return nil, apperror.ErrAttachmentQuotaExceeded
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(req.File, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	if !slices.Contains(attachmentContentTypes, contentType) {
+		return nil, apperror.ErrUnsupportedAttachmentType
+	}
+
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	key := req.UserID.String() + "/" + id.String()
+	err = s.storage.Put(ctx, key, io.MultiReader(bytes.NewReader(head), req.File))
+	if err != nil {
+		return nil, err
+	}
+
+	var attachment *models.Attachment
+	err = s.transaction.WithTx(ctx, func(txCtx context.Context) error {
+		attachment, err = s.attachmentRepo.CreateAttachment(txCtx, &repo.CreateAttachmentParams{
+			ID:             id,
+			UserID:         req.UserID,
+			SubscriptionID: req.SubscriptionID,
+			FileName:       attachmentFileName(req.FileName),
+			ContentType:    contentType,
+			Size:           req.Size,
+			StorageKey:     key,
+			Quota:          s.config.UserQuota,
+		})
+		return err
+	})
+	if err != nil {
+		_ = s.storage.Delete(ctx, key)
+		// another upload of the user took the rest of the quota in the meantime
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrAttachmentQuotaExceeded
+		}
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// GetAttachments returns the attachments of a subscription of the user, newest first
+func (s *attachmentService) GetAttachments(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	userID uuid.UUID,
+) ([]*models.Attachment, error) {
+	_, err := s.getUserSubscription(ctx, subscriptionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.attachmentRepo.GetAttachments(ctx, subscriptionID)
+}
+
+type AttachmentFile struct {
+	io.ReadCloser
+	Attachment *models.Attachment
+}
+
+// OpenAttachment opens the content of an attachment of the user, the caller closes it
+func (s *attachmentService) OpenAttachment(
+	ctx context.Context,
+	id, userID uuid.UUID,
+) (*AttachmentFile, error) {
+	attachment, err := s.getAttachment(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := s.storage.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, apperror.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+
+	return &AttachmentFile{ReadCloser: file, Attachment: attachment}, nil
+}
+
+func (s *attachmentService) DeleteAttachment(ctx context.Context, id, userID uuid.UUID) error {
+	attachment, err := s.getAttachment(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.attachmentRepo.DeleteAttachment(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrAttachmentNotFound
+		}
+		return err
+	}
+
+	// the attachment is already gone for the user, a content left behind only wastes space
+	err = s.storage.Delete(ctx, attachment.StorageKey)
+	if err != nil {
+		log.Println("delete attachment content:", err)
+	}
+
+	return nil
+}
+
+func (s *attachmentService) getAttachment(
+	ctx context.Context,
+	id, userID uuid.UUID,
+) (*models.Attachment, error) {
+	attachment, err := s.attachmentRepo.GetAttachment(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// attachments can only be added to and listed for the subscriptions a user owns
+func (s *attachmentService) getUserSubscription(
+	ctx context.Context,
+	id uuid.UUID,
+	userID uuid.UUID,
+) (*repo.SubscriptionRow, error) {
+	row, err := s.subscriptionRepo.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	if row.UserID != userID || row.DeletedAt != nil {
+		return nil, apperror.ErrSubscriptionNotFound
+	}
+
+	return row, nil
+}
+
+// attachmentFileName keeps the base name of an uploaded file without the characters
+// which could break a Content-Disposition header
+func attachmentFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+
+	if runes := []rune(name); len(runes) > maxAttachmentFileNameLen {
+		name = string(runes[:maxAttachmentFileNameLen])
+	}
+
+	return name
+}
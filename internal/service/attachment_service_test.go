@@ -0,0 +1,233 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/config"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/storage"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUploadAttachment(t *testing.T) {
+	userID := uuid.New()
+	row := randomSubscriptionRow(userID)
+
+	pdf := []byte("%PDF-1.7\n1 0 obj\n<< /Type /Catalog >>\nendobj\n")
+	executable := []byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00\x00\x00\xff\xff")
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockAttachmentRepo, *mocks.MockSubscriptionRepo)
+		checkResponse func(*testing.T, *models.Attachment, error)
+		name          string
+		fileName      string
+		content       []byte
+		userID        uuid.UUID
+	}{
+		{
+			name:     "Pdf",
+			userID:   userID,
+			fileName: `C:\invoices\"jan".pdf`,
+			content:  pdf,
+			buildStubs: func(a *mocks.MockAttachmentRepo, r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				a.EXPECT().GetUserAttachmentsSize(gomock.Any(), userID).Times(1).Return(int64(0), nil)
+				a.EXPECT().
+					CreateAttachment(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(
+						_ context.Context,
+						arg *repo.CreateAttachmentParams,
+					) (*models.Attachment, error) {
+						require.Equal(t, int64(8192), arg.Quota)
+						return &models.Attachment{
+							ID:             arg.ID,
+							UserID:         arg.UserID,
+							SubscriptionID: &arg.SubscriptionID,
+							FileName:       arg.FileName,
+							ContentType:    arg.ContentType,
+							Size:           arg.Size,
+							StorageKey:     arg.StorageKey,
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *models.Attachment, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "jan.pdf", res.FileName)
+				require.Equal(t, "application/pdf", res.ContentType)
+				require.Equal(t, int64(len(pdf)), res.Size)
+				require.Equal(t, userID.String()+"/"+res.ID.String(), res.StorageKey)
+			},
+		},
+		{
+			name:     "Unsupported type",
+			userID:   userID,
+			fileName: "invoice.pdf",
+			content:  executable,
+			buildStubs: func(a *mocks.MockAttachmentRepo, r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				a.EXPECT().GetUserAttachmentsSize(gomock.Any(), userID).Times(1).Return(int64(0), nil)
+				a.EXPECT().CreateAttachment(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *models.Attachment, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrUnsupportedAttachmentType)
+			},
+		},
+		{
+			name:     "Too large",
+			userID:   userID,
+			fileName: "invoice.pdf",
+			content:  bytes.Repeat(pdf, 100),
+			buildStubs: func(a *mocks.MockAttachmentRepo, r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				a.EXPECT().GetUserAttachmentsSize(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *models.Attachment, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrAttachmentTooLarge)
+			},
+		},
+		{
+			name:     "Quota exceeded",
+			userID:   userID,
+			fileName: "invoice.pdf",
+			content:  pdf,
+			buildStubs: func(a *mocks.MockAttachmentRepo, r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				a.EXPECT().GetUserAttachmentsSize(gomock.Any(), userID).Times(1).Return(int64(8190), nil)
+				a.EXPECT().CreateAttachment(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *models.Attachment, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrAttachmentQuotaExceeded)
+			},
+		},
+		{
+			name:     "Quota taken by a concurrent upload",
+			userID:   userID,
+			fileName: "invoice.pdf",
+			content:  pdf,
+			buildStubs: func(a *mocks.MockAttachmentRepo, r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				a.EXPECT().GetUserAttachmentsSize(gomock.Any(), userID).Times(1).Return(int64(0), nil)
+				a.EXPECT().
+					CreateAttachment(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, res *models.Attachment, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrAttachmentQuotaExceeded)
+			},
+		},
+		{
+			name:     "Not owner",
+			userID:   uuid.New(),
+			fileName: "invoice.pdf",
+			content:  pdf,
+			buildStubs: func(a *mocks.MockAttachmentRepo, r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				a.EXPECT().GetUserAttachmentsSize(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *models.Attachment, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrSubscriptionNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAttachmentRepo := mocks.NewMockAttachmentRepo(ctrl)
+			mockSubscriptionRepo := mocks.NewMockSubscriptionRepo(ctrl)
+			tc.buildStubs(mockAttachmentRepo, mockSubscriptionRepo)
+
+			blobs := storage.NewLocalStorage(t.TempDir())
+			attachmentService := service.NewAttachmentService(
+				mockAttachmentRepo,
+				mockSubscriptionRepo,
+				blobs,
+				&fakeTransaction{},
+				&config.AttachmentConfig{MaxFileSize: 1000, UserQuota: 8192},
+			)
+
+			res, err := attachmentService.UploadAttachment(
+				context.Background(),
+				&service.UploadAttachmentRequest{
+					File:           bytes.NewReader(tc.content),
+					FileName:       tc.fileName,
+					Size:           int64(len(tc.content)),
+					SubscriptionID: row.ID,
+					UserID:         tc.userID,
+				},
+			)
+			tc.checkResponse(t, res, err)
+
+			// the whole content is stored, including the sniffed head
+			if err == nil {
+				file, err := blobs.Open(context.Background(), res.StorageKey)
+				require.NoError(t, err)
+				defer file.Close()
+
+				content, err := io.ReadAll(file)
+				require.NoError(t, err)
+				require.Equal(t, tc.content, content)
+			}
+		})
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+	attachment := &models.Attachment{ID: uuid.New(), UserID: userID, StorageKey: userID.String() + "/receipt"}
+
+	blobs := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, blobs.Put(ctx, attachment.StorageKey, bytes.NewReader([]byte("receipt"))))
+
+	mockAttachmentRepo := mocks.NewMockAttachmentRepo(ctrl)
+	mockAttachmentRepo.EXPECT().
+		GetAttachment(gomock.Any(), attachment.ID, userID).
+		Times(1).
+		Return(attachment, nil)
+	mockAttachmentRepo.EXPECT().DeleteAttachment(gomock.Any(), attachment.ID, userID).Times(1).Return(nil)
+	// an attachment of another user is not found
+	mockAttachmentRepo.EXPECT().
+		GetAttachment(gomock.Any(), attachment.ID, gomock.Not(userID)).
+		Times(1).
+		Return(nil, sql.ErrNoRows)
+
+	attachmentService := service.NewAttachmentService(
+		mockAttachmentRepo,
+		mocks.NewMockSubscriptionRepo(ctrl),
+		blobs,
+		&fakeTransaction{},
+		&config.AttachmentConfig{},
+	)
+
+	err := attachmentService.DeleteAttachment(ctx, attachment.ID, uuid.New())
+	require.ErrorIs(t, err, apperror.ErrAttachmentNotFound)
+
+	err = attachmentService.DeleteAttachment(ctx, attachment.ID, userID)
+	require.NoError(t, err)
+
+	_, err = blobs.Open(ctx, attachment.StorageKey)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+const budgetMonthLayout = "2006-01"
+
+type BudgetService interface {
+	GetBudgets(ctx context.Context, userID uuid.UUID) ([]*BudgetStatus, error)
+	SetBudget(ctx context.Context, req *SetBudgetRequest) (*models.Budget, error)
+	DeleteBudget(ctx context.Context, id, userID uuid.UUID) error
+}
+
+type budgetService struct {
+	budgetRepo       repo.BudgetRepo
+	subscriptionRepo repo.SubscriptionRepo
+	priceRepo        repo.SubscriptionPriceRepo
+}
+
+func NewBudgetService(
+	budgetRepo repo.BudgetRepo,
+	subscriptionRepo repo.SubscriptionRepo,
+	priceRepo repo.SubscriptionPriceRepo,
+) *budgetService {
+	return &budgetService{budgetRepo, subscriptionRepo, priceRepo}
+}
+
+// BudgetStatus is the spend of the current month against a budget, amounts are in minor units.
+// Committed is every renewal of the month including the upcoming ones,
+// Spent only counts the renewals up to today
+type BudgetStatus struct {
+	// MonthStart is the first day of the month, it is used to record the alerts of the month
+	MonthStart time.Time      `json:"-"`
+	Budget     *models.Budget `json:"budget"`
+	Month      string         `json:"month"          example:"2025-05"`
+	Spent      int64          `json:"spent"          example:"2598"`
+	Committed  int64          `json:"committed"      example:"4197"`
+	// Remaining is negative when the budget is overspent
+	Remaining int64 `json:"remaining"      example:"803"`
+	// UsedPercent is Committed in percent of the budget amount, rounded down
+	UsedPercent int `json:"used_percent"   example:"83"`
+	// UnpricedCount is the number of subscriptions renewing this month without a price,
+	// they are not in the spend
+	UnpricedCount int `json:"unpriced_count" example:"1"`
+}
+
+// GetBudgets returns the status of every budget of the user for the current month
+func (s *budgetService) GetBudgets(ctx context.Context, userID uuid.UUID) ([]*BudgetStatus, error) {
+	budgets, err := s.budgetRepo.GetBudgets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*BudgetStatus, 0, len(budgets))
+	if len(budgets) == 0 {
+		return statuses, nil
+	}
+
+	rows, err := s.subscriptionRepo.GetUserSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := getScheduledPrices(ctx, s.priceRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	for _, budget := range budgets {
+		statuses = append(statuses, newBudgetStatus(budget, rows, prices, now))
+	}
+
+	return statuses, nil
+}
+
+type SetBudgetRequest struct {
+	// Duration limits the budget to subscriptions billed at this interval,
+	// the budget covers every subscription when it is omitted
+	Duration *enums.Duration `json:"duration" swaggertype:"string"                example:"monthly"`
+	Currency string          `json:"currency" validate:"required,iso4217"        example:"USD"`
+	UserID   uuid.UUID       `json:"-"        validate:"-"`
+	// Amount is the monthly budget in minor units (e.g. cents)
+	Amount int64 `json:"amount"   validate:"gte=1"                   example:"5000"`
+}
+
+// SetBudget creates the budget of the currency and duration, or replaces its amount when it exists
+func (s *budgetService) SetBudget(
+	ctx context.Context,
+	req *SetBudgetRequest,
+) (*models.Budget, error) {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.budgetRepo.SetBudget(ctx, &repo.SetBudgetParams{
+		ID:       id,
+		UserID:   req.UserID,
+		Duration: req.Duration,
+		Currency: req.Currency,
+		Amount:   req.Amount,
+	})
+}
+
+func (s *budgetService) DeleteBudget(ctx context.Context, id, userID uuid.UUID) error {
+	err := s.budgetRepo.DeleteBudget(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrBudgetNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// newBudgetStatus sums the renewals in the month of now of the subscriptions covered by the budget
+func newBudgetStatus(
+	budget *models.Budget,
+	rows []*repo.SubscriptionRow,
+	prices scheduledPrices,
+	now time.Time,
+) *BudgetStatus {
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	today := truncateToDay(now)
+
+	status := &BudgetStatus{
+		Budget:     budget,
+		Month:      from.Format(budgetMonthLayout),
+		MonthStart: from,
+	}
+
+	for _, row := range rows {
+		if !coveredByBudget(budget, row) {
+			continue
+		}
+
+		renewals := monthRenewals(row, prices, from, to)
+		if len(renewals) == 0 {
+			continue
+		}
+
+		// the currency of a subscription without a price is unknown,
+		// it is reported by every budget covering it
+		if row.Currency == nil {
+			status.UnpricedCount++
+			continue
+		}
+
+		if *row.Currency != budget.Currency {
+			continue
+		}
+
+		unpriced := false
+		for _, renewal := range renewals {
+			if renewal.Amount == nil {
+				unpriced = true
+				continue
+			}
+
+			// a scheduled price may move the subscription to another currency
+			if renewal.Currency == nil || *renewal.Currency != budget.Currency {
+				continue
+			}
+
+			status.Committed += *renewal.Amount
+			if !renewal.Date.After(today) {
+				status.Spent += *renewal.Amount
+			}
+		}
+
+		if unpriced {
+			status.UnpricedCount++
+		}
+	}
+
+	status.Remaining = budget.Amount - status.Committed
+	status.UsedPercent = int(status.Committed * 100 / budget.Amount)
+
+	return status
+}
+
+// only subscriptions which are still billed are covered
+func coveredByBudget(budget *models.Budget, row *repo.SubscriptionRow) bool {
+	if row.DeletedAt != nil || row.Status() != models.SubscriptionStatusActive {
+		return false
+	}
+
+	return budget.Duration == nil || *budget.Duration == row.Duration
+}
+
+type budgetRenewal struct {
+	Date     time.Time
+	Amount   *int64
+	Currency *string
+}
+
+// monthRenewals returns the renewals of row between from and to.
+// The current and past periods are walked back from the start date, trial periods are not charged,
+// and the upcoming renewals are projected like GetUpcomingRenewals
+func monthRenewals(row *repo.SubscriptionRow, prices scheduledPrices, from, to time.Time) []budgetRenewal {
+	renewals := []budgetRenewal{}
+
+	start, end := row.StartDate, row.EndDate
+	for !start.Before(from) {
+		if row.TrialEndDate != nil && start.Before(*row.TrialEndDate) {
+			break
+		}
+
+		// periods ended before the subscription was tracked were not necessarily paid
+		if !end.After(row.CreatedAt) {
+			break
+		}
+
+		if start.Before(to) {
+			renewals = append(renewals, budgetRenewal{start, row.Amount, row.Currency})
+		}
+
+		end = start
+		start = row.Duration.SubtractDurationFromTimeWithAnchor(end, row.BillingAnchorDay)
+	}
+
+	if row.CancelAtPeriodEnd {
+		return renewals
+	}
+
+	for date := row.EndDate; date.Before(to); date = row.Duration.AddDurationToTimeWithAnchor(
+		date,
+		row.BillingAnchorDay,
+	) {
+		if date.Before(from) {
+			continue
+		}
+
+		renewal := newUpcomingRenewal(row, date, prices)
+		renewals = append(renewals, budgetRenewal{date, renewal.Amount, renewal.Currency})
+	}
+
+	return renewals
+}
@@ -0,0 +1,216 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetBudgets(t *testing.T) {
+	userID := uuid.New()
+
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	createdAt := monthStart.AddDate(-2, 0, 0)
+
+	usd, eur := "USD", "EUR"
+	newRow := func(duration enums.Duration, start, end time.Time, amount int64, currency *string) *repo.SubscriptionRow {
+		row := randomSubscriptionRow(userID)
+		row.Duration = duration
+		row.StartDate = start
+		row.EndDate = end
+		row.CreatedAt = createdAt
+		row.BillingAnchorDay = 1
+		row.Amount = &amount
+		row.Currency = currency
+
+		return row
+	}
+
+	// charged on the first day of the month
+	monthly := newRow(enums.Monthly, monthStart, monthStart.AddDate(0, 1, 0), 1000, &usd)
+	// renews on the first day of the month, the daily job has not moved its dates yet
+	yearly := newRow(enums.Yearly, monthStart.AddDate(-1, 0, 0), monthStart, 5000, &usd)
+	other := newRow(enums.Monthly, monthStart, monthStart.AddDate(0, 1, 0), 900, &eur)
+	unpriced := newRow(enums.Monthly, monthStart, monthStart.AddDate(0, 1, 0), 0, nil)
+	unpriced.Amount = nil
+	cancelled := newRow(enums.Monthly, monthStart, monthStart.AddDate(0, 1, 0), 700, &usd)
+	cancelled.IsCancelled = true
+
+	overall := &models.Budget{ID: uuid.New(), UserID: userID, Currency: usd, Amount: 8000}
+	monthlyOnly := &models.Budget{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Currency: usd,
+		Amount:   500,
+		Duration: &enums.Monthly,
+	}
+
+	// the yearly renewal of this month is charged a price scheduled after its current period started
+	increase := &models.SubscriptionPrice{
+		ID:             uuid.New(),
+		SubscriptionID: yearly.ID,
+		Amount:         7000,
+		Currency:       usd,
+		EffectiveFrom:  models.SubscriptionTime(monthStart),
+	}
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockBudgetRepo, *mocks.MockSubscriptionRepo)
+		checkResponse func(*testing.T, []*service.BudgetStatus, error)
+		name          string
+		prices        []*models.SubscriptionPrice
+	}{
+		{
+			name: "Committed spend of the month",
+			buildStubs: func(b *mocks.MockBudgetRepo, r *mocks.MockSubscriptionRepo) {
+				b.EXPECT().
+					GetBudgets(gomock.Any(), userID).
+					Times(1).
+					Return([]*models.Budget{overall, monthlyOnly}, nil)
+				r.EXPECT().
+					GetUserSubscriptions(gomock.Any(), userID).
+					Times(1).
+					Return([]*repo.SubscriptionRow{monthly, yearly, other, unpriced, cancelled}, nil)
+			},
+			checkResponse: func(t *testing.T, res []*service.BudgetStatus, err error) {
+				require.NoError(t, err)
+				require.Len(t, res, 2)
+
+				require.Equal(t, overall, res[0].Budget)
+				require.Equal(t, monthStart.Format("2006-01"), res[0].Month)
+				require.Equal(t, monthStart, res[0].MonthStart)
+				require.Equal(t, int64(6000), res[0].Committed)
+				require.Equal(t, int64(6000), res[0].Spent)
+				require.Equal(t, int64(2000), res[0].Remaining)
+				require.Equal(t, 75, res[0].UsedPercent)
+				require.Equal(t, 1, res[0].UnpricedCount)
+
+				// only the monthly subscription is covered, the budget is overspent
+				require.Equal(t, int64(1000), res[1].Committed)
+				require.Equal(t, int64(-500), res[1].Remaining)
+				require.Equal(t, 200, res[1].UsedPercent)
+				require.Equal(t, 1, res[1].UnpricedCount)
+			},
+		},
+		{
+			name:   "Scheduled price increase crosses the budget",
+			prices: []*models.SubscriptionPrice{increase},
+			buildStubs: func(b *mocks.MockBudgetRepo, r *mocks.MockSubscriptionRepo) {
+				b.EXPECT().
+					GetBudgets(gomock.Any(), userID).
+					Times(1).
+					Return([]*models.Budget{overall}, nil)
+				r.EXPECT().
+					GetUserSubscriptions(gomock.Any(), userID).
+					Times(1).
+					Return([]*repo.SubscriptionRow{monthly, yearly}, nil)
+			},
+			checkResponse: func(t *testing.T, res []*service.BudgetStatus, err error) {
+				require.NoError(t, err)
+				require.Len(t, res, 1)
+				require.Equal(t, int64(8000), res[0].Committed)
+				require.Equal(t, 100, res[0].UsedPercent)
+			},
+		},
+		{
+			name: "No budgets",
+			buildStubs: func(b *mocks.MockBudgetRepo, r *mocks.MockSubscriptionRepo) {
+				b.EXPECT().
+					GetBudgets(gomock.Any(), userID).
+					Times(1).
+					Return([]*models.Budget{}, nil)
+				r.EXPECT().GetUserSubscriptions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res []*service.BudgetStatus, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.Empty(t, res)
+			},
+		},
+		{
+			name: "Internal error",
+			buildStubs: func(b *mocks.MockBudgetRepo, r *mocks.MockSubscriptionRepo) {
+				b.EXPECT().
+					GetBudgets(gomock.Any(), userID).
+					Times(1).
+					Return([]*models.Budget{overall}, nil)
+				r.EXPECT().
+					GetUserSubscriptions(gomock.Any(), userID).
+					Times(1).
+					Return(nil, errors.New("db error"))
+			},
+			checkResponse: func(t *testing.T, res []*service.BudgetStatus, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			budgetRepo := mocks.NewMockBudgetRepo(ctrl)
+			subscriptionRepo := mocks.NewMockSubscriptionRepo(ctrl)
+			tc.buildStubs(budgetRepo, subscriptionRepo)
+
+			priceRepo := mocks.NewMockSubscriptionPriceRepo(ctrl)
+			priceRepo.EXPECT().
+				GetScheduledSubscriptionPrices(gomock.Any(), userID).
+				AnyTimes().
+				Return(tc.prices, nil)
+
+			s := service.NewBudgetService(budgetRepo, subscriptionRepo, priceRepo)
+
+			res, err := s.GetBudgets(context.Background(), userID)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestDeleteBudget(t *testing.T) {
+	userID := uuid.New()
+	id := uuid.New()
+
+	testCases := []struct {
+		err         error
+		expectedErr error
+		name        string
+	}{
+		{name: "Delete budget successfully"},
+		{name: "Budget not found", err: sql.ErrNoRows, expectedErr: apperror.ErrBudgetNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			budgetRepo := mocks.NewMockBudgetRepo(ctrl)
+			budgetRepo.EXPECT().DeleteBudget(gomock.Any(), id, userID).Times(1).Return(tc.err)
+
+			s := service.NewBudgetService(
+				budgetRepo,
+				mocks.NewMockSubscriptionRepo(ctrl),
+				mocks.NewMockSubscriptionPriceRepo(ctrl),
+			)
+
+			err := s.DeleteBudget(context.Background(), id, userID)
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
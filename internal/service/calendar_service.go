@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/ics"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/money"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+// the feed lists renewals from today until this many days later
+const calendarFeedDays = 365
+
+type CalendarService interface {
+	// GetCalendarFeed returns the iCalendar feed of the user owning token
+	GetCalendarFeed(ctx context.Context, token string) ([]byte, error)
+	RotateCalendarToken(ctx context.Context, userID uuid.UUID) (*RotateCalendarTokenResponse, error)
+}
+
+type calendarService struct {
+	userRepo            repo.UserRepo
+	subscriptionService SubscriptionService
+	reminderDays        []int
+}
+
+func NewCalendarService(
+	userRepo repo.UserRepo,
+	subscriptionService SubscriptionService,
+	reminderDays []int,
+) *calendarService {
+	return &calendarService{userRepo, subscriptionService, reminderDays}
+}
+
+func (s *calendarService) GetCalendarFeed(ctx context.Context, token string) ([]byte, error) {
+	user, err := s.userRepo.GetUserByCalendarTokenHash(ctx, hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrCalendarNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	upcoming, err := s.subscriptionService.GetUpcomingRenewals(ctx, &GetUpcomingRenewalsRequest{
+		From:   from,
+		To:     from.AddDate(0, 0, calendarFeedDays),
+		UserID: user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &ics.Calendar{
+		ProdID: "-//Subdub//Subscription Renewals//EN",
+		Name:   "Subdub renewals",
+	}
+
+	for _, day := range upcoming.Days {
+		date, err := time.Parse(upcomingDateLayout, day.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, renewal := range day.Renewals {
+			calendar.Events = append(calendar.Events, s.newRenewalEvent(renewal, date, now))
+		}
+	}
+
+	return calendar.Marshal(), nil
+}
+
+// newRenewalEvent returns the event of a renewal on date with an alarm for every reminder day
+func (s *calendarService) newRenewalEvent(
+	renewal *UpcomingRenewal,
+	date time.Time,
+	stamp time.Time,
+) *ics.Event {
+	summary := renewal.Name + " renews"
+	if renewal.TrialEnds {
+		summary = renewal.Name + " free trial ends"
+	}
+
+	description := ""
+	if renewal.Amount != nil && renewal.Currency != nil {
+		description = fmt.Sprintf(
+			"Price: %s (%s)",
+			money.Format(*renewal.Amount, *renewal.Currency),
+			renewal.Duration,
+		)
+	}
+
+	event := &ics.Event{
+		// the same renewal keeps its UID when the feed is refreshed
+		UID:         fmt.Sprintf("%s-%s@subdub", renewal.SubscriptionID, date.Format("20060102")),
+		Stamp:       stamp,
+		Date:        date,
+		Summary:     summary,
+		Description: description,
+	}
+
+	for _, days := range s.reminderDays {
+		event.Alarms = append(event.Alarms, &ics.Alarm{
+			DaysBefore:  days,
+			Description: fmt.Sprintf("%s in %d days", summary, days),
+		})
+	}
+
+	return event
+}
+
+type RotateCalendarTokenResponse struct {
+	// Token is only returned once, rotating it again disables the previous feed URL
+	Token    string `json:"token"     example:"b3JhbmdlLXRva2Vu"`
+	FeedPath string `json:"feed_path" example:"/api/v1/calendar/b3JhbmdlLXRva2Vu.ics"`
+}
+
+func (s *calendarService) RotateCalendarToken(
+	ctx context.Context,
+	userID uuid.UUID,
+) (*RotateCalendarTokenResponse, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.userRepo.UpdateCalendarTokenHash(ctx, userID, hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &RotateCalendarTokenResponse{
+		Token:    token,
+		FeedPath: "/api/v1/calendar/" + token + ".ics",
+	}, nil
+}
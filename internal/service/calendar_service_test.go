@@ -0,0 +1,150 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetCalendarFeed(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Email: "user@example.com"}
+	token := "feed-token"
+	sum := sha256.Sum256([]byte(token))
+	tokenHash := hex.EncodeToString(sum[:])
+
+	amount, currency := int64(1599), "USD"
+	renewal := &service.UpcomingRenewal{
+		SubscriptionID: uuid.New(),
+		Name:           "Netflix",
+		Duration:       "monthly",
+		Amount:         &amount,
+		Currency:       &currency,
+	}
+	trial := &service.UpcomingRenewal{
+		SubscriptionID: uuid.New(),
+		Name:           "Music",
+		Duration:       "yearly",
+		TrialEnds:      true,
+	}
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockUserRepo, *mocks.MockSubscriptionService)
+		checkResponse func(*testing.T, string, error)
+		name          string
+	}{
+		{
+			name: "Feed of upcoming renewals",
+			buildStubs: func(userRepo *mocks.MockUserRepo, s *mocks.MockSubscriptionService) {
+				userRepo.EXPECT().
+					GetUserByCalendarTokenHash(gomock.Any(), tokenHash).
+					Times(1).
+					Return(user, nil)
+				s.EXPECT().
+					GetUpcomingRenewals(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(
+						_ context.Context,
+						req *service.GetUpcomingRenewalsRequest,
+					) (*service.GetUpcomingRenewalsResponse, error) {
+						require.Equal(t, user.ID, req.UserID)
+						require.Equal(t, req.From.AddDate(0, 0, 365), req.To)
+
+						return &service.GetUpcomingRenewalsResponse{
+							Days: []*service.UpcomingDay{
+								{Date: "2025-05-15", Renewals: []*service.UpcomingRenewal{renewal}},
+								{Date: "2025-06-01", Renewals: []*service.UpcomingRenewal{trial}},
+							},
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, feed string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 2, strings.Count(feed, "BEGIN:VEVENT"))
+
+				require.Contains(t, feed, "UID:"+renewal.SubscriptionID.String()+"-20250515@subdub")
+				require.Contains(t, feed, "DTSTART;VALUE=DATE:20250515")
+				require.Contains(t, feed, "SUMMARY:Netflix renews\r\n")
+				require.Contains(t, feed, "DESCRIPTION:Price: 15.99 USD (monthly)\r\n")
+
+				require.Contains(t, feed, "SUMMARY:Music free trial ends\r\n")
+
+				// every event has an alarm per reminder day
+				require.Equal(t, 2, strings.Count(feed, "TRIGGER:-P7D"))
+				require.Equal(t, 2, strings.Count(feed, "TRIGGER:-P1D"))
+				require.Contains(t, feed, "DESCRIPTION:Netflix renews in 7 days\r\n")
+			},
+		},
+		{
+			name: "Unknown token",
+			buildStubs: func(userRepo *mocks.MockUserRepo, s *mocks.MockSubscriptionService) {
+				userRepo.EXPECT().
+					GetUserByCalendarTokenHash(gomock.Any(), tokenHash).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+				s.EXPECT().GetUpcomingRenewals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, feed string, err error) {
+				require.ErrorIs(t, err, apperror.ErrCalendarNotFound)
+				require.Empty(t, feed)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mocks.NewMockUserRepo(ctrl)
+			subscriptionService := mocks.NewMockSubscriptionService(ctrl)
+			tc.buildStubs(userRepo, subscriptionService)
+
+			s := service.NewCalendarService(userRepo, subscriptionService, []int{7, 1})
+			feed, err := s.GetCalendarFeed(context.Background(), token)
+			tc.checkResponse(t, string(feed), err)
+		})
+	}
+}
+
+func TestRotateCalendarToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+
+	var storedHash string
+	userRepo := mocks.NewMockUserRepo(ctrl)
+	userRepo.EXPECT().
+		UpdateCalendarTokenHash(gomock.Any(), userID, gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, tokenHash string) error {
+			storedHash = tokenHash
+			return nil
+		})
+
+	s := service.NewCalendarService(userRepo, mocks.NewMockSubscriptionService(ctrl), nil)
+
+	first, err := s.RotateCalendarToken(context.Background(), userID)
+	require.NoError(t, err)
+	require.NotEmpty(t, first.Token)
+	require.Equal(t, "/api/v1/calendar/"+first.Token+".ics", first.FeedPath)
+
+	// only the hash of the token is stored
+	sum := sha256.Sum256([]byte(first.Token))
+	require.Equal(t, hex.EncodeToString(sum[:]), storedHash)
+
+	second, err := s.RotateCalendarToken(context.Background(), userID)
+	require.NoError(t, err)
+	require.NotEqual(t, first.Token, second.Token)
+}
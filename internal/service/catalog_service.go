@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/catalog"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+type CatalogService interface {
+	SearchCatalog(ctx context.Context, query string, limit int) ([]*models.CatalogEntry, error)
+	GetCatalogEntry(ctx context.Context, id uuid.UUID) (*models.CatalogEntry, error)
+	CreateCatalogEntry(
+		ctx context.Context,
+		req *CreateCatalogEntryRequest,
+	) (*models.CatalogEntry, error)
+	UpdateCatalogEntry(
+		ctx context.Context,
+		req *UpdateCatalogEntryRequest,
+	) (*models.CatalogEntry, error)
+	DeleteCatalogEntry(ctx context.Context, id, userID uuid.UUID) error
+	SeedCatalog(ctx context.Context) (int, error)
+}
+
+type catalogService struct {
+	catalogRepo repo.CatalogRepo
+	userRepo    repo.UserRepo
+	transaction repo.TransactionManager
+}
+
+func NewCatalogService(
+	catalogRepo repo.CatalogRepo,
+	userRepo repo.UserRepo,
+	transaction repo.TransactionManager,
+) *catalogService {
+	return &catalogService{catalogRepo, userRepo, transaction}
+}
+
+// SearchCatalog is the autocomplete of service names, see repo.CatalogRepo.SearchCatalog
+func (s *catalogService) SearchCatalog(
+	ctx context.Context,
+	query string,
+	limit int,
+) ([]*models.CatalogEntry, error) {
+	return s.catalogRepo.SearchCatalog(ctx, strings.TrimSpace(query), limit)
+}
+
+func (s *catalogService) GetCatalogEntry(
+	ctx context.Context,
+	id uuid.UUID,
+) (*models.CatalogEntry, error) {
+	entry, err := s.catalogRepo.GetCatalogEntry(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrCatalogNotFound
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// CatalogPlanRequest is a plan of a catalog service, plans are identified by their slug within the service
+type CatalogPlanRequest struct {
+	// Amount is the price in minor units (e.g. cents), it must be sent together with Currency
+	Amount   *int64 `json:"amount"   validate:"required_with=Currency,omitempty,gte=0"`
+	Currency string `json:"currency" validate:"required_with=Amount,omitempty,iso4217"   example:"USD"`
+	Slug     string `json:"slug"     validate:"required,max=100"                         example:"premium-individual"`
+	Name     string `json:"name"     validate:"required,max=50"                          example:"Premium Individual"`
+	// Duration accepts the same values as the duration of a subscription
+	Duration enums.Duration `json:"duration" validate:"required" swaggertype:"string" example:"monthly"`
+}
+
+type CreateCatalogEntryRequest struct {
+	Slug      string                `json:"slug"       validate:"required,max=100"          example:"spotify"`
+	Name      string                `json:"name"       validate:"required,min=1,max=50"     example:"Spotify"`
+	LogoURL   string                `json:"logo_url"   validate:"omitempty,url"`
+	CancelURL string                `json:"cancel_url" validate:"omitempty,url"`
+	Plans     []*CatalogPlanRequest `json:"plans"      validate:"required,min=1,dive"`
+	UserID    uuid.UUID             `json:"-"          validate:"-"`
+}
+
+// CreateCatalogEntry adds a service to the catalog, only admins can edit the catalog
+func (s *catalogService) CreateCatalogEntry(
+	ctx context.Context,
+	req *CreateCatalogEntryRequest,
+) (*models.CatalogEntry, error) {
+	err := s.checkAdmin(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transaction.WithTx(ctx, func(txContext context.Context) error {
+		created, err := s.catalogRepo.CreateCatalogEntry(txContext, &repo.SaveCatalogEntryParams{
+			ID:        id,
+			Slug:      req.Slug,
+			Name:      req.Name,
+			LogoURL:   req.LogoURL,
+			CancelURL: req.CancelURL,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return apperror.ErrExisted
+		}
+
+		return s.savePlans(txContext, id, req.Plans)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCatalogEntry(ctx, id)
+}
+
+// UpdateCatalogEntryRequest replaces a catalog service, plans missing from Plans are deleted
+type UpdateCatalogEntryRequest struct {
+	Name      string                `json:"name"       validate:"required,min=1,max=50"     example:"Spotify"`
+	LogoURL   string                `json:"logo_url"   validate:"omitempty,url"`
+	CancelURL string                `json:"cancel_url" validate:"omitempty,url"`
+	Plans     []*CatalogPlanRequest `json:"plans"      validate:"required,min=1,dive"`
+	ID        uuid.UUID             `json:"-"          validate:"-"`
+	UserID    uuid.UUID             `json:"-"          validate:"-"`
+}
+
+func (s *catalogService) UpdateCatalogEntry(
+	ctx context.Context,
+	req *UpdateCatalogEntryRequest,
+) (*models.CatalogEntry, error) {
+	err := s.checkAdmin(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transaction.WithTx(ctx, func(txContext context.Context) error {
+		err := s.catalogRepo.UpdateCatalogEntry(txContext, &repo.SaveCatalogEntryParams{
+			ID:        req.ID,
+			Name:      req.Name,
+			LogoURL:   req.LogoURL,
+			CancelURL: req.CancelURL,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.ErrCatalogNotFound
+			}
+			return err
+		}
+
+		err = s.savePlans(txContext, req.ID, req.Plans)
+		if err != nil {
+			return err
+		}
+
+		slugs := make([]string, 0, len(req.Plans))
+		for _, plan := range req.Plans {
+			slugs = append(slugs, plan.Slug)
+		}
+
+		return s.catalogRepo.DeleteCatalogPlans(txContext, req.ID, slugs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCatalogEntry(ctx, req.ID)
+}
+
+func (s *catalogService) DeleteCatalogEntry(ctx context.Context, id, userID uuid.UUID) error {
+	err := s.checkAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.catalogRepo.DeleteCatalogEntry(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrCatalogNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// SeedCatalog adds the services of the built-in catalog missing from the database,
+// services already there are left as they are so admin edits are kept,
+// and services deleted by an admin are not added again.
+// It returns the number of services added
+func (s *catalogService) SeedCatalog(ctx context.Context) (int, error) {
+	services, err := catalog.Load()
+	if err != nil {
+		return 0, err
+	}
+
+	var num int
+	err = s.transaction.WithTx(ctx, func(txContext context.Context) error {
+		for _, service := range services {
+			id, err := uuid.NewUUID()
+			if err != nil {
+				return err
+			}
+
+			created, err := s.catalogRepo.SeedCatalogEntry(txContext, &repo.SaveCatalogEntryParams{
+				ID:        id,
+				Slug:      service.Slug,
+				Name:      service.Name,
+				LogoURL:   service.LogoURL,
+				CancelURL: service.CancelURL,
+			})
+			if err != nil {
+				return err
+			}
+
+			if !created {
+				continue
+			}
+
+			plans := make([]*CatalogPlanRequest, 0, len(service.Plans))
+			for _, plan := range service.Plans {
+				plans = append(plans, &CatalogPlanRequest{
+					Amount:   plan.Amount,
+					Currency: plan.Currency,
+					Slug:     plan.Slug,
+					Name:     plan.Name,
+					Duration: plan.Duration,
+				})
+			}
+
+			err = s.savePlans(txContext, id, plans)
+			if err != nil {
+				return err
+			}
+			num++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return num, nil
+}
+
+func (s *catalogService) savePlans(
+	ctx context.Context,
+	serviceID uuid.UUID,
+	plans []*CatalogPlanRequest,
+) error {
+	slugs := make(map[string]bool, len(plans))
+	for _, plan := range plans {
+		if slugs[plan.Slug] {
+			return apperror.ErrDuplicatedPlan
+		}
+		slugs[plan.Slug] = true
+
+		id, err := uuid.NewUUID()
+		if err != nil {
+			return err
+		}
+
+		arg := repo.SaveCatalogPlanParams{
+			ID:        id,
+			ServiceID: serviceID,
+			Slug:      plan.Slug,
+			Name:      plan.Name,
+			Amount:    plan.Amount,
+			Duration:  plan.Duration,
+		}
+		if plan.Currency != "" {
+			currency := strings.ToUpper(plan.Currency)
+			arg.Currency = &currency
+		}
+
+		err = s.catalogRepo.SaveCatalogPlan(ctx, &arg)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkAdmin returns apperror.ErrForbidden unless the operator made the user an admin.
+// The email is not enough since it is not verified when signing up with a password
+func (s *catalogService) checkAdmin(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrForbidden
+		}
+		return err
+	}
+
+	if !user.IsAdmin {
+		return apperror.ErrForbidden
+	}
+
+	return nil
+}
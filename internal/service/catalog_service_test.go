@@ -0,0 +1,171 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/catalog"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateCatalogEntry(t *testing.T) {
+	admin := &models.User{ID: uuid.New(), Email: "john.doe@example.com", IsAdmin: true}
+	user := &models.User{ID: uuid.New(), Email: "admin@example.com"}
+	amount := int64(1199)
+
+	plan := &service.CatalogPlanRequest{
+		Slug:     "premium",
+		Name:     "Premium",
+		Amount:   &amount,
+		Currency: "usd",
+		Duration: enums.Monthly,
+	}
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockCatalogRepo)
+		checkResponse func(*testing.T, *models.CatalogEntry, error)
+		user          *models.User
+		name          string
+		plans         []*service.CatalogPlanRequest
+	}{
+		{
+			name:  "Admin",
+			user:  admin,
+			plans: []*service.CatalogPlanRequest{plan},
+			buildStubs: func(c *mocks.MockCatalogRepo) {
+				c.EXPECT().CreateCatalogEntry(gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+				c.EXPECT().
+					SaveCatalogPlan(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg *repo.SaveCatalogPlanParams) error {
+						require.Equal(t, "USD", *arg.Currency)
+						require.Equal(t, amount, *arg.Amount)
+						return nil
+					})
+				c.EXPECT().
+					GetCatalogEntry(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&models.CatalogEntry{Slug: "spotify"}, nil)
+			},
+			checkResponse: func(t *testing.T, res *models.CatalogEntry, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "spotify", res.Slug)
+			},
+		},
+		{
+			name:  "Admin email without the admin flag",
+			user:  user,
+			plans: []*service.CatalogPlanRequest{plan},
+			buildStubs: func(c *mocks.MockCatalogRepo) {
+				c.EXPECT().CreateCatalogEntry(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *models.CatalogEntry, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrForbidden)
+			},
+		},
+		{
+			name:  "Slug existed",
+			user:  admin,
+			plans: []*service.CatalogPlanRequest{plan},
+			buildStubs: func(c *mocks.MockCatalogRepo) {
+				c.EXPECT().CreateCatalogEntry(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+				c.EXPECT().SaveCatalogPlan(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *models.CatalogEntry, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrExisted)
+			},
+		},
+		{
+			name:  "Duplicated plan",
+			user:  admin,
+			plans: []*service.CatalogPlanRequest{plan, plan},
+			buildStubs: func(c *mocks.MockCatalogRepo) {
+				c.EXPECT().CreateCatalogEntry(gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+				c.EXPECT().SaveCatalogPlan(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *models.CatalogEntry, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrDuplicatedPlan)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCatalogRepo := mocks.NewMockCatalogRepo(ctrl)
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			mockUserRepo.EXPECT().GetUserByID(gomock.Any(), tc.user.ID).Times(1).Return(tc.user, nil)
+			tc.buildStubs(mockCatalogRepo)
+
+			catalogService := service.NewCatalogService(
+				mockCatalogRepo,
+				mockUserRepo,
+				&fakeTransaction{},
+			)
+
+			res, err := catalogService.CreateCatalogEntry(
+				context.Background(),
+				&service.CreateCatalogEntryRequest{
+					Slug:   "spotify",
+					Name:   "Spotify",
+					Plans:  tc.plans,
+					UserID: tc.user.ID,
+				},
+			)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestSeedCatalog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	services, err := catalog.Load()
+	require.NoError(t, err)
+
+	// the first service is already in the database and the second one was deleted by an admin,
+	// so neither gets its plans saved
+	var plans int
+	for _, s := range services[2:] {
+		plans += len(s.Plans)
+	}
+
+	mockCatalogRepo := mocks.NewMockCatalogRepo(ctrl)
+	skipped := mockCatalogRepo.EXPECT().
+		SeedCatalogEntry(gomock.Any(), gomock.Any()).
+		Times(2).
+		Return(false, nil)
+	mockCatalogRepo.EXPECT().
+		SeedCatalogEntry(gomock.Any(), gomock.Any()).
+		Times(len(services)-2).
+		Return(true, nil).
+		After(skipped)
+	mockCatalogRepo.EXPECT().CreateCatalogEntry(gomock.Any(), gomock.Any()).Times(0)
+	mockCatalogRepo.EXPECT().SaveCatalogPlan(gomock.Any(), gomock.Any()).Times(plans).Return(nil)
+
+	transaction := &fakeTransaction{}
+	catalogService := service.NewCatalogService(
+		mockCatalogRepo,
+		mocks.NewMockUserRepo(ctrl),
+		transaction,
+	)
+
+	num, err := catalogService.SeedCatalog(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, len(services)-2, num)
+	require.Equal(t, 1, transaction.calls)
+}
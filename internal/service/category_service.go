@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+type CategoryService interface {
+	GetAllCategories(ctx context.Context, userID uuid.UUID) ([]*models.Category, error)
+	CreateCategory(ctx context.Context, req *CreateCategoryRequest) (*models.Category, error)
+	UpdateCategory(ctx context.Context, req *UpdateCategoryRequest) (*models.Category, error)
+	DeleteCategory(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+}
+
+type categoryService struct {
+	repo repo.CategoryRepo
+}
+
+func NewCategoryService(repo repo.CategoryRepo) *categoryService {
+	return &categoryService{repo}
+}
+
+func (s *categoryService) GetAllCategories(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.Category, error) {
+	return s.repo.GetAllCategories(ctx, userID)
+}
+
+type CreateCategoryRequest struct {
+	Name   string    `json:"name" validate:"required,min=1,max=50" example:"streaming"`
+	UserID uuid.UUID `json:"-"    validate:"-"`
+}
+
+func (s *categoryService) CreateCategory(
+	ctx context.Context,
+	req *CreateCategoryRequest,
+) (*models.Category, error) {
+	err := s.checkCategoryNameNotExisted(ctx, req.UserID, req.Name, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateCategory(ctx, &repo.CreateCategoryParams{
+		ID:     id,
+		UserID: req.UserID,
+		Name:   req.Name,
+	})
+}
+
+type UpdateCategoryRequest struct {
+	Name   string    `json:"name" validate:"required,min=1,max=50" example:"streaming"`
+	ID     uuid.UUID `json:"-"    validate:"-"`
+	UserID uuid.UUID `json:"-"    validate:"-"`
+}
+
+func (s *categoryService) UpdateCategory(
+	ctx context.Context,
+	req *UpdateCategoryRequest,
+) (*models.Category, error) {
+	err := s.checkCategoryNameNotExisted(ctx, req.UserID, req.Name, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := s.repo.UpdateCategory(ctx, &repo.UpdateCategoryParams{
+		ID:     req.ID,
+		UserID: req.UserID,
+		Name:   req.Name,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrCategoryNotFound
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (s *categoryService) DeleteCategory(
+	ctx context.Context,
+	id uuid.UUID,
+	userID uuid.UUID,
+) error {
+	err := s.repo.DeleteCategory(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrCategoryNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *categoryService) checkCategoryNameNotExisted(
+	ctx context.Context,
+	userID uuid.UUID,
+	name string,
+	id uuid.UUID,
+) error {
+	return checkNameNotExisted(
+		ctx,
+		s.repo.GetCategoryByName,
+		func(category *models.Category) uuid.UUID { return category.ID },
+		userID,
+		name,
+		id,
+	)
+}
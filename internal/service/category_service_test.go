@@ -0,0 +1,204 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateCategory(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockCategoryRepo)
+		checkResponse func(*testing.T, *models.Category, error)
+		name          string
+	}{
+		{
+			name: "Create category successfully",
+			buildStubs: func(r *mocks.MockCategoryRepo) {
+				r.EXPECT().
+					GetCategoryByName(gomock.Any(), userID, "streaming").
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+				r.EXPECT().
+					CreateCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg *repo.CreateCategoryParams) (*models.Category, error) {
+						return &models.Category{ID: arg.ID, UserID: arg.UserID, Name: arg.Name}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *models.Category, err error) {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, res.ID)
+				require.Equal(t, userID, res.UserID)
+				require.Equal(t, "streaming", res.Name)
+			},
+		},
+		{
+			name: "Duplicated name",
+			buildStubs: func(r *mocks.MockCategoryRepo) {
+				r.EXPECT().
+					GetCategoryByName(gomock.Any(), userID, "streaming").
+					Times(1).
+					Return(&models.Category{ID: uuid.New(), UserID: userID, Name: "streaming"}, nil)
+				r.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *models.Category, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrExisted)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockCategoryRepo(ctrl)
+			tc.buildStubs(mockRepo)
+			categoryService := service.NewCategoryService(mockRepo)
+
+			res, err := categoryService.CreateCategory(
+				context.Background(),
+				&service.CreateCategoryRequest{Name: "streaming", UserID: userID},
+			)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestUpdateCategory(t *testing.T) {
+	userID := uuid.New()
+	category := &models.Category{ID: uuid.New(), UserID: userID, Name: "streaming"}
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockCategoryRepo)
+		checkResponse func(*testing.T, *models.Category, error)
+		name          string
+		categoryName  string
+		userID        uuid.UUID
+	}{
+		{
+			name:         "Rename category",
+			userID:       userID,
+			categoryName: "music",
+			buildStubs: func(r *mocks.MockCategoryRepo) {
+				r.EXPECT().
+					GetCategoryByName(gomock.Any(), userID, "music").
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+				r.EXPECT().
+					UpdateCategory(gomock.Any(), &repo.UpdateCategoryParams{
+						ID:     category.ID,
+						UserID: userID,
+						Name:   "music",
+					}).
+					Times(1).
+					Return(&models.Category{ID: category.ID, UserID: userID, Name: "music"}, nil)
+			},
+			checkResponse: func(t *testing.T, res *models.Category, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "music", res.Name)
+			},
+		},
+		{
+			name:         "Keep its own name",
+			userID:       userID,
+			categoryName: "streaming",
+			buildStubs: func(r *mocks.MockCategoryRepo) {
+				r.EXPECT().GetCategoryByName(gomock.Any(), userID, "streaming").Times(1).Return(category, nil)
+				r.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Times(1).Return(category, nil)
+			},
+			checkResponse: func(t *testing.T, res *models.Category, err error) {
+				require.NoError(t, err)
+				require.Equal(t, category, res)
+			},
+		},
+		{
+			name:         "Duplicated name",
+			userID:       userID,
+			categoryName: "news",
+			buildStubs: func(r *mocks.MockCategoryRepo) {
+				r.EXPECT().
+					GetCategoryByName(gomock.Any(), userID, "news").
+					Times(1).
+					Return(&models.Category{ID: uuid.New(), UserID: userID, Name: "news"}, nil)
+				r.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *models.Category, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrExisted)
+			},
+		},
+		{
+			name:         "Owned by another user",
+			userID:       uuid.New(),
+			categoryName: "music",
+			buildStubs: func(r *mocks.MockCategoryRepo) {
+				r.EXPECT().
+					GetCategoryByName(gomock.Any(), gomock.Any(), "music").
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+				r.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, res *models.Category, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrCategoryNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockCategoryRepo(ctrl)
+			tc.buildStubs(mockRepo)
+			categoryService := service.NewCategoryService(mockRepo)
+
+			res, err := categoryService.UpdateCategory(
+				context.Background(),
+				&service.UpdateCategoryRequest{ID: category.ID, UserID: tc.userID, Name: tc.categoryName},
+			)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	id := uuid.New()
+
+	mockRepo := mocks.NewMockCategoryRepo(ctrl)
+	mockRepo.EXPECT().DeleteCategory(gomock.Any(), id, userID).Times(1).Return(nil)
+	// a category of another user is not found
+	mockRepo.EXPECT().
+		DeleteCategory(gomock.Any(), id, gomock.Not(userID)).
+		Times(1).
+		Return(sql.ErrNoRows)
+
+	categoryService := service.NewCategoryService(mockRepo)
+
+	err := categoryService.DeleteCategory(context.Background(), id, uuid.New())
+	require.ErrorIs(t, err, apperror.ErrCategoryNotFound)
+
+	err = categoryService.DeleteCategory(context.Background(), id, userID)
+	require.NoError(t, err)
+}
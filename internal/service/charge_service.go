@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+// maxBackfillPeriods bounds the periods booked per subscription by a backfill,
+// it is more than 20 years of weekly renewals
+const maxBackfillPeriods = 1100
+
+type ChargeService interface {
+	GetCharges(ctx context.Context, req *GetChargesRequest) (*GetChargesResponse, error)
+	BackfillCharges(ctx context.Context, userID uuid.UUID) (*BackfillChargesResponse, error)
+}
+
+type chargeService struct {
+	chargeRepo       repo.ChargeRepo
+	subscriptionRepo repo.SubscriptionRepo
+	priceRepo        repo.SubscriptionPriceRepo
+	transaction      repo.TransactionManager
+}
+
+func NewChargeService(
+	chargeRepo repo.ChargeRepo,
+	subscriptionRepo repo.SubscriptionRepo,
+	priceRepo repo.SubscriptionPriceRepo,
+	transaction repo.TransactionManager,
+) *chargeService {
+	return &chargeService{chargeRepo, subscriptionRepo, priceRepo, transaction}
+}
+
+type GetChargesRequest struct {
+	SubscriptionID *uuid.UUID `validate:"-"`
+	// From and To filter on the period start, both are inclusive
+	From     *time.Time `validate:"-"`
+	To       *time.Time `validate:"-"`
+	Currency string     `validate:"omitempty,iso4217"`
+	UserID   uuid.UUID  `validate:"-"`
+}
+
+// amounts are in minor units of the currency they are grouped by,
+// different currencies are never added together
+type GetChargesResponse struct {
+	Charges []*models.Charge `json:"charges"`
+	Totals  []*ChargeTotal   `json:"totals"`
+	// UnpricedCount is the number of charges whose price is unknown, they are not in the totals
+	UnpricedCount int `json:"unpriced_count" example:"1"`
+}
+
+type ChargeTotal struct {
+	Currency string `json:"currency" example:"USD"`
+	Total    int64  `json:"total"    example:"4797"`
+	Count    int    `json:"count"    example:"3"`
+}
+
+// GetCharges returns the charges of the user matching the filters, most recent period first,
+// with their total per currency
+func (s *chargeService) GetCharges(
+	ctx context.Context,
+	req *GetChargesRequest,
+) (*GetChargesResponse, error) {
+	charges, err := s.chargeRepo.GetCharges(ctx, &repo.GetChargesParams{
+		UserID:         req.UserID,
+		SubscriptionID: req.SubscriptionID,
+		Currency:       req.Currency,
+		From:           req.From,
+		To:             req.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &GetChargesResponse{Charges: charges, Totals: []*ChargeTotal{}}
+	totals := map[string]*ChargeTotal{}
+
+	for _, charge := range charges {
+		if charge.Amount == nil || charge.Currency == nil {
+			res.UnpricedCount++
+			continue
+		}
+
+		total, ok := totals[*charge.Currency]
+		if !ok {
+			total = &ChargeTotal{Currency: *charge.Currency}
+			totals[*charge.Currency] = total
+			res.Totals = append(res.Totals, total)
+		}
+
+		total.Total += *charge.Amount
+		total.Count++
+	}
+
+	return res, nil
+}
+
+type BackfillChargesResponse struct {
+	// Created is the number of charges booked, periods which are already booked are skipped
+	Created int `json:"created" example:"12"`
+}
+
+// BackfillCharges books the past periods of every subscription of the user,
+// from the period in progress when the subscription was created up to its current period.
+// Trial periods are not charged, and running it again never books a period twice
+func (s *chargeService) BackfillCharges(
+	ctx context.Context,
+	userID uuid.UUID,
+) (*BackfillChargesResponse, error) {
+	subs, err := s.subscriptionRepo.GetUserSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &BackfillChargesResponse{}
+	now := time.Now()
+
+	err = s.transaction.WithTx(ctx, func(txContext context.Context) error {
+		for _, sub := range subs {
+			if sub.InTrial() {
+				continue
+			}
+
+			created, err := s.backfillSubscription(txContext, sub, now)
+			if err != nil {
+				return err
+			}
+
+			res.Created += created
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// backfillSubscription walks back from the current period of sub and books every period
+func (s *chargeService) backfillSubscription(
+	ctx context.Context,
+	sub *repo.SubscriptionRow,
+	now time.Time,
+) (int, error) {
+	prices, err := s.priceRepo.GetSubscriptionPrices(ctx, sub.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	start, end := sub.StartDate, sub.EndDate
+
+	for range maxBackfillPeriods {
+		// periods ended before the subscription was tracked were not necessarily paid
+		if !end.After(sub.CreatedAt) {
+			break
+		}
+
+		if sub.TrialEndDate != nil && start.Before(*sub.TrialEndDate) {
+			break
+		}
+
+		if !start.After(now) {
+			id, err := uuid.NewUUID()
+			if err != nil {
+				return 0, err
+			}
+
+			amount, currency := priceAt(sub, prices, start)
+
+			ok, err := s.chargeRepo.CreateCharge(ctx, &repo.CreateChargeParams{
+				ID:               id,
+				UserID:           sub.UserID,
+				SubscriptionID:   sub.ID,
+				SubscriptionName: sub.Name,
+				PeriodStart:      start,
+				PeriodEnd:        end,
+				Amount:           amount,
+				Currency:         currency,
+			})
+			if err != nil {
+				return 0, err
+			}
+
+			if ok {
+				created++
+			}
+		}
+
+		end = start
+		start = sub.Duration.SubtractDurationFromTimeWithAnchor(end, sub.BillingAnchorDay)
+	}
+
+	return created, nil
+}
+
+// priceAt returns the price effective at the given date from a history ordered oldest first.
+// Periods before the history are charged at the oldest known price,
+// and the current price is used when there is no history
+func priceAt(
+	sub *repo.SubscriptionRow,
+	prices []*models.SubscriptionPrice,
+	at time.Time,
+) (*int64, *string) {
+	if len(prices) == 0 {
+		return sub.Amount, sub.Currency
+	}
+
+	price := prices[0]
+	for _, p := range prices[1:] {
+		if time.Time(p.EffectiveFrom).After(at) {
+			break
+		}
+		price = p
+	}
+
+	return &price.Amount, &price.Currency
+}
@@ -0,0 +1,287 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func randomCharge(userID uuid.UUID, amount int64, currency string) *models.Charge {
+	start := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	return &models.Charge{
+		ID:               uuid.New(),
+		UserID:           userID,
+		SubscriptionName: "Netflix Premium",
+		PeriodStart:      models.SubscriptionTime(start),
+		PeriodEnd:        models.SubscriptionTime(start.AddDate(0, 1, 0)),
+		Amount:           &amount,
+		Currency:         &currency,
+	}
+}
+
+func TestGetCharges(t *testing.T) {
+	userID := uuid.New()
+	subscriptionID := uuid.New()
+	from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	unpriced := randomCharge(userID, 0, "")
+	unpriced.Amount = nil
+	unpriced.Currency = nil
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockChargeRepo)
+		checkResponse func(*testing.T, *service.GetChargesResponse, error)
+		name          string
+	}{
+		{
+			name: "Charges totaled per currency",
+			buildStubs: func(r *mocks.MockChargeRepo) {
+				r.EXPECT().
+					GetCharges(gomock.Any(), &repo.GetChargesParams{
+						UserID:         userID,
+						SubscriptionID: &subscriptionID,
+						From:           &from,
+					}).
+					Times(1).
+					Return([]*models.Charge{
+						randomCharge(userID, 1599, "USD"),
+						randomCharge(userID, 999, "EUR"),
+						unpriced,
+						randomCharge(userID, 1299, "USD"),
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, res *service.GetChargesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.Charges, 4)
+				require.Equal(t, 1, res.UnpricedCount)
+				require.Equal(t, []*service.ChargeTotal{
+					{Currency: "USD", Total: 2898, Count: 2},
+					{Currency: "EUR", Total: 999, Count: 1},
+				}, res.Totals)
+			},
+		},
+		{
+			name: "No charges",
+			buildStubs: func(r *mocks.MockChargeRepo) {
+				r.EXPECT().
+					GetCharges(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]*models.Charge{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *service.GetChargesResponse, err error) {
+				require.NoError(t, err)
+				require.Empty(t, res.Charges)
+				require.NotNil(t, res.Totals)
+				require.Empty(t, res.Totals)
+			},
+		},
+		{
+			name: "Internal error",
+			buildStubs: func(r *mocks.MockChargeRepo) {
+				r.EXPECT().
+					GetCharges(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("db error"))
+			},
+			checkResponse: func(t *testing.T, res *service.GetChargesResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			chargeRepo := mocks.NewMockChargeRepo(ctrl)
+			tc.buildStubs(chargeRepo)
+
+			s := service.NewChargeService(
+				chargeRepo,
+				mocks.NewMockSubscriptionRepo(ctrl),
+				mocks.NewMockSubscriptionPriceRepo(ctrl),
+				&fakeTransaction{},
+			)
+
+			res, err := s.GetCharges(context.Background(), &service.GetChargesRequest{
+				UserID:         userID,
+				SubscriptionID: &subscriptionID,
+				From:           &from,
+			})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestBackfillCharges(t *testing.T) {
+	userID := uuid.New()
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	usd := "USD"
+	amount := int64(1599)
+
+	// tracked since Feb 20, so the period from Feb 15 is the first one booked
+	row := randomSubscriptionRow(userID)
+	row.StartDate = date(time.April, 15)
+	row.EndDate = date(time.May, 15)
+	row.CreatedAt = date(time.February, 20)
+	row.BillingAnchorDay = 15
+	row.Amount = &amount
+	row.Currency = &usd
+
+	// the first paid period starts at the trial end date
+	converted := randomSubscriptionRow(userID)
+	converted.StartDate = date(time.April, 15)
+	converted.EndDate = date(time.May, 15)
+	trialEnd := date(time.March, 15)
+	converted.TrialEndDate = &trialEnd
+	converted.Amount = &amount
+	converted.Currency = &usd
+
+	inTrial := randomSubscriptionRow(userID)
+	inTrial.TrialEndDate = &inTrial.EndDate
+
+	prices := []*models.SubscriptionPrice{
+		{EffectiveFrom: models.SubscriptionTime(date(time.January, 15)), Amount: 1299, Currency: usd},
+		{EffectiveFrom: models.SubscriptionTime(date(time.April, 15)), Amount: 1599, Currency: usd},
+	}
+
+	type charge struct {
+		start  time.Time
+		end    time.Time
+		amount int64
+	}
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockSubscriptionRepo, *mocks.MockSubscriptionPriceRepo, *mocks.MockChargeRepo, *[]charge)
+		checkResponse func(*testing.T, *service.BackfillChargesResponse, []charge, error)
+		name          string
+	}{
+		{
+			name: "Periods since creation priced from history",
+			buildStubs: func(
+				r *mocks.MockSubscriptionRepo,
+				p *mocks.MockSubscriptionPriceRepo,
+				c *mocks.MockChargeRepo,
+				booked *[]charge,
+			) {
+				r.EXPECT().
+					GetUserSubscriptions(gomock.Any(), userID).
+					Times(1).
+					Return([]*repo.SubscriptionRow{row, inTrial}, nil)
+				p.EXPECT().
+					GetSubscriptionPrices(gomock.Any(), row.ID).
+					Times(1).
+					Return(prices, nil)
+				c.EXPECT().
+					CreateCharge(gomock.Any(), gomock.Any()).
+					Times(3).
+					DoAndReturn(func(_ context.Context, arg *repo.CreateChargeParams) (bool, error) {
+						*booked = append(*booked, charge{arg.PeriodStart, arg.PeriodEnd, *arg.Amount})
+						// the current period was booked on renewal already
+						return arg.PeriodStart != row.StartDate, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *service.BackfillChargesResponse, booked []charge, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 2, res.Created)
+				require.Equal(t, []charge{
+					{date(time.April, 15), date(time.May, 15), 1599},
+					{date(time.March, 15), date(time.April, 15), 1299},
+					{date(time.February, 15), date(time.March, 15), 1299},
+				}, booked)
+			},
+		},
+		{
+			name: "Trial periods are not charged",
+			buildStubs: func(
+				r *mocks.MockSubscriptionRepo,
+				p *mocks.MockSubscriptionPriceRepo,
+				c *mocks.MockChargeRepo,
+				booked *[]charge,
+			) {
+				r.EXPECT().
+					GetUserSubscriptions(gomock.Any(), userID).
+					Times(1).
+					Return([]*repo.SubscriptionRow{converted}, nil)
+				p.EXPECT().
+					GetSubscriptionPrices(gomock.Any(), converted.ID).
+					Times(1).
+					Return([]*models.SubscriptionPrice{}, nil)
+				c.EXPECT().
+					CreateCharge(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(_ context.Context, arg *repo.CreateChargeParams) (bool, error) {
+						*booked = append(*booked, charge{arg.PeriodStart, arg.PeriodEnd, *arg.Amount})
+						return true, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *service.BackfillChargesResponse, booked []charge, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 2, res.Created)
+				// without history the current price is used
+				require.Equal(t, []charge{
+					{date(time.April, 15), date(time.May, 15), 1599},
+					{date(time.March, 15), date(time.April, 15), 1599},
+				}, booked)
+			},
+		},
+		{
+			name: "Internal error",
+			buildStubs: func(
+				r *mocks.MockSubscriptionRepo,
+				p *mocks.MockSubscriptionPriceRepo,
+				c *mocks.MockChargeRepo,
+				booked *[]charge,
+			) {
+				r.EXPECT().
+					GetUserSubscriptions(gomock.Any(), userID).
+					Times(1).
+					Return([]*repo.SubscriptionRow{row}, nil)
+				p.EXPECT().
+					GetSubscriptionPrices(gomock.Any(), row.ID).
+					Times(1).
+					Return(prices, nil)
+				c.EXPECT().
+					CreateCharge(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, errors.New("db error"))
+			},
+			checkResponse: func(t *testing.T, res *service.BackfillChargesResponse, booked []charge, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			subscriptionRepo := mocks.NewMockSubscriptionRepo(ctrl)
+			priceRepo := mocks.NewMockSubscriptionPriceRepo(ctrl)
+			chargeRepo := mocks.NewMockChargeRepo(ctrl)
+
+			var booked []charge
+			tc.buildStubs(subscriptionRepo, priceRepo, chargeRepo, &booked)
+
+			transaction := &fakeTransaction{}
+			s := service.NewChargeService(chargeRepo, subscriptionRepo, priceRepo, transaction)
+
+			res, err := s.BackfillCharges(context.Background(), userID)
+			tc.checkResponse(t, res, booked, err)
+			require.Equal(t, 1, transaction.calls)
+		})
+	}
+}
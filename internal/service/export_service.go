@@ -0,0 +1,745 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/config"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/mailer"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+// an export generated in background must be written within this time
+const exportJobTimeout = 5 * time.Minute
+
+type ExportService interface {
+	// ExportUserData collects every personal data of the user to be downloaded directly,
+	// a user with more than SyncLimit subscriptions has to queue an export instead
+	ExportUserData(ctx context.Context, userID uuid.UUID) (*UserDataExport, error)
+	// QueueExport generates the export of the user in background and emails its download link,
+	// a user can only queue a new export once the previous one expired
+	QueueExport(ctx context.Context, userID uuid.UUID) error
+	// OpenExport opens an export generated in background with the token of its download link
+	OpenExport(token string) (*ExportFile, error)
+}
+
+// BackgroundRunner runs fn in a goroutine which the server waits for before shutting down
+type BackgroundRunner interface {
+	Run(fn func())
+}
+
+type exportService struct {
+	userRepo          repo.UserRepo
+	subscriptionRepo  repo.SubscriptionRepo
+	categoryRepo      repo.CategoryRepo
+	tagRepo           repo.TagRepo
+	sessionRepo       repo.SessionRepo
+	authProviderRepo  repo.AuthProviderRepo
+	reminderLogRepo   repo.ReminderLogRepo
+	priceRepo         repo.SubscriptionPriceRepo
+	chargeRepo        repo.ChargeRepo
+	budgetRepo        repo.BudgetRepo
+	memberRepo        repo.SubscriptionMemberRepo
+	attachmentRepo    repo.AttachmentRepo
+	paymentMethodRepo repo.PaymentMethodRepo
+	mailer            mailer.Mailer
+	background        BackgroundRunner
+	config            *config.ExportConfig
+}
+
+func NewExportService(
+	repo *repo.Repo,
+	mailer mailer.Mailer,
+	background BackgroundRunner,
+	config *config.ExportConfig,
+) *exportService {
+	return &exportService{
+		userRepo:          repo.User,
+		subscriptionRepo:  repo.Subscription,
+		categoryRepo:      repo.Category,
+		tagRepo:           repo.Tag,
+		sessionRepo:       repo.Session,
+		authProviderRepo:  repo.AuthProvider,
+		reminderLogRepo:   repo.ReminderLog,
+		priceRepo:         repo.Price,
+		chargeRepo:        repo.Charge,
+		budgetRepo:        repo.Budget,
+		memberRepo:        repo.Member,
+		attachmentRepo:    repo.Attachment,
+		paymentMethodRepo: repo.PaymentMethod,
+		mailer:            mailer,
+		background:        background,
+		config:            config,
+	}
+}
+
+type UserDataExport struct {
+	data *userData
+	// FileName is the name the zip is downloaded as
+	FileName string
+}
+
+// WriteZip writes the export as a zip of export.json and a csv file per entity
+func (e *UserDataExport) WriteZip(w io.Writer) error {
+	return writeUserDataZip(w, e.data)
+}
+
+type ExportFile struct {
+	io.ReadCloser
+	Name string
+	Size int64
+}
+
+// userData is the content of export.json
+type userData struct {
+	ExportedAt     time.Time                   `json:"exported_at"`
+	Profile        *exportProfile              `json:"profile"`
+	Subscriptions  []*models.Subscription      `json:"subscriptions"`
+	Prices         []*models.SubscriptionPrice `json:"prices"`
+	Charges        []*models.Charge            `json:"charges"`
+	Categories     []*models.Category          `json:"categories"`
+	Tags           []*models.Tag               `json:"tags"`
+	PaymentMethods []*models.PaymentMethod     `json:"payment_methods"`
+	// only the metadata of attachments is exported, not their content
+	Attachments []*models.Attachment `json:"attachments"`
+	// Memberships are the subscriptions shared with the user and the members of the subscriptions they share
+	Memberships   []*models.SubscriptionMember `json:"memberships"`
+	Budgets       []*models.Budget             `json:"budgets"`
+	Sessions      []*exportSession             `json:"sessions"`
+	AuthProviders []*exportAuthProvider        `json:"auth_providers"`
+	Reminders     []*models.ReminderLog        `json:"reminders"`
+}
+
+// the password hash is never exported
+type exportProfile struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Email       string    `json:"email"`
+	ID          uuid.UUID `json:"id"`
+	HasPassword bool      `json:"has_password"`
+}
+
+// only session metadata is exported, refresh tokens are secrets
+type exportSession struct {
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	ID        uuid.UUID `json:"id"`
+	IsRevoked bool      `json:"is_revoked"`
+}
+
+type exportAuthProvider struct {
+	CreatedAt  time.Time `json:"created_at"`
+	Provider   string    `json:"provider"`
+	ProviderID string    `json:"provider_id"`
+	ID         uuid.UUID `json:"id"`
+}
+
+func (s *exportService) ExportUserData(
+	ctx context.Context,
+	userID uuid.UUID,
+) (*UserDataExport, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.subscriptionRepo.CountUserSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > s.config.SyncLimit {
+		return nil, apperror.ErrExportTooLarge
+	}
+
+	data, err := s.collectUserData(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserDataExport{data: data, FileName: exportFileName(data.ExportedAt)}, nil
+}
+
+// QueueExport creates a marker of the user before starting the export so only one export is generated at a time.
+// The marker is kept until it expires with the export, it is removed when the export fails
+func (s *exportService) QueueExport(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.purgeExpiredExports()
+	if err != nil {
+		log.Println("purge expired exports:", err)
+	}
+
+	err = os.MkdirAll(s.config.Dir, 0o700)
+	if err != nil {
+		return err
+	}
+
+	marker := s.markerPath(userID)
+	file, err := os.OpenFile(marker, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return apperror.ErrExportPending
+		}
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	s.background.Run(func() {
+		err := s.generateExport(user)
+		if err != nil {
+			log.Println("generate export:", err)
+			_ = os.Remove(marker)
+			return
+		}
+
+		// the user can queue a new export once this one expired
+		now := time.Now()
+		_ = os.Chtimes(marker, now, now)
+	})
+
+	return nil
+}
+
+func (s *exportService) getUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// generateExport writes the export of user to the export directory and emails its download link
+func (s *exportService) generateExport(user *models.User) error {
+	ctx, cancel := context.WithTimeout(context.Background(), exportJobTimeout)
+	defer cancel()
+
+	data, err := s.collectUserData(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		return err
+	}
+
+	// the zip is only visible under its final name once it is complete
+	path := s.exportPath(token)
+	file, err := os.OpenFile(path+".tmp", os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	err = writeUserDataZip(file, data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path + ".tmp")
+		return err
+	}
+
+	err = os.Rename(path+".tmp", path)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.SendWithRetry(&mailer.SendRequest{
+		To:       []string{user.Email},
+		Template: mailer.ExportReadyTemplate,
+		Data: mailer.ExportReadyData{
+			Email:          user.Email,
+			Link:           strings.TrimSuffix(s.config.BaseURL, "/") + "/api/v1/export/" + token + ".zip",
+			ExpiresInHours: s.config.ExpiryHours,
+		},
+	}, 3)
+}
+
+func (s *exportService) OpenExport(token string) (*ExportFile, error) {
+	path := s.exportPath(token)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, apperror.ErrExportNotFound
+		}
+		return nil, err
+	}
+
+	if s.isExpired(info) {
+		_ = os.Remove(path)
+		return nil, apperror.ErrExportNotFound
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportFile{
+		ReadCloser: file,
+		Name:       exportFileName(info.ModTime()),
+		Size:       info.Size(),
+	}, nil
+}
+
+// exports are named by the hash of their token, so a token from a link never reaches the file system
+func (s *exportService) exportPath(token string) string {
+	return filepath.Join(s.config.Dir, hashSecretToken(token)+".zip")
+}
+
+// markerPath is the file marking that the user has a pending or unexpired export
+func (s *exportService) markerPath(userID uuid.UUID) string {
+	return filepath.Join(s.config.Dir, userID.String()+".queued")
+}
+
+func (s *exportService) isExpired(info fs.FileInfo) bool {
+	return time.Since(info.ModTime()) > time.Duration(s.config.ExpiryHours)*time.Hour
+}
+
+// purgeExpiredExports deletes expired exports with their markers and files left by interrupted exports
+func (s *exportService) purgeExpiredExports() error {
+	entries, err := os.ReadDir(s.config.Dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() || !s.isExpired(info) {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(s.config.Dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *exportService) collectUserData(ctx context.Context, user *models.User) (*userData, error) {
+	data := &userData{
+		ExportedAt: time.Now().UTC(),
+		Profile: &exportProfile{
+			ID:          user.ID,
+			Email:       user.Email,
+			CreatedAt:   user.CreatedAt,
+			HasPassword: user.Password != "",
+		},
+		Subscriptions: []*models.Subscription{},
+		Sessions:      []*exportSession{},
+		AuthProviders: []*exportAuthProvider{},
+	}
+
+	rows, err := s.subscriptionRepo.GetUserSubscriptions(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		var sub models.Subscription
+		err := row.MapToSubscriptionModel(&sub)
+		if err != nil {
+			return nil, err
+		}
+
+		data.Subscriptions = append(data.Subscriptions, &sub)
+	}
+
+	data.Categories, err = s.categoryRepo.GetAllCategories(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Tags, err = s.tagRepo.GetAllTags(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.sessionRepo.GetSessionsByUserEmail(ctx, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		data.Sessions = append(data.Sessions, &exportSession{
+			ID:        session.ID,
+			IsRevoked: session.IsRevoked,
+			CreatedAt: session.CreatedAt,
+			ExpiresAt: session.ExpiresAt,
+		})
+	}
+
+	authProviders, err := s.authProviderRepo.GetAuthProvidersByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, authProvider := range authProviders {
+		data.AuthProviders = append(data.AuthProviders, &exportAuthProvider{
+			ID:         authProvider.ID,
+			Provider:   authProvider.Provider,
+			ProviderID: authProvider.ProviderID,
+			CreatedAt:  authProvider.CreatedAt,
+		})
+	}
+
+	data.Reminders, err = s.reminderLogRepo.GetUserReminderLogs(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Prices, err = s.priceRepo.GetUserSubscriptionPrices(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Charges, err = s.chargeRepo.GetCharges(ctx, &repo.GetChargesParams{UserID: user.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	data.PaymentMethods, err = s.paymentMethodRepo.GetPaymentMethods(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Attachments, err = s.attachmentRepo.GetUserAttachments(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Memberships, err = s.memberRepo.GetUserMemberships(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Budgets, err = s.budgetRepo.GetBudgets(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func exportFileName(exportedAt time.Time) string {
+	return "subdub-export-" + exportedAt.Format("2006-01-02") + ".zip"
+}
+
+func writeUserDataZip(w io.Writer, data *userData) error {
+	zw := zip.NewWriter(w)
+
+	file, err := zw.Create("export.json")
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(data)
+	if err != nil {
+		return err
+	}
+
+	for _, csvFile := range data.csvFiles() {
+		file, err := zw.Create(csvFile.name)
+		if err != nil {
+			return err
+		}
+
+		err = csv.NewWriter(file).WriteAll(csvFile.records)
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+type exportCSVFile struct {
+	name string
+	// records start with the header
+	records [][]string
+}
+
+// csvFiles returns a csv file per entity, always in the same order so exports of the same data are identical
+func (data *userData) csvFiles() []exportCSVFile {
+	profile := [][]string{
+		{"id", "email", "has_password", "created_at"},
+		{
+			data.Profile.ID.String(),
+			data.Profile.Email,
+			strconv.FormatBool(data.Profile.HasPassword),
+			formatExportTime(&data.Profile.CreatedAt),
+		},
+	}
+
+	subscriptions := [][]string{{
+		"id", "name", "status", "start_date", "end_date", "duration", "amount", "currency",
+		"billing_anchor_day", "trial_end_date", "post_trial_amount", "category_id", "tag_ids",
+		"payment_method_id", "notes",
+		"cancel_at_period_end", "cancelled_at", "ended_at", "deleted_at", "created_at",
+	}}
+	for _, sub := range data.Subscriptions {
+		var trialEndDate string
+		if sub.TrialEndDate != nil {
+			trialEndDate = time.Time(*sub.TrialEndDate).Format("2006-01-02")
+		}
+
+		tagIDs := make([]string, 0, len(sub.TagIDs))
+		for _, id := range sub.TagIDs {
+			tagIDs = append(tagIDs, id.String())
+		}
+
+		subscriptions = append(subscriptions, []string{
+			sub.ID.String(),
+			sub.Name,
+			sub.Status,
+			time.Time(sub.StartDate).Format("2006-01-02"),
+			time.Time(sub.EndDate).Format("2006-01-02"),
+			sub.Duration.String(),
+			formatExportInt(sub.Amount),
+			formatExportString(sub.Currency),
+			strconv.Itoa(sub.BillingAnchorDay),
+			trialEndDate,
+			formatExportInt(sub.PostTrialAmount),
+			formatExportUUID(sub.CategoryID),
+			strings.Join(tagIDs, ";"),
+			formatExportUUID(sub.PaymentMethodID),
+			formatExportString(sub.Notes),
+			strconv.FormatBool(sub.CancelAtPeriodEnd),
+			formatExportTime(sub.CancelledAt),
+			formatExportTime(sub.EndedAt),
+			formatExportTime(sub.DeletedAt),
+			formatExportTime(&sub.CreatedAt),
+		})
+	}
+
+	categories := [][]string{{"id", "name", "created_at"}}
+	for _, category := range data.Categories {
+		categories = append(categories, []string{
+			category.ID.String(),
+			category.Name,
+			formatExportTime(&category.CreatedAt),
+		})
+	}
+
+	tags := [][]string{{"id", "name", "created_at"}}
+	for _, tag := range data.Tags {
+		tags = append(tags, []string{tag.ID.String(), tag.Name, formatExportTime(&tag.CreatedAt)})
+	}
+
+	sessions := [][]string{{"id", "is_revoked", "created_at", "expires_at"}}
+	for _, session := range data.Sessions {
+		sessions = append(sessions, []string{
+			session.ID.String(),
+			strconv.FormatBool(session.IsRevoked),
+			formatExportTime(&session.CreatedAt),
+			formatExportTime(&session.ExpiresAt),
+		})
+	}
+
+	authProviders := [][]string{{"id", "provider", "provider_id", "created_at"}}
+	for _, authProvider := range data.AuthProviders {
+		authProviders = append(authProviders, []string{
+			authProvider.ID.String(),
+			authProvider.Provider,
+			authProvider.ProviderID,
+			formatExportTime(&authProvider.CreatedAt),
+		})
+	}
+
+	reminders := [][]string{
+		{"id", "subscription_id", "subscription_name", "kind", "days_before", "sent_at"},
+	}
+	for _, reminder := range data.Reminders {
+		reminders = append(reminders, []string{
+			reminder.ID.String(),
+			formatExportUUID(reminder.SubscriptionID),
+			reminder.SubscriptionName,
+			reminder.Kind,
+			strconv.Itoa(reminder.DaysBefore),
+			formatExportTime(&reminder.SentAt),
+		})
+	}
+
+	prices := [][]string{{"id", "subscription_id", "amount", "currency", "effective_from", "created_at"}}
+	for _, price := range data.Prices {
+		prices = append(prices, []string{
+			price.ID.String(),
+			price.SubscriptionID.String(),
+			strconv.FormatInt(price.Amount, 10),
+			price.Currency,
+			time.Time(price.EffectiveFrom).Format("2006-01-02"),
+			formatExportTime(&price.CreatedAt),
+		})
+	}
+
+	charges := [][]string{{
+		"id", "subscription_id", "subscription_name", "period_start", "period_end", "amount", "currency",
+		"created_at",
+	}}
+	for _, charge := range data.Charges {
+		charges = append(charges, []string{
+			charge.ID.String(),
+			formatExportUUID(charge.SubscriptionID),
+			charge.SubscriptionName,
+			time.Time(charge.PeriodStart).Format("2006-01-02"),
+			time.Time(charge.PeriodEnd).Format("2006-01-02"),
+			formatExportInt(charge.Amount),
+			formatExportString(charge.Currency),
+			formatExportTime(&charge.CreatedAt),
+		})
+	}
+
+	paymentMethods := [][]string{
+		{"id", "label", "brand", "last4", "expiry_month", "expiry_year", "created_at", "updated_at"},
+	}
+	for _, method := range data.PaymentMethods {
+		paymentMethods = append(paymentMethods, []string{
+			method.ID.String(),
+			method.Label,
+			method.Brand,
+			method.Last4,
+			strconv.Itoa(method.ExpiryMonth),
+			strconv.Itoa(method.ExpiryYear),
+			formatExportTime(&method.CreatedAt),
+			formatExportTime(&method.UpdatedAt),
+		})
+	}
+
+	attachments := [][]string{{"id", "subscription_id", "file_name", "content_type", "size", "created_at"}}
+	for _, attachment := range data.Attachments {
+		attachments = append(attachments, []string{
+			attachment.ID.String(),
+			formatExportUUID(attachment.SubscriptionID),
+			attachment.FileName,
+			attachment.ContentType,
+			strconv.FormatInt(attachment.Size, 10),
+			formatExportTime(&attachment.CreatedAt),
+		})
+	}
+
+	memberships := [][]string{{
+		"id", "subscription_id", "user_id", "email", "status", "share_percent", "share_amount",
+		"invited_at", "responded_at",
+	}}
+	for _, member := range data.Memberships {
+		var sharePercent string
+		if member.SharePercent != nil {
+			sharePercent = strconv.Itoa(*member.SharePercent)
+		}
+
+		memberships = append(memberships, []string{
+			member.ID.String(),
+			member.SubscriptionID.String(),
+			member.UserID.String(),
+			member.Email,
+			member.Status,
+			sharePercent,
+			formatExportInt(member.ShareAmount),
+			formatExportTime(&member.InvitedAt),
+			formatExportTime(member.RespondedAt),
+		})
+	}
+
+	budgets := [][]string{{"id", "duration", "amount", "currency", "created_at", "updated_at"}}
+	for _, budget := range data.Budgets {
+		var duration string
+		if budget.Duration != nil {
+			duration = budget.Duration.String()
+		}
+
+		budgets = append(budgets, []string{
+			budget.ID.String(),
+			duration,
+			strconv.FormatInt(budget.Amount, 10),
+			budget.Currency,
+			formatExportTime(&budget.CreatedAt),
+			formatExportTime(&budget.UpdatedAt),
+		})
+	}
+
+	return []exportCSVFile{
+		{"profile.csv", profile},
+		{"subscriptions.csv", subscriptions},
+		{"prices.csv", prices},
+		{"charges.csv", charges},
+		{"categories.csv", categories},
+		{"tags.csv", tags},
+		{"payment_methods.csv", paymentMethods},
+		{"attachments.csv", attachments},
+		{"memberships.csv", memberships},
+		{"budgets.csv", budgets},
+		{"sessions.csv", sessions},
+		{"auth_providers.csv", authProviders},
+		{"reminders.csv", reminders},
+	}
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatExportInt(i *int64) string {
+	if i == nil {
+		return ""
+	}
+
+	return strconv.FormatInt(*i, 10)
+}
+
+func formatExportUUID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+
+	return id.String()
+}
+
+func formatExportString(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,462 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/config"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/mailer"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// syncRunner runs background jobs before returning so their result can be checked
+type syncRunner struct {
+	runs int
+}
+
+func (r *syncRunner) Run(fn func()) {
+	r.runs++
+	fn()
+}
+
+// fakeMailer records sent emails
+type fakeMailer struct {
+	sent []*mailer.SendRequest
+}
+
+func (m *fakeMailer) Send(req *mailer.SendRequest) error {
+	m.sent = append(m.sent, req)
+	return nil
+}
+
+func (m *fakeMailer) SendWithRetry(req *mailer.SendRequest, _ int) error {
+	return m.Send(req)
+}
+
+type exportMocks struct {
+	user          *mocks.MockUserRepo
+	subscription  *mocks.MockSubscriptionRepo
+	category      *mocks.MockCategoryRepo
+	tag           *mocks.MockTagRepo
+	session       *mocks.MockSessionRepo
+	authProvider  *mocks.MockAuthProviderRepo
+	reminderLog   *mocks.MockReminderLogRepo
+	price         *mocks.MockSubscriptionPriceRepo
+	charge        *mocks.MockChargeRepo
+	budget        *mocks.MockBudgetRepo
+	member        *mocks.MockSubscriptionMemberRepo
+	attachment    *mocks.MockAttachmentRepo
+	paymentMethod *mocks.MockPaymentMethodRepo
+}
+
+func newExportMocks(ctrl *gomock.Controller) (*exportMocks, *repo.Repo) {
+	m := &exportMocks{
+		user:          mocks.NewMockUserRepo(ctrl),
+		subscription:  mocks.NewMockSubscriptionRepo(ctrl),
+		category:      mocks.NewMockCategoryRepo(ctrl),
+		tag:           mocks.NewMockTagRepo(ctrl),
+		session:       mocks.NewMockSessionRepo(ctrl),
+		authProvider:  mocks.NewMockAuthProviderRepo(ctrl),
+		reminderLog:   mocks.NewMockReminderLogRepo(ctrl),
+		price:         mocks.NewMockSubscriptionPriceRepo(ctrl),
+		charge:        mocks.NewMockChargeRepo(ctrl),
+		budget:        mocks.NewMockBudgetRepo(ctrl),
+		member:        mocks.NewMockSubscriptionMemberRepo(ctrl),
+		attachment:    mocks.NewMockAttachmentRepo(ctrl),
+		paymentMethod: mocks.NewMockPaymentMethodRepo(ctrl),
+	}
+
+	return m, &repo.Repo{
+		User:          m.user,
+		Subscription:  m.subscription,
+		Category:      m.category,
+		Tag:           m.tag,
+		Session:       m.session,
+		AuthProvider:  m.authProvider,
+		ReminderLog:   m.reminderLog,
+		Price:         m.price,
+		Charge:        m.charge,
+		Budget:        m.budget,
+		Member:        m.member,
+		Attachment:    m.attachment,
+		PaymentMethod: m.paymentMethod,
+	}
+}
+
+func (m *exportMocks) expectUserData(user *models.User, sub *repo.SubscriptionRow) {
+	m.subscription.EXPECT().
+		GetUserSubscriptions(gomock.Any(), user.ID).
+		Times(1).
+		Return([]*repo.SubscriptionRow{sub}, nil)
+	m.category.EXPECT().
+		GetAllCategories(gomock.Any(), user.ID).
+		Times(1).
+		Return([]*models.Category{{ID: uuid.New(), UserID: user.ID, Name: "streaming"}}, nil)
+	m.tag.EXPECT().GetAllTags(gomock.Any(), user.ID).Times(1).Return([]*models.Tag{}, nil)
+	m.session.EXPECT().
+		GetSessionsByUserEmail(gomock.Any(), user.Email).
+		Times(1).
+		Return([]*models.Session{{
+			ID:           uuid.New(),
+			UserEmail:    user.Email,
+			RefreshToken: "secret-refresh-token",
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}}, nil)
+	m.authProvider.EXPECT().
+		GetAuthProvidersByUserID(gomock.Any(), user.ID).
+		Times(1).
+		Return([]*models.AuthProvider{{ID: uuid.New(), Provider: "google", ProviderID: "1234"}}, nil)
+	m.reminderLog.EXPECT().
+		GetUserReminderLogs(gomock.Any(), user.ID).
+		Times(1).
+		Return([]*models.ReminderLog{{
+			ID:               uuid.New(),
+			UserID:           user.ID,
+			SubscriptionID:   &sub.ID,
+			SubscriptionName: sub.Name,
+			Kind:             models.ReminderKindRenewal,
+			DaysBefore:       3,
+			SentAt:           time.Now(),
+		}}, nil)
+	m.price.EXPECT().
+		GetUserSubscriptionPrices(gomock.Any(), user.ID).
+		Times(1).
+		Return([]*models.SubscriptionPrice{{
+			ID:             uuid.New(),
+			SubscriptionID: sub.ID,
+			Amount:         1599,
+			Currency:       "USD",
+			EffectiveFrom:  models.SubscriptionTime(sub.StartDate),
+		}}, nil)
+	amount, currency := int64(1599), "USD"
+	m.charge.EXPECT().
+		GetCharges(gomock.Any(), &repo.GetChargesParams{UserID: user.ID}).
+		Times(1).
+		Return([]*models.Charge{{
+			ID:               uuid.New(),
+			UserID:           user.ID,
+			SubscriptionID:   &sub.ID,
+			SubscriptionName: sub.Name,
+			PeriodStart:      models.SubscriptionTime(sub.StartDate),
+			PeriodEnd:        models.SubscriptionTime(sub.EndDate),
+			Amount:           &amount,
+			Currency:         &currency,
+		}}, nil)
+	m.paymentMethod.EXPECT().
+		GetPaymentMethods(gomock.Any(), user.ID).
+		Times(1).
+		Return([]*models.PaymentMethod{{
+			ID:          uuid.New(),
+			UserID:      user.ID,
+			Label:       "Personal card",
+			Brand:       "visa",
+			Last4:       "4242",
+			ExpiryMonth: 12,
+			ExpiryYear:  2030,
+		}}, nil)
+	m.attachment.EXPECT().
+		GetUserAttachments(gomock.Any(), user.ID).
+		Times(1).
+		Return([]*models.Attachment{{
+			ID:             uuid.New(),
+			UserID:         user.ID,
+			SubscriptionID: &sub.ID,
+			FileName:       "invoice.pdf",
+			ContentType:    "application/pdf",
+			StorageKey:     "secret-storage-key",
+			Size:           2048,
+		}}, nil)
+	m.member.EXPECT().
+		GetUserMemberships(gomock.Any(), user.ID).
+		Times(1).
+		Return([]*models.SubscriptionMember{{
+			ID:             uuid.New(),
+			SubscriptionID: sub.ID,
+			UserID:         uuid.New(),
+			Email:          "friend@example.com",
+			Status:         models.MemberStatusPending,
+		}}, nil)
+	m.budget.EXPECT().GetBudgets(gomock.Any(), user.ID).Times(1).Return([]*models.Budget{}, nil)
+}
+
+func readZip(t *testing.T, data []byte) map[string]string {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+
+		files[f.Name] = string(content)
+	}
+
+	return files
+}
+
+func TestExportUserData(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Email: "user@example.com", Password: "hashed"}
+	sub := randomSubscriptionRow(user.ID)
+	paymentMethodID := uuid.New()
+	sub.PaymentMethodID = &paymentMethodID
+
+	testCases := []struct {
+		buildStubs    func(*exportMocks)
+		checkResponse func(*testing.T, *service.UserDataExport, error)
+		name          string
+		syncLimit     int
+	}{
+		{
+			name:      "Small export is streamed",
+			syncLimit: 10,
+			buildStubs: func(m *exportMocks) {
+				m.user.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+				m.subscription.EXPECT().
+					CountUserSubscriptions(gomock.Any(), user.ID).
+					Times(1).
+					Return(1, nil)
+				m.expectUserData(user, sub)
+			},
+			checkResponse: func(t *testing.T, export *service.UserDataExport, err error) {
+				require.NoError(t, err)
+
+				var buf bytes.Buffer
+				require.NoError(t, export.WriteZip(&buf))
+
+				// the layout of the zip is the same on every run
+				var again bytes.Buffer
+				require.NoError(t, export.WriteZip(&again))
+				require.Equal(t, buf.Bytes(), again.Bytes())
+
+				zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+				require.NoError(t, err)
+				names := make([]string, 0, len(zr.File))
+				for _, f := range zr.File {
+					names = append(names, f.Name)
+				}
+				require.Equal(t, []string{
+					"export.json", "profile.csv", "subscriptions.csv", "prices.csv", "charges.csv",
+					"categories.csv", "tags.csv", "payment_methods.csv", "attachments.csv",
+					"memberships.csv", "budgets.csv", "sessions.csv", "auth_providers.csv",
+					"reminders.csv",
+				}, names)
+
+				files := readZip(t, buf.Bytes())
+				require.Len(t, files, 14)
+				require.Contains(t, files["export.json"], `"email": "user@example.com"`)
+				require.Contains(t, files["export.json"], `"has_password": true`)
+				require.NotContains(t, files["export.json"], "hashed")
+				require.NotContains(t, files["export.json"], "secret-refresh-token")
+				require.NotContains(t, files["sessions.csv"], "secret-refresh-token")
+				require.Contains(t, files["subscriptions.csv"], sub.ID.String()+",Netflix Premium,")
+				require.Contains(t, files["categories.csv"], "streaming")
+				require.Contains(t, files["auth_providers.csv"], "google,1234")
+				require.Contains(t, files["reminders.csv"], "Netflix Premium,renewal,3,")
+				require.Equal(t, "id,name,created_at\n", files["tags.csv"])
+
+				require.Contains(t, files["subscriptions.csv"], ",payment_method_id,")
+				require.Contains(t, files["subscriptions.csv"], ","+paymentMethodID.String()+",")
+				require.Contains(t, files["prices.csv"], sub.ID.String()+",1599,USD,2025-01-15,")
+				require.Contains(t, files["charges.csv"], ",Netflix Premium,2025-01-15,2025-02-15,1599,USD,")
+				require.Contains(t, files["payment_methods.csv"], ",Personal card,visa,4242,12,2030,")
+				require.Contains(t, files["attachments.csv"], ",invoice.pdf,application/pdf,2048,")
+				require.NotContains(t, files["export.json"], "secret-storage-key")
+				require.Contains(t, files["memberships.csv"], ",friend@example.com,pending,")
+				require.Equal(t,
+					"id,duration,amount,currency,created_at,updated_at\n",
+					files["budgets.csv"],
+				)
+				for _, key := range []string{
+					`"payment_methods"`, `"attachments"`, `"memberships"`, `"budgets": []`,
+					`"charges"`, `"prices"`, `"payment_method_id": "` + paymentMethodID.String(),
+				} {
+					require.Contains(t, files["export.json"], key)
+				}
+			},
+		},
+		{
+			name:      "Large export has to be queued",
+			syncLimit: 0,
+			buildStubs: func(m *exportMocks) {
+				m.user.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+				m.subscription.EXPECT().
+					CountUserSubscriptions(gomock.Any(), user.ID).
+					Times(1).
+					Return(1, nil)
+				m.subscription.EXPECT().GetUserSubscriptions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, export *service.UserDataExport, err error) {
+				require.ErrorIs(t, err, apperror.ErrExportTooLarge)
+				require.Nil(t, export)
+			},
+		},
+		{
+			name:      "User not found",
+			syncLimit: 10,
+			buildStubs: func(m *exportMocks) {
+				m.user.EXPECT().
+					GetUserByID(gomock.Any(), user.ID).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, export *service.UserDataExport, err error) {
+				require.ErrorIs(t, err, apperror.ErrUserNotFound)
+				require.Nil(t, export)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m, r := newExportMocks(ctrl)
+			tc.buildStubs(m)
+
+			runner := &syncRunner{}
+			s := service.NewExportService(r, &fakeMailer{}, runner, &config.ExportConfig{
+				BaseURL:     "https://subdub.test",
+				Dir:         filepath.Join(t.TempDir(), "exports"),
+				SyncLimit:   tc.syncLimit,
+				ExpiryHours: 24,
+			})
+
+			export, err := s.ExportUserData(context.Background(), user.ID)
+			tc.checkResponse(t, export, err)
+			require.Zero(t, runner.runs)
+		})
+	}
+}
+
+func TestQueueExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m, r := newExportMocks(ctrl)
+
+	user := &models.User{ID: uuid.New(), Email: "user@example.com"}
+	m.user.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(3).Return(user, nil)
+	m.expectUserData(user, randomSubscriptionRow(user.ID))
+
+	runner := &syncRunner{}
+	fm := &fakeMailer{}
+	dir := filepath.Join(t.TempDir(), "exports")
+	s := service.NewExportService(r, fm, runner, &config.ExportConfig{
+		BaseURL:     "https://subdub.test",
+		Dir:         dir,
+		SyncLimit:   10,
+		ExpiryHours: 24,
+	})
+
+	err := s.QueueExport(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, runner.runs)
+
+	require.Len(t, fm.sent, 1)
+	require.Equal(t, []string{user.Email}, fm.sent[0].To)
+	require.Equal(t, mailer.ExportReadyTemplate, fm.sent[0].Template)
+
+	data := fm.sent[0].Data.(mailer.ExportReadyData)
+	require.Equal(t, 24, data.ExpiresInHours)
+	require.True(t, strings.HasPrefix(data.Link, "https://subdub.test/api/v1/export/"))
+
+	// the zip is named by the token hash, never by the token, next to the marker of the user
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	token := strings.TrimSuffix(strings.TrimPrefix(data.Link, "https://subdub.test/api/v1/export/"), ".zip")
+	for _, entry := range entries {
+		require.NotContains(t, entry.Name(), token)
+	}
+
+	// the export is not generated again until it expired
+	err = s.QueueExport(context.Background(), user.ID)
+	require.ErrorIs(t, err, apperror.ErrExportPending)
+	require.Equal(t, 1, runner.runs)
+	require.Len(t, fm.sent, 1)
+
+	old := time.Now().Add(-25 * time.Hour)
+	for _, entry := range entries {
+		require.NoError(t, os.Chtimes(filepath.Join(dir, entry.Name()), old, old))
+	}
+
+	m.expectUserData(user, randomSubscriptionRow(user.ID))
+
+	err = s.QueueExport(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, runner.runs)
+	require.Len(t, fm.sent, 2)
+
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
+
+func TestOpenExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m, r := newExportMocks(ctrl)
+
+	user := &models.User{ID: uuid.New(), Email: "user@example.com"}
+	m.user.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+	m.expectUserData(user, randomSubscriptionRow(user.ID))
+
+	fm := &fakeMailer{}
+	dir := t.TempDir()
+	s := service.NewExportService(r, fm, &syncRunner{}, &config.ExportConfig{
+		BaseURL:     "https://subdub.test",
+		Dir:         dir,
+		SyncLimit:   0,
+		ExpiryHours: 24,
+	})
+
+	err := s.QueueExport(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, fm.sent, 1)
+
+	link := fm.sent[0].Data.(mailer.ExportReadyData).Link
+	token := strings.TrimSuffix(link[strings.LastIndex(link, "/")+1:], ".zip")
+
+	file, err := s.OpenExport(token)
+	require.NoError(t, err)
+
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.Equal(t, file.Size, int64(len(content)))
+	require.Contains(t, readZip(t, content), "export.json")
+
+	_, err = s.OpenExport("unknown-token")
+	require.ErrorIs(t, err, apperror.ErrExportNotFound)
+
+	// an expired export is deleted when it is opened
+	zips, err := filepath.Glob(filepath.Join(dir, "*.zip"))
+	require.NoError(t, err)
+	require.Len(t, zips, 1)
+
+	old := time.Now().Add(-25 * time.Hour)
+	require.NoError(t, os.Chtimes(zips[0], old, old))
+
+	_, err = s.OpenExport(token)
+	require.ErrorIs(t, err, apperror.ErrExportNotFound)
+
+	zips, err = filepath.Glob(filepath.Join(dir, "*.zip"))
+	require.NoError(t, err)
+	require.Empty(t, zips)
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/money"
+	appvalidator "github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+// MaxImportRows is the number of subscriptions a single csv file can import
+const MaxImportRows = 1000
+
+// columns of an import file, header names are matched case-insensitively
+// and spaces or dashes are read as underscores, so "Start Date" is start_date
+const (
+	importColumnName      = "name"
+	importColumnStartDate = "start_date"
+	importColumnDuration  = "duration"
+	importColumnAmount    = "amount"
+	importColumnCurrency  = "currency"
+	importColumnNotes     = "notes"
+)
+
+var requiredImportColumns = []string{importColumnName, importColumnStartDate, importColumnDuration}
+
+// validator reports fields by their lower-cased struct field name
+var importValidateFields = map[string]string{
+	"startdate": importColumnStartDate,
+}
+
+type ImportService interface {
+	ImportSubscriptions(
+		ctx context.Context,
+		req *ImportSubscriptionsRequest,
+	) (*ImportSubscriptionsResponse, error)
+}
+
+type importService struct {
+	subscriptionService SubscriptionService
+	transaction         repo.TransactionManager
+	validator           appvalidator.Validator
+}
+
+func NewImportService(
+	subscriptionService SubscriptionService,
+	transaction repo.TransactionManager,
+	validator appvalidator.Validator,
+) *importService {
+	return &importService{subscriptionService, transaction, validator}
+}
+
+type ImportSubscriptionsRequest struct {
+	File   io.Reader
+	UserID uuid.UUID
+	// DryRun only validates the file, nothing is imported
+	DryRun bool
+}
+
+type ImportSubscriptionsResponse struct {
+	Rows     []*ImportRowResult `json:"rows"`
+	Total    int                `json:"total"    example:"3"`
+	Valid    int                `json:"valid"    example:"2"`
+	Invalid  int                `json:"invalid"  example:"1"`
+	Imported int                `json:"imported" example:"2"`
+	DryRun   bool               `json:"dry_run"`
+}
+
+type ImportRowResult struct {
+	// ID is set once the row is imported
+	ID *uuid.UUID `json:"id,omitempty"`
+	// req is the subscription created for a valid row
+	req    *CreateSubscriptionRequest
+	Name   string                   `json:"name"             example:"Netflix"`
+	Errors []apperror.ValidateError `json:"errors,omitempty"`
+	// Line is the line of the row in the file, the header is line 1
+	Line int `json:"line" example:"2"`
+}
+
+// ImportSubscriptions creates a subscription for every valid row of a csv file.
+// Name, start_date (YYYY-MM-DD) and duration columns are required, amount and currency are optional
+// and every other column is kept in the notes of the subscription.
+// Valid rows are inserted in a single transaction, invalid rows are only reported
+func (s *importService) ImportSubscriptions(
+	ctx context.Context,
+	req *ImportSubscriptionsRequest,
+) (*ImportSubscriptionsResponse, error) {
+	rows, err := s.readImportFile(req.File, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ImportSubscriptionsResponse{
+		Rows:   rows,
+		Total:  len(rows),
+		DryRun: req.DryRun,
+	}
+	for _, row := range rows {
+		if len(row.Errors) > 0 {
+			res.Invalid++
+		} else {
+			res.Valid++
+		}
+	}
+
+	if req.DryRun || res.Valid == 0 {
+		return res, nil
+	}
+
+	err = s.transaction.WithTx(ctx, func(txContext context.Context) error {
+		for _, row := range rows {
+			if len(row.Errors) > 0 {
+				continue
+			}
+
+			sub, err := s.subscriptionService.CreateSubscription(txContext, row.req)
+			if err != nil {
+				return err
+			}
+
+			row.ID = &sub.ID
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Imported = res.Valid
+
+	return res, nil
+}
+
+func (s *importService) readImportFile(file io.Reader, userID uuid.UUID) ([]*ImportRowResult, error) {
+	reader := csv.NewReader(file)
+	// rows may omit empty trailing columns
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, apperror.ErrInvalidImportHeader
+		}
+		return nil, invalidImportFileError(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// spreadsheet applications often start utf-8 files with a byte order mark
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		header[i] = strings.TrimSpace(name)
+
+		key := strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(header[i]))
+		if _, ok := columns[key]; !ok {
+			columns[key] = i
+		}
+	}
+
+	for _, column := range requiredImportColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, apperror.ErrInvalidImportHeader
+		}
+	}
+
+	rows := []*ImportRowResult{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, invalidImportFileError(err)
+		}
+
+		if isBlankRecord(record) {
+			continue
+		}
+
+		if len(rows) == MaxImportRows {
+			return nil, apperror.ErrImportTooLarge
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, s.parseImportRow(header, columns, record, line, userID))
+	}
+
+	return rows, nil
+}
+
+// parseImportRow maps a record to a create subscription request and validates it
+func (s *importService) parseImportRow(
+	header []string,
+	columns map[string]int,
+	record []string,
+	line int,
+	userID uuid.UUID,
+) *ImportRowResult {
+	value := func(column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	req := &CreateSubscriptionRequest{
+		Name:   value(importColumnName),
+		UserID: userID,
+	}
+	row := &ImportRowResult{Line: line, Name: req.Name, req: req}
+
+	addError := func(field, msg string) {
+		row.Errors = append(row.Errors, apperror.ValidateError{Field: field, Msg: msg})
+	}
+
+	if startDate := value(importColumnStartDate); startDate == "" {
+		addError(importColumnStartDate, "this field is required")
+	} else if t, err := time.Parse("2006-01-02", startDate); err != nil {
+		addError(importColumnStartDate, "should be formatted as YYYY-MM-DD")
+	} else {
+		req.StartDate = models.SubscriptionTime(t)
+	}
+
+	if duration := value(importColumnDuration); duration != "" {
+		d, err := enums.ParseString2Duration(duration)
+		if err != nil {
+			addError(importColumnDuration, "invalid duration")
+		} else {
+			req.Duration = d
+		}
+	}
+
+	req.Currency = strings.ToUpper(value(importColumnCurrency))
+	if amount := value(importColumnAmount); amount != "" {
+		a, err := money.Parse(amount, req.Currency)
+		if err != nil {
+			addError(importColumnAmount, "should be a non negative number like 9.99")
+		} else {
+			req.Amount = &a
+		}
+	}
+
+	if notes := importNotes(header, columns, record); notes != "" {
+		req.Notes = &notes
+	}
+
+	err := s.validator.Validate(req)
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		for _, e := range apperror.ToValidateErrors(validationErrors) {
+			if field, ok := importValidateFields[e.Field]; ok {
+				e.Field = field
+			}
+
+			// a value which could not be parsed is already reported
+			if !hasFieldError(row.Errors, e.Field) {
+				row.Errors = append(row.Errors, e)
+			}
+		}
+	}
+
+	return row
+}
+
+// importNotes joins the notes column and every unknown column as "Header: value" lines
+func importNotes(header []string, columns map[string]int, record []string) string {
+	var lines []string
+	if i, ok := columns[importColumnNotes]; ok && i < len(record) && strings.TrimSpace(record[i]) != "" {
+		lines = append(lines, strings.TrimSpace(record[i]))
+	}
+
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+		if i >= len(header) || value == "" || isKnownImportColumn(columns, i) {
+			continue
+		}
+
+		lines = append(lines, header[i]+": "+value)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func isKnownImportColumn(columns map[string]int, i int) bool {
+	for _, column := range []string{
+		importColumnName,
+		importColumnStartDate,
+		importColumnDuration,
+		importColumnAmount,
+		importColumnCurrency,
+		importColumnNotes,
+	} {
+		if idx, ok := columns[column]; ok && idx == i {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasFieldError(errs []apperror.ValidateError, field string) bool {
+	for _, err := range errs {
+		if err.Field == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func invalidImportFileError(err error) error {
+	var parseError *csv.ParseError
+	if errors.As(err, &parseError) {
+		return apperror.NewAppError(
+			http.StatusBadRequest,
+			fmt.Sprintf("invalid csv file at line %d", parseError.Line),
+		)
+	}
+
+	return err
+}
@@ -0,0 +1,229 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeTransaction runs f directly and records whether a transaction was used
+type fakeTransaction struct {
+	calls int
+}
+
+func (t *fakeTransaction) WithTx(ctx context.Context, f func(context.Context) error) error {
+	t.calls++
+	return f(ctx)
+}
+
+func TestImportSubscriptions(t *testing.T) {
+	userID := uuid.New()
+
+	file := strings.Join([]string{
+		"\ufeffName,Start Date,Duration,Amount,Currency,Account",
+		"Netflix,2025-01-15,monthly,15.99,usd,family@example.com",
+		"Gym,2025-02-01,quarterly,,,",
+		"",
+		"Music,15/01/2025,monthly,,,",
+		",2025-01-15,weekly,,,",
+		"Cloud,2025-01-15,fortnightly,2.99,,",
+	}, "\n")
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockSubscriptionService)
+		checkResponse func(*testing.T, *service.ImportSubscriptionsResponse, error, *fakeTransaction)
+		name          string
+		file          string
+		dryRun        bool
+	}{
+		{
+			name:   "Dry run reports every row",
+			file:   file,
+			dryRun: true,
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(
+				t *testing.T,
+				res *service.ImportSubscriptionsResponse,
+				err error,
+				tx *fakeTransaction,
+			) {
+				require.NoError(t, err)
+				require.Zero(t, tx.calls)
+				require.True(t, res.DryRun)
+				require.Equal(t, 5, res.Total)
+				require.Equal(t, 2, res.Valid)
+				require.Equal(t, 3, res.Invalid)
+				require.Zero(t, res.Imported)
+
+				require.Empty(t, res.Rows[0].Errors)
+				require.Empty(t, res.Rows[1].Errors)
+
+				// the blank line is skipped but still counted in line numbers
+				require.Equal(t, 5, res.Rows[2].Line)
+				require.Equal(t, []apperror.ValidateError{
+					{Field: "start_date", Msg: "should be formatted as YYYY-MM-DD"},
+				}, res.Rows[2].Errors)
+
+				require.Equal(t, []apperror.ValidateError{
+					{Field: "name", Msg: "this field is required"},
+				}, res.Rows[3].Errors)
+
+				require.ElementsMatch(t, []apperror.ValidateError{
+					{Field: "duration", Msg: "invalid duration"},
+					{Field: "currency", Msg: "this field is required when amount posttrialamount is present"},
+				}, res.Rows[4].Errors)
+			},
+		},
+		{
+			name: "Valid rows are imported in a transaction",
+			file: file,
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().
+					CreateSubscription(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(
+						_ context.Context,
+						req *service.CreateSubscriptionRequest,
+					) (*models.Subscription, error) {
+						require.Equal(t, userID, req.UserID)
+
+						switch req.Name {
+						case "Netflix":
+							require.Equal(
+								t,
+								models.SubscriptionTime(time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)),
+								req.StartDate,
+							)
+							require.Equal(t, enums.Monthly, req.Duration)
+							require.Equal(t, int64(1599), *req.Amount)
+							require.Equal(t, "USD", req.Currency)
+							require.Equal(t, "Account: family@example.com", *req.Notes)
+						case "Gym":
+							require.Nil(t, req.Amount)
+							require.Nil(t, req.Notes)
+						default:
+							t.Fatalf("unexpected subscription %s", req.Name)
+						}
+
+						return &models.Subscription{ID: uuid.New(), Name: req.Name}, nil
+					})
+			},
+			checkResponse: func(
+				t *testing.T,
+				res *service.ImportSubscriptionsResponse,
+				err error,
+				tx *fakeTransaction,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, 1, tx.calls)
+				require.Equal(t, 2, res.Imported)
+				require.NotNil(t, res.Rows[0].ID)
+				require.NotNil(t, res.Rows[1].ID)
+				require.Nil(t, res.Rows[2].ID)
+			},
+		},
+		{
+			name: "Failed insert aborts the import",
+			file: file,
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().
+					CreateSubscription(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(
+				t *testing.T,
+				res *service.ImportSubscriptionsResponse,
+				err error,
+				tx *fakeTransaction,
+			) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "Missing required column",
+			file: "name,duration\nNetflix,monthly\n",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(
+				t *testing.T,
+				res *service.ImportSubscriptionsResponse,
+				err error,
+				tx *fakeTransaction,
+			) {
+				require.ErrorIs(t, err, apperror.ErrInvalidImportHeader)
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "Too many rows",
+			file: "name,start_date,duration\n" +
+				strings.Repeat("Netflix,2025-01-15,monthly\n", service.MaxImportRows+1),
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(
+				t *testing.T,
+				res *service.ImportSubscriptionsResponse,
+				err error,
+				tx *fakeTransaction,
+			) {
+				require.ErrorIs(t, err, apperror.ErrImportTooLarge)
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "Malformed csv",
+			file: "name,start_date,duration\n\"Netflix,2025-01-15,monthly\n",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(
+				t *testing.T,
+				res *service.ImportSubscriptionsResponse,
+				err error,
+				tx *fakeTransaction,
+			) {
+				var appError *apperror.AppError
+				require.ErrorAs(t, err, &appError)
+				require.Equal(t, "invalid csv file at line 2", appError.Msg)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			subscriptionService := mocks.NewMockSubscriptionService(ctrl)
+			tc.buildStubs(subscriptionService)
+			tx := &fakeTransaction{}
+
+			s := service.NewImportService(subscriptionService, tx, validator.NewAppValidator())
+			res, err := s.ImportSubscriptions(context.Background(), &service.ImportSubscriptionsRequest{
+				File:   strings.NewReader(tc.file),
+				UserID: userID,
+				DryRun: tc.dryRun,
+			})
+
+			tc.checkResponse(t, res, err, tx)
+		})
+	}
+}
@@ -0,0 +1,468 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+type MemberService interface {
+	InviteMember(ctx context.Context, req *InviteMemberRequest) (*models.SubscriptionMember, error)
+	GetMembers(ctx context.Context, subscriptionID, userID uuid.UUID) ([]*models.SubscriptionMember, error)
+	RemoveMember(ctx context.Context, subscriptionID, memberID, userID uuid.UUID) error
+	GetInvitations(ctx context.Context, userID uuid.UUID) ([]*models.SubscriptionInvitation, error)
+	RespondInvitation(
+		ctx context.Context,
+		id, userID uuid.UUID,
+		accept bool,
+	) (*models.SubscriptionMember, error)
+	GetBalances(ctx context.Context, req *GetBalancesRequest) (*GetBalancesResponse, error)
+}
+
+type memberService struct {
+	subscriptionRepo repo.SubscriptionRepo
+	memberRepo       repo.SubscriptionMemberRepo
+	userRepo         repo.UserRepo
+	chargeRepo       repo.ChargeRepo
+}
+
+func NewMemberService(
+	subscriptionRepo repo.SubscriptionRepo,
+	memberRepo repo.SubscriptionMemberRepo,
+	userRepo repo.UserRepo,
+	chargeRepo repo.ChargeRepo,
+) *memberService {
+	return &memberService{subscriptionRepo, memberRepo, userRepo, chargeRepo}
+}
+
+// InviteMemberRequest shares a subscription with a registered user,
+// exactly one of SharePercent and ShareAmount must be set
+type InviteMemberRequest struct {
+	SharePercent *int `json:"share_percent" validate:"omitempty,gte=1,lte=100"  example:"25"`
+	// ShareAmount is a fixed amount in minor units of the currency of the subscription
+	ShareAmount    *int64    `json:"share_amount"  validate:"omitempty,gte=0"        example:"499"`
+	Email          string    `json:"email"         validate:"required,email"         example:"jane@example.com"`
+	SubscriptionID uuid.UUID `json:"-"             validate:"-"`
+	UserID         uuid.UUID `json:"-"             validate:"-"`
+}
+
+// InviteMember invites a user to share a subscription of req.UserID,
+// the member owes its share of the billing periods once it accepts
+func (s *memberService) InviteMember(
+	ctx context.Context,
+	req *InviteMemberRequest,
+) (*models.SubscriptionMember, error) {
+	if (req.SharePercent == nil) == (req.ShareAmount == nil) {
+		return nil, apperror.ErrInvalidShare
+	}
+
+	sub, err := s.getSubscription(ctx, req.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sub.UserID != req.UserID {
+		return nil, apperror.ErrSubscriptionNotFound
+	}
+
+	invitee, err := s.userRepo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrInviteeNotFound
+		}
+		return nil, err
+	}
+
+	if invitee.ID == req.UserID {
+		return nil, apperror.ErrInviteOwner
+	}
+
+	members, err := s.memberRepo.GetMembers(ctx, sub.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		if member.UserID == invitee.ID && member.Status != models.MemberStatusDeclined {
+			return nil, apperror.ErrAlreadyMember
+		}
+	}
+
+	// a declined member invited again is replaced by the new invitation
+	members = append(members, &models.SubscriptionMember{
+		UserID:       invitee.ID,
+		SharePercent: req.SharePercent,
+		ShareAmount:  req.ShareAmount,
+		Status:       models.MemberStatusPending,
+	})
+	if sharesExceedPrice(members, subscriptionPrice(sub)) {
+		return nil, apperror.ErrShareExceeded
+	}
+
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.memberRepo.CreateMember(ctx, &repo.CreateMemberParams{
+		ID:             id,
+		SubscriptionID: sub.ID,
+		UserID:         invitee.ID,
+		SharePercent:   req.SharePercent,
+		ShareAmount:    req.ShareAmount,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrAlreadyMember
+		}
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// GetMembers returns the members of a subscription to its owner and to its accepted members
+func (s *memberService) GetMembers(
+	ctx context.Context,
+	subscriptionID, userID uuid.UUID,
+) ([]*models.SubscriptionMember, error) {
+	sub, err := s.getSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.memberRepo.GetMembers(ctx, sub.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sub.UserID == userID {
+		return members, nil
+	}
+
+	for _, member := range members {
+		if member.UserID == userID && member.Status == models.MemberStatusAccepted {
+			return members, nil
+		}
+	}
+
+	return nil, apperror.ErrSubscriptionNotFound
+}
+
+// RemoveMember lets the owner remove any member and a member leave the subscription
+func (s *memberService) RemoveMember(
+	ctx context.Context,
+	subscriptionID, memberID, userID uuid.UUID,
+) error {
+	sub, err := s.getSubscription(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	members, err := s.memberRepo.GetMembers(ctx, sub.ID)
+	if err != nil {
+		return err
+	}
+
+	idx := -1
+	for i, member := range members {
+		if member.ID == memberID {
+			idx = i
+			break
+		}
+	}
+
+	if idx == -1 || (sub.UserID != userID && members[idx].UserID != userID) {
+		return apperror.ErrMemberNotFound
+	}
+
+	err = s.memberRepo.DeleteMember(ctx, memberID, sub.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrMemberNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *memberService) GetInvitations(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.SubscriptionInvitation, error) {
+	return s.memberRepo.GetInvitations(ctx, userID)
+}
+
+// RespondInvitation accepts or declines a pending invitation of userID
+func (s *memberService) RespondInvitation(
+	ctx context.Context,
+	id, userID uuid.UUID,
+	accept bool,
+) (*models.SubscriptionMember, error) {
+	status := models.MemberStatusDeclined
+	if accept {
+		status = models.MemberStatusAccepted
+	}
+
+	member, err := s.memberRepo.RespondInvitation(ctx, id, userID, status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// GetBalancesRequest filters the billing periods on their start, both dates are inclusive
+type GetBalancesRequest struct {
+	From   *time.Time
+	To     *time.Time
+	UserID uuid.UUID
+}
+
+// amounts are in minor units of the currency they are grouped by,
+// different currencies are never added together
+type GetBalancesResponse struct {
+	// Periods are the billing periods of shared subscriptions with what each member owes, most recent first
+	Periods []*SharedPeriod `json:"periods"`
+	// Balances are netted per user and currency, a positive amount is owed to the current user
+	// and a negative amount is owed by the current user
+	Balances []*Balance `json:"balances"`
+}
+
+type SharedPeriod struct {
+	Currency         string    `json:"currency"          example:"USD"`
+	PeriodStart      string    `json:"period_start"      example:"2025-05-15"`
+	PeriodEnd        string    `json:"period_end"        example:"2025-06-15"`
+	SubscriptionName string    `json:"subscription_name" example:"Spotify Family"`
+	Debts            []*Debt   `json:"debts"`
+	Amount           int64     `json:"amount"            example:"1999"`
+	SubscriptionID   uuid.UUID `json:"subscription_id"`
+}
+
+// Debt is what a member owes to the owner of the subscription for a period
+type Debt struct {
+	FromEmail  string    `json:"from_email" example:"jane@example.com"`
+	ToEmail    string    `json:"to_email"   example:"john@example.com"`
+	Amount     int64     `json:"amount"     example:"500"`
+	FromUserID uuid.UUID `json:"from_user_id"`
+	ToUserID   uuid.UUID `json:"to_user_id"`
+}
+
+type Balance struct {
+	Email    string    `json:"email"    example:"jane@example.com"`
+	Currency string    `json:"currency" example:"USD"`
+	Amount   int64     `json:"amount"   example:"1500"`
+	UserID   uuid.UUID `json:"user_id"`
+}
+
+// GetBalances computes who owes whom for every booked billing period of the shared subscriptions of the user.
+// A member owes its share of the periods which were not over when it accepted the invitation,
+// the current user only sees the debts it owes or is owed
+func (s *memberService) GetBalances(
+	ctx context.Context,
+	req *GetBalancesRequest,
+) (*GetBalancesResponse, error) {
+	rows, err := s.memberRepo.GetSharedMembers(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &GetBalancesResponse{Periods: []*SharedPeriod{}, Balances: []*Balance{}}
+	balances := make(map[string]*Balance)
+
+	// rows are grouped by subscription
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && rows[end].Member.SubscriptionID == rows[start].Member.SubscriptionID {
+			end++
+		}
+
+		periods, err := s.sharedPeriods(ctx, req, rows[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, period := range periods {
+			for _, debt := range period.Debts {
+				addBalance(balances, res, req.UserID, debt, period.Currency)
+			}
+		}
+
+		res.Periods = append(res.Periods, periods...)
+		start = end
+	}
+
+	sort.SliceStable(res.Periods, func(i, j int) bool {
+		return res.Periods[i].PeriodStart > res.Periods[j].PeriodStart
+	})
+
+	sort.SliceStable(res.Balances, func(i, j int) bool {
+		if res.Balances[i].Email != res.Balances[j].Email {
+			return res.Balances[i].Email < res.Balances[j].Email
+		}
+		return res.Balances[i].Currency < res.Balances[j].Currency
+	})
+
+	return res, nil
+}
+
+// sharedPeriods splits the booked charges of a subscription between the owner and its accepted members
+func (s *memberService) sharedPeriods(
+	ctx context.Context,
+	req *GetBalancesRequest,
+	rows []*repo.SharedMemberRow,
+) ([]*SharedPeriod, error) {
+	owner := rows[0]
+
+	charges, err := s.chargeRepo.GetCharges(ctx, &repo.GetChargesParams{
+		UserID:         owner.OwnerID,
+		SubscriptionID: &owner.Member.SubscriptionID,
+		From:           req.From,
+		To:             req.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	periods := []*SharedPeriod{}
+	for _, charge := range charges {
+		if charge.Amount == nil || charge.Currency == nil {
+			continue
+		}
+
+		period := &SharedPeriod{
+			SubscriptionID:   owner.Member.SubscriptionID,
+			SubscriptionName: owner.SubscriptionName,
+			PeriodStart:      time.Time(charge.PeriodStart).Format(upcomingDateLayout),
+			PeriodEnd:        time.Time(charge.PeriodEnd).Format(upcomingDateLayout),
+			Amount:           *charge.Amount,
+			Currency:         *charge.Currency,
+			Debts:            []*Debt{},
+		}
+
+		for _, row := range rows {
+			member := row.Member
+			if member.UserID != req.UserID && row.OwnerID != req.UserID {
+				continue
+			}
+
+			// periods over before the member joined are paid by the owner alone
+			if member.RespondedAt == nil || !time.Time(charge.PeriodEnd).After(*member.RespondedAt) {
+				continue
+			}
+
+			period.Debts = append(period.Debts, &Debt{
+				FromUserID: member.UserID,
+				FromEmail:  member.Email,
+				ToUserID:   row.OwnerID,
+				ToEmail:    row.OwnerEmail,
+				Amount:     memberShare(member, *charge.Amount),
+			})
+		}
+
+		if len(period.Debts) > 0 {
+			periods = append(periods, period)
+		}
+	}
+
+	return periods, nil
+}
+
+// addBalance nets a debt into the balance of the current user with the other side of the debt
+func addBalance(
+	balances map[string]*Balance,
+	res *GetBalancesResponse,
+	userID uuid.UUID,
+	debt *Debt,
+	currency string,
+) {
+	otherID, email, amount := debt.FromUserID, debt.FromEmail, debt.Amount
+	if debt.FromUserID == userID {
+		otherID, email, amount = debt.ToUserID, debt.ToEmail, -debt.Amount
+	}
+
+	key := otherID.String() + currency
+	balance, ok := balances[key]
+	if !ok {
+		balance = &Balance{UserID: otherID, Email: email, Currency: currency}
+		balances[key] = balance
+		res.Balances = append(res.Balances, balance)
+	}
+
+	balance.Amount += amount
+}
+
+// memberShare is what the member owes for a period charged price,
+// a percent share is rounded to the nearest minor unit and a fixed share never exceeds the price
+func memberShare(member *models.SubscriptionMember, price int64) int64 {
+	if member.SharePercent != nil {
+		return (price*int64(*member.SharePercent) + 50) / 100
+	}
+
+	return min(*member.ShareAmount, price)
+}
+
+// sharesExceedPrice reports whether the shares of the members who did not decline add up to more than the price,
+// only percents are checked when the price is unknown
+func sharesExceedPrice(members []*models.SubscriptionMember, price *int64) bool {
+	var percent, fixed int64
+	for _, member := range members {
+		if member.Status == models.MemberStatusDeclined {
+			continue
+		}
+
+		if member.SharePercent != nil {
+			percent += int64(*member.SharePercent)
+		} else if member.ShareAmount != nil {
+			fixed += *member.ShareAmount
+		}
+	}
+
+	if percent > 100 {
+		return true
+	}
+
+	if price == nil {
+		return false
+	}
+
+	return fixed*100+*price*percent > *price*100
+}
+
+// subscriptionPrice is the price of the next paid period
+func subscriptionPrice(sub *repo.SubscriptionRow) *int64 {
+	if sub.InTrial() && sub.PostTrialAmount != nil {
+		return sub.PostTrialAmount
+	}
+
+	return sub.Amount
+}
+
+// getSubscription returns a subscription which is not in trash,
+// callers check whether the user owns it or is a member of it
+func (s *memberService) getSubscription(ctx context.Context, id uuid.UUID) (*repo.SubscriptionRow, error) {
+	row, err := s.subscriptionRepo.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	if row.DeletedAt != nil {
+		return nil, apperror.ErrSubscriptionNotFound
+	}
+
+	return row, nil
+}
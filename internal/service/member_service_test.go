@@ -0,0 +1,368 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type memberMocks struct {
+	subscription *mocks.MockSubscriptionRepo
+	member       *mocks.MockSubscriptionMemberRepo
+	user         *mocks.MockUserRepo
+	charge       *mocks.MockChargeRepo
+}
+
+func newMemberService(t *testing.T) (service.MemberService, *memberMocks) {
+	ctrl := gomock.NewController(t)
+	m := &memberMocks{
+		subscription: mocks.NewMockSubscriptionRepo(ctrl),
+		member:       mocks.NewMockSubscriptionMemberRepo(ctrl),
+		user:         mocks.NewMockUserRepo(ctrl),
+		charge:       mocks.NewMockChargeRepo(ctrl),
+	}
+
+	return service.NewMemberService(m.subscription, m.member, m.user, m.charge), m
+}
+
+func TestInviteMember(t *testing.T) {
+	ownerID := uuid.New()
+	invitee := &models.User{ID: uuid.New(), Email: "jane@example.com"}
+
+	amount := int64(2000)
+	row := randomSubscriptionRow(ownerID)
+	row.Amount = &amount
+
+	percent := func(p int) *int { return &p }
+	fixed := func(a int64) *int64 { return &a }
+
+	// the other member already owes half of the price
+	other := &models.SubscriptionMember{
+		ID:           uuid.New(),
+		UserID:       uuid.New(),
+		SharePercent: percent(50),
+		Status:       models.MemberStatusAccepted,
+	}
+
+	testCases := []struct {
+		req           *service.InviteMemberRequest
+		buildStubs    func(*memberMocks)
+		checkResponse func(*testing.T, *models.SubscriptionMember, error)
+		name          string
+	}{
+		{
+			name: "Invite member successfully",
+			req:  &service.InviteMemberRequest{Email: invitee.Email, ShareAmount: fixed(1000)},
+			buildStubs: func(m *memberMocks) {
+				m.subscription.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				m.user.EXPECT().GetUserByEmail(gomock.Any(), invitee.Email).Times(1).Return(invitee, nil)
+				m.member.EXPECT().
+					GetMembers(gomock.Any(), row.ID).
+					Times(1).
+					Return([]*models.SubscriptionMember{other}, nil)
+				m.member.EXPECT().
+					CreateMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg *repo.CreateMemberParams) (*models.SubscriptionMember, error) {
+						require.Equal(t, row.ID, arg.SubscriptionID)
+						require.Equal(t, invitee.ID, arg.UserID)
+						require.Equal(t, int64(1000), *arg.ShareAmount)
+						require.Nil(t, arg.SharePercent)
+
+						return &models.SubscriptionMember{
+							ID:             arg.ID,
+							SubscriptionID: arg.SubscriptionID,
+							UserID:         arg.UserID,
+							Email:          invitee.Email,
+							ShareAmount:    arg.ShareAmount,
+							Status:         models.MemberStatusPending,
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, member *models.SubscriptionMember, err error) {
+				require.NoError(t, err)
+				require.Equal(t, invitee.Email, member.Email)
+				require.Equal(t, models.MemberStatusPending, member.Status)
+			},
+		},
+		{
+			name: "Shares exceed the price",
+			req:  &service.InviteMemberRequest{Email: invitee.Email, ShareAmount: fixed(1001)},
+			buildStubs: func(m *memberMocks) {
+				m.subscription.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				m.user.EXPECT().GetUserByEmail(gomock.Any(), invitee.Email).Times(1).Return(invitee, nil)
+				m.member.EXPECT().
+					GetMembers(gomock.Any(), row.ID).
+					Times(1).
+					Return([]*models.SubscriptionMember{other}, nil)
+				m.member.EXPECT().CreateMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, member *models.SubscriptionMember, err error) {
+				require.ErrorIs(t, err, apperror.ErrShareExceeded)
+			},
+		},
+		{
+			name: "Already a member",
+			req:  &service.InviteMemberRequest{Email: invitee.Email, SharePercent: percent(10)},
+			buildStubs: func(m *memberMocks) {
+				m.subscription.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				m.user.EXPECT().GetUserByEmail(gomock.Any(), invitee.Email).Times(1).Return(invitee, nil)
+				m.member.EXPECT().
+					GetMembers(gomock.Any(), row.ID).
+					Times(1).
+					Return([]*models.SubscriptionMember{{
+						UserID:       invitee.ID,
+						SharePercent: percent(10),
+						Status:       models.MemberStatusPending,
+					}}, nil)
+				m.member.EXPECT().CreateMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, member *models.SubscriptionMember, err error) {
+				require.ErrorIs(t, err, apperror.ErrAlreadyMember)
+			},
+		},
+		{
+			name: "Both shares",
+			req: &service.InviteMemberRequest{
+				Email:        invitee.Email,
+				SharePercent: percent(10),
+				ShareAmount:  fixed(100),
+			},
+			buildStubs: func(m *memberMocks) {
+				m.subscription.EXPECT().GetSubscriptionByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, member *models.SubscriptionMember, err error) {
+				require.ErrorIs(t, err, apperror.ErrInvalidShare)
+			},
+		},
+		{
+			name: "Not the owner",
+			req:  &service.InviteMemberRequest{Email: invitee.Email, SharePercent: percent(10)},
+			buildStubs: func(m *memberMocks) {
+				otherRow := randomSubscriptionRow(uuid.New())
+				otherRow.ID = row.ID
+				m.subscription.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(otherRow, nil)
+				m.user.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, member *models.SubscriptionMember, err error) {
+				require.ErrorIs(t, err, apperror.ErrSubscriptionNotFound)
+			},
+		},
+		{
+			name: "Invitee not registered",
+			req:  &service.InviteMemberRequest{Email: invitee.Email, SharePercent: percent(10)},
+			buildStubs: func(m *memberMocks) {
+				m.subscription.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				m.user.EXPECT().
+					GetUserByEmail(gomock.Any(), invitee.Email).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, member *models.SubscriptionMember, err error) {
+				require.ErrorIs(t, err, apperror.ErrInviteeNotFound)
+			},
+		},
+		{
+			name: "Owner invites themselves",
+			req:  &service.InviteMemberRequest{Email: "john@example.com", SharePercent: percent(10)},
+			buildStubs: func(m *memberMocks) {
+				m.subscription.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				m.user.EXPECT().
+					GetUserByEmail(gomock.Any(), "john@example.com").
+					Times(1).
+					Return(&models.User{ID: ownerID, Email: "john@example.com"}, nil)
+			},
+			checkResponse: func(t *testing.T, member *models.SubscriptionMember, err error) {
+				require.ErrorIs(t, err, apperror.ErrInviteOwner)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, m := newMemberService(t)
+			tc.buildStubs(m)
+
+			tc.req.UserID = ownerID
+			tc.req.SubscriptionID = row.ID
+
+			member, err := s.InviteMember(context.Background(), tc.req)
+			tc.checkResponse(t, member, err)
+		})
+	}
+}
+
+func TestRespondInvitation(t *testing.T) {
+	userID := uuid.New()
+	id := uuid.New()
+
+	testCases := []struct {
+		err         error
+		expectedErr error
+		name        string
+		status      string
+		accept      bool
+	}{
+		{name: "Accept invitation", accept: true, status: models.MemberStatusAccepted},
+		{name: "Decline invitation", status: models.MemberStatusDeclined},
+		{
+			name:        "No pending invitation",
+			accept:      true,
+			status:      models.MemberStatusAccepted,
+			err:         sql.ErrNoRows,
+			expectedErr: apperror.ErrInvitationNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, m := newMemberService(t)
+
+			var member *models.SubscriptionMember
+			if tc.err == nil {
+				member = &models.SubscriptionMember{ID: id, UserID: userID, Status: tc.status}
+			}
+			m.member.EXPECT().
+				RespondInvitation(gomock.Any(), id, userID, tc.status).
+				Times(1).
+				Return(member, tc.err)
+
+			res, err := s.RespondInvitation(context.Background(), id, userID, tc.accept)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.status, res.Status)
+		})
+	}
+}
+
+func TestGetBalances(t *testing.T) {
+	ownerID := uuid.New()
+	subscriptionID := uuid.New()
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	percent := 25
+	fixed := int64(700)
+	joinedAt := date(time.March, 20)
+
+	jane := &models.SubscriptionMember{
+		ID:             uuid.New(),
+		SubscriptionID: subscriptionID,
+		UserID:         uuid.New(),
+		Email:          "jane@example.com",
+		SharePercent:   &percent,
+		Status:         models.MemberStatusAccepted,
+		RespondedAt:    &joinedAt,
+	}
+	bob := &models.SubscriptionMember{
+		ID:             uuid.New(),
+		SubscriptionID: subscriptionID,
+		UserID:         uuid.New(),
+		Email:          "bob@example.com",
+		ShareAmount:    &fixed,
+		Status:         models.MemberStatusAccepted,
+		RespondedAt:    &joinedAt,
+	}
+
+	rows := []*repo.SharedMemberRow{
+		{Member: jane, OwnerID: ownerID, OwnerEmail: "john@example.com", SubscriptionName: "Spotify Family"},
+		{Member: bob, OwnerID: ownerID, OwnerEmail: "john@example.com", SubscriptionName: "Spotify Family"},
+	}
+
+	usd := "USD"
+	newCharge := func(start time.Time, amount int64) *models.Charge {
+		return &models.Charge{
+			ID:               uuid.New(),
+			UserID:           ownerID,
+			SubscriptionID:   &subscriptionID,
+			SubscriptionName: "Spotify Family",
+			PeriodStart:      models.SubscriptionTime(start),
+			PeriodEnd:        models.SubscriptionTime(start.AddDate(0, 1, 0)),
+			Amount:           &amount,
+			Currency:         &usd,
+		}
+	}
+
+	// members joined during the March period, the February one is paid by the owner alone
+	charges := []*models.Charge{
+		newCharge(date(time.April, 15), 1999),
+		newCharge(date(time.March, 15), 1999),
+		newCharge(date(time.February, 15), 1999),
+	}
+
+	testCases := []struct {
+		checkResponse func(*testing.T, *service.GetBalancesResponse, error)
+		name          string
+		userID        uuid.UUID
+	}{
+		{
+			name:   "Owner is owed by every member",
+			userID: ownerID,
+			checkResponse: func(t *testing.T, res *service.GetBalancesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.Periods, 2)
+				require.Equal(t, "2025-04-15", res.Periods[0].PeriodStart)
+				require.Equal(t, "2025-03-15", res.Periods[1].PeriodStart)
+
+				debts := res.Periods[0].Debts
+				require.Len(t, debts, 2)
+				require.Equal(t, jane.UserID, debts[0].FromUserID)
+				require.Equal(t, ownerID, debts[0].ToUserID)
+				// 25% of 19.99 is rounded to 5.00
+				require.Equal(t, int64(500), debts[0].Amount)
+				require.Equal(t, int64(700), debts[1].Amount)
+
+				require.Equal(t, []*service.Balance{
+					{UserID: bob.UserID, Email: "bob@example.com", Currency: "USD", Amount: 1400},
+					{UserID: jane.UserID, Email: "jane@example.com", Currency: "USD", Amount: 1000},
+				}, res.Balances)
+			},
+		},
+		{
+			name:   "Member only sees what it owes",
+			userID: jane.UserID,
+			checkResponse: func(t *testing.T, res *service.GetBalancesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.Periods, 2)
+				require.Len(t, res.Periods[0].Debts, 1)
+				require.Equal(t, jane.UserID, res.Periods[0].Debts[0].FromUserID)
+
+				require.Equal(t, []*service.Balance{
+					{UserID: ownerID, Email: "john@example.com", Currency: "USD", Amount: -1000},
+				}, res.Balances)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, m := newMemberService(t)
+
+			m.member.EXPECT().GetSharedMembers(gomock.Any(), tc.userID).Times(1).Return(rows, nil)
+			m.charge.EXPECT().
+				GetCharges(gomock.Any(), &repo.GetChargesParams{
+					UserID:         ownerID,
+					SubscriptionID: &subscriptionID,
+				}).
+				Times(1).
+				Return(charges, nil)
+
+			res, err := s.GetBalances(context.Background(), &service.GetBalancesRequest{UserID: tc.userID})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
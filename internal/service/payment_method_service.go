@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+// cardNumberPattern matches 12 or more digits optionally grouped with spaces or dashes,
+// labels looking like a full card number are rejected so one is never stored by mistake
+var cardNumberPattern = regexp.MustCompile(`\d(?:[ -]?\d){11,}`)
+
+type PaymentMethodService interface {
+	GetPaymentMethods(ctx context.Context, userID uuid.UUID) ([]*models.PaymentMethod, error)
+	CreatePaymentMethod(
+		ctx context.Context,
+		req *CreatePaymentMethodRequest,
+	) (*models.PaymentMethod, error)
+	UpdatePaymentMethod(
+		ctx context.Context,
+		req *UpdatePaymentMethodRequest,
+	) (*models.PaymentMethod, error)
+	DeletePaymentMethod(ctx context.Context, id, userID uuid.UUID) error
+	SetSubscriptionPaymentMethod(
+		ctx context.Context,
+		req *SetSubscriptionPaymentMethodRequest,
+	) (*models.Subscription, error)
+}
+
+type paymentMethodService struct {
+	repo             repo.PaymentMethodRepo
+	subscriptionRepo repo.SubscriptionRepo
+}
+
+func NewPaymentMethodService(
+	repo repo.PaymentMethodRepo,
+	subscriptionRepo repo.SubscriptionRepo,
+) *paymentMethodService {
+	return &paymentMethodService{repo, subscriptionRepo}
+}
+
+// GetPaymentMethods returns the payment methods of the user ordered by label
+func (s *paymentMethodService) GetPaymentMethods(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.PaymentMethod, error) {
+	return s.repo.GetPaymentMethods(ctx, userID)
+}
+
+type CreatePaymentMethodRequest struct {
+	Label string `json:"label" validate:"required,min=1,max=50" example:"Personal card"`
+	Brand string `json:"brand" validate:"required,oneof=visa mastercard amex discover diners jcb unionpay other" example:"visa"`
+	// Last4 are the last four digits of the card, the full number is never sent
+	Last4       string    `json:"last4"        validate:"required,len=4,numeric"      example:"4242"`
+	UserID      uuid.UUID `json:"-"            validate:"-"`
+	ExpiryMonth int       `json:"expiry_month" validate:"required,gte=1,lte=12"       example:"8"`
+	ExpiryYear  int       `json:"expiry_year"  validate:"required,gte=2000,lte=2100" example:"2027"`
+}
+
+func (s *paymentMethodService) CreatePaymentMethod(
+	ctx context.Context,
+	req *CreatePaymentMethodRequest,
+) (*models.PaymentMethod, error) {
+	if cardNumberPattern.MatchString(req.Label) {
+		return nil, apperror.ErrCardNumberNotAllowed
+	}
+
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreatePaymentMethod(ctx, &repo.SavePaymentMethodParams{
+		ID:          id,
+		UserID:      req.UserID,
+		Label:       req.Label,
+		Brand:       req.Brand,
+		Last4:       req.Last4,
+		ExpiryMonth: req.ExpiryMonth,
+		ExpiryYear:  req.ExpiryYear,
+	})
+}
+
+type UpdatePaymentMethodRequest struct {
+	Label string `json:"label" validate:"required,min=1,max=50" example:"Personal card"`
+	Brand string `json:"brand" validate:"required,oneof=visa mastercard amex discover diners jcb unionpay other" example:"visa"`
+	// Last4 are the last four digits of the card, the full number is never sent
+	Last4       string    `json:"last4"        validate:"required,len=4,numeric"      example:"4242"`
+	ID          uuid.UUID `json:"-"            validate:"-"`
+	UserID      uuid.UUID `json:"-"            validate:"-"`
+	ExpiryMonth int       `json:"expiry_month" validate:"required,gte=1,lte=12"       example:"8"`
+	ExpiryYear  int       `json:"expiry_year"  validate:"required,gte=2000,lte=2100" example:"2027"`
+}
+
+// UpdatePaymentMethod replaces a payment method of the user,
+// a renewed card with a new expiry is warned about again before it expires
+func (s *paymentMethodService) UpdatePaymentMethod(
+	ctx context.Context,
+	req *UpdatePaymentMethodRequest,
+) (*models.PaymentMethod, error) {
+	if cardNumberPattern.MatchString(req.Label) {
+		return nil, apperror.ErrCardNumberNotAllowed
+	}
+
+	method, err := s.repo.UpdatePaymentMethod(ctx, &repo.SavePaymentMethodParams{
+		ID:          req.ID,
+		UserID:      req.UserID,
+		Label:       req.Label,
+		Brand:       req.Brand,
+		Last4:       req.Last4,
+		ExpiryMonth: req.ExpiryMonth,
+		ExpiryYear:  req.ExpiryYear,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrPaymentMethodNotFound
+		}
+		return nil, err
+	}
+
+	return method, nil
+}
+
+// DeletePaymentMethod deletes a payment method of the user, its subscriptions are unlinked
+func (s *paymentMethodService) DeletePaymentMethod(ctx context.Context, id, userID uuid.UUID) error {
+	err := s.repo.DeletePaymentMethod(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrPaymentMethodNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+type SetSubscriptionPaymentMethodRequest struct {
+	// PaymentMethodID is null to unlink the payment method of the subscription
+	PaymentMethodID *uuid.UUID `json:"payment_method_id"`
+	ID              uuid.UUID  `json:"-"`
+	UserID          uuid.UUID  `json:"-"`
+}
+
+// SetSubscriptionPaymentMethod links a subscription of the user to one of their payment methods
+func (s *paymentMethodService) SetSubscriptionPaymentMethod(
+	ctx context.Context,
+	req *SetSubscriptionPaymentMethodRequest,
+) (*models.Subscription, error) {
+	existed, err := s.subscriptionRepo.GetSubscriptionByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	if existed.UserID != req.UserID || existed.DeletedAt != nil {
+		return nil, apperror.ErrSubscriptionNotFound
+	}
+
+	if req.PaymentMethodID != nil {
+		_, err := s.repo.GetPaymentMethod(ctx, *req.PaymentMethodID, req.UserID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, apperror.ErrPaymentMethodNotFound
+			}
+			return nil, err
+		}
+	}
+
+	row, err := s.subscriptionRepo.UpdateSubscriptionPaymentMethod(
+		ctx,
+		&repo.UpdateSubscriptionPaymentMethodParams{
+			ID:              existed.ID,
+			UserID:          existed.UserID,
+			PaymentMethodID: req.PaymentMethodID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var res models.Subscription
+	err = row.MapToSubscriptionModel(&res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,215 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreatePaymentMethod(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockPaymentMethodRepo)
+		checkResponse func(*testing.T, *models.PaymentMethod, error)
+		name          string
+		label         string
+	}{
+		{
+			name:  "OK",
+			label: "Personal card",
+			buildStubs: func(p *mocks.MockPaymentMethodRepo) {
+				p.EXPECT().
+					CreatePaymentMethod(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(
+						_ context.Context,
+						arg *repo.SavePaymentMethodParams,
+					) (*models.PaymentMethod, error) {
+						return &models.PaymentMethod{
+							ID:          arg.ID,
+							UserID:      arg.UserID,
+							Label:       arg.Label,
+							Brand:       arg.Brand,
+							Last4:       arg.Last4,
+							ExpiryMonth: arg.ExpiryMonth,
+							ExpiryYear:  arg.ExpiryYear,
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *models.PaymentMethod, err error) {
+				require.NoError(t, err)
+				require.Equal(t, userID, res.UserID)
+				require.Equal(t, "4242", res.Last4)
+				require.Equal(t, 12, res.ExpiryMonth)
+			},
+		},
+		{
+			name:  "Card number in label",
+			label: "Visa 4242 4242 4242 4242",
+			buildStubs: func(p *mocks.MockPaymentMethodRepo) {
+				p.EXPECT().CreatePaymentMethod(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *models.PaymentMethod, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrCardNumberNotAllowed)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPaymentMethodRepo := mocks.NewMockPaymentMethodRepo(ctrl)
+			tc.buildStubs(mockPaymentMethodRepo)
+
+			paymentMethodService := service.NewPaymentMethodService(
+				mockPaymentMethodRepo,
+				mocks.NewMockSubscriptionRepo(ctrl),
+			)
+
+			res, err := paymentMethodService.CreatePaymentMethod(
+				context.Background(),
+				&service.CreatePaymentMethodRequest{
+					Label:       tc.label,
+					Brand:       "visa",
+					Last4:       "4242",
+					ExpiryMonth: 12,
+					ExpiryYear:  2027,
+					UserID:      userID,
+				},
+			)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestSetSubscriptionPaymentMethod(t *testing.T) {
+	userID := uuid.New()
+	row := randomSubscriptionRow(userID)
+	methodID := uuid.New()
+
+	testCases := []struct {
+		buildStubs      func(*mocks.MockPaymentMethodRepo, *mocks.MockSubscriptionRepo)
+		checkResponse   func(*testing.T, *models.Subscription, error)
+		paymentMethodID *uuid.UUID
+		name            string
+		userID          uuid.UUID
+	}{
+		{
+			name:            "OK",
+			userID:          userID,
+			paymentMethodID: &methodID,
+			buildStubs: func(p *mocks.MockPaymentMethodRepo, r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				p.EXPECT().
+					GetPaymentMethod(gomock.Any(), methodID, userID).
+					Times(1).
+					Return(&models.PaymentMethod{ID: methodID, UserID: userID}, nil)
+				r.EXPECT().
+					UpdateSubscriptionPaymentMethod(gomock.Any(), &repo.UpdateSubscriptionPaymentMethodParams{
+						PaymentMethodID: &methodID,
+						ID:              row.ID,
+						UserID:          userID,
+					}).
+					Times(1).
+					DoAndReturn(func(
+						_ context.Context,
+						arg *repo.UpdateSubscriptionPaymentMethodParams,
+					) (*repo.SubscriptionRow, error) {
+						updated := *row
+						updated.PaymentMethodID = arg.PaymentMethodID
+						return &updated, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, &methodID, res.PaymentMethodID)
+			},
+		},
+		{
+			name:   "Unlink",
+			userID: userID,
+			buildStubs: func(p *mocks.MockPaymentMethodRepo, r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				p.EXPECT().GetPaymentMethod(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				r.EXPECT().
+					UpdateSubscriptionPaymentMethod(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(row, nil)
+			},
+			checkResponse: func(t *testing.T, res *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.Nil(t, res.PaymentMethodID)
+			},
+		},
+		{
+			name:            "Payment method of another user",
+			userID:          userID,
+			paymentMethodID: &methodID,
+			buildStubs: func(p *mocks.MockPaymentMethodRepo, r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				p.EXPECT().
+					GetPaymentMethod(gomock.Any(), methodID, userID).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+				r.EXPECT().UpdateSubscriptionPaymentMethod(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *models.Subscription, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrPaymentMethodNotFound)
+			},
+		},
+		{
+			name:            "Not owner",
+			userID:          uuid.New(),
+			paymentMethodID: &methodID,
+			buildStubs: func(p *mocks.MockPaymentMethodRepo, r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				p.EXPECT().GetPaymentMethod(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				r.EXPECT().UpdateSubscriptionPaymentMethod(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *models.Subscription, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrSubscriptionNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPaymentMethodRepo := mocks.NewMockPaymentMethodRepo(ctrl)
+			mockSubscriptionRepo := mocks.NewMockSubscriptionRepo(ctrl)
+			tc.buildStubs(mockPaymentMethodRepo, mockSubscriptionRepo)
+
+			paymentMethodService := service.NewPaymentMethodService(
+				mockPaymentMethodRepo,
+				mockSubscriptionRepo,
+			)
+
+			res, err := paymentMethodService.SetSubscriptionPaymentMethod(
+				context.Background(),
+				&service.SetSubscriptionPaymentMethodRequest{
+					PaymentMethodID: tc.paymentMethodID,
+					ID:              row.ID,
+					UserID:          tc.userID,
+				},
+			)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
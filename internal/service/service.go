@@ -3,25 +3,77 @@ package service
 import (
 	"github.com/sangtandoan/subscription_tracker/internal/authenticator"
 	"github.com/sangtandoan/subscription_tracker/internal/config"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/mailer"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/storage"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/validator"
 	"github.com/sangtandoan/subscription_tracker/internal/repo"
 )
 
 type Service struct {
-	User         UserService
-	Subscription SubscriptionService
-	Auth         AuthService
-	OAuth2       OAuth2Service
+	User          UserService
+	Subscription  SubscriptionService
+	Auth          AuthService
+	OAuth2        OAuth2Service
+	Category      CategoryService
+	Tag           TagService
+	Analytics     AnalyticsService
+	Calendar      CalendarService
+	Import        ImportService
+	Export        ExportService
+	Statement     StatementService
+	Charge        ChargeService
+	Budget        BudgetService
+	Member        MemberService
+	Catalog       CatalogService
+	Attachment    AttachmentService
+	PaymentMethod PaymentMethodService
 }
 
 func NewService(
 	repo *repo.Repo,
 	authenticator authenticator.Authenticator,
 	config *config.Config,
+	validator validator.Validator,
+	mailer mailer.Mailer,
+	background BackgroundRunner,
+	storage storage.Storage,
 ) *Service {
+	subscriptionService := NewSubscriptionService(
+		repo.Subscription,
+		repo.Category,
+		repo.Tag,
+		repo.Price,
+		repo.Catalog,
+		repo.Transaction,
+	)
+
 	return &Service{
 		User:         NewUserService(repo.User),
-		Subscription: NewSubscriptionService(repo.Subscription),
-		Auth:         NewAuthService(repo.User, repo.Session, authenticator),
+		Subscription: subscriptionService,
+		Category:     NewCategoryService(repo.Category),
+		Tag:          NewTagService(repo.Tag),
+		Analytics:    NewAnalyticsService(repo.Analytics),
+		Calendar:     NewCalendarService(repo.User, subscriptionService, config.Chrono.ReminderDays),
+		Import:       NewImportService(subscriptionService, repo.Transaction, validator),
+		Export:       NewExportService(repo, mailer, background, config.Export),
+		Statement:    NewStatementService(repo.Subscription, subscriptionService, repo.Transaction),
+		Charge:       NewChargeService(repo.Charge, repo.Subscription, repo.Price, repo.Transaction),
+		Budget:       NewBudgetService(repo.Budget, repo.Subscription, repo.Price),
+		Member:       NewMemberService(repo.Subscription, repo.Member, repo.User, repo.Charge),
+		Attachment: NewAttachmentService(
+			repo.Attachment,
+			repo.Subscription,
+			storage,
+			repo.Transaction,
+			config.Attachment,
+		),
+		PaymentMethod: NewPaymentMethodService(repo.PaymentMethod, repo.Subscription),
+		Catalog: NewCatalogService(
+			repo.Catalog,
+			repo.User,
+			repo.Transaction,
+		),
+		Auth: NewAuthService(repo.User, repo.Session, authenticator),
 		OAuth2: NewGoogleOAuth2Service(
 			config.GoogleOAuth,
 			repo.User,
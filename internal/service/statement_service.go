@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/statement"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+type StatementService interface {
+	DetectSubscriptions(
+		ctx context.Context,
+		req *DetectSubscriptionsRequest,
+	) (*DetectSubscriptionsResponse, error)
+	ConfirmSubscriptions(
+		ctx context.Context,
+		req *ConfirmSubscriptionsRequest,
+	) ([]*models.Subscription, error)
+}
+
+type statementService struct {
+	subscriptionRepo    repo.SubscriptionRepo
+	subscriptionService SubscriptionService
+	transaction         repo.TransactionManager
+}
+
+func NewStatementService(
+	subscriptionRepo repo.SubscriptionRepo,
+	subscriptionService SubscriptionService,
+	transaction repo.TransactionManager,
+) *statementService {
+	return &statementService{subscriptionRepo, subscriptionService, transaction}
+}
+
+type DetectSubscriptionsRequest struct {
+	File io.Reader `validate:"-"`
+	// Currency is used for statements which do not tell their currency
+	Currency string    `validate:"omitempty,iso4217"`
+	UserID   uuid.UUID `validate:"-"`
+}
+
+type DetectSubscriptionsResponse struct {
+	Candidates []*SubscriptionCandidate `json:"candidates"`
+	// Transactions is the number of transactions read from the statement
+	Transactions int `json:"transactions" example:"120"`
+}
+
+type SubscriptionCandidate struct {
+	// Subscription is the request which creates the candidate once confirmed, it can be edited before
+	Subscription *CreateSubscriptionRequest `json:"subscription"`
+	// TrackedSubscriptionID is an active subscription of the same merchant, the candidate is likely tracked already
+	TrackedSubscriptionID *uuid.UUID `json:"tracked_subscription_id,omitempty"`
+	FirstCharge           string     `json:"first_charge"                      example:"2025-01-15"`
+	LastCharge            string     `json:"last_charge"                       example:"2025-03-15"`
+	NextCharge            string     `json:"next_charge"                       example:"2025-04-15"`
+	Occurrences           int        `json:"occurrences"                       example:"3"`
+}
+
+// DetectSubscriptions reads an OFX/QFX or csv bank statement and proposes a subscription
+// for every series of charges of the same amount by the same merchant at a weekly, monthly or yearly cadence.
+// Nothing is created, candidates are created by ConfirmSubscriptions
+func (s *statementService) DetectSubscriptions(
+	ctx context.Context,
+	req *DetectSubscriptionsRequest,
+) (*DetectSubscriptionsResponse, error) {
+	transactions, err := statement.Parse(req.File)
+	if err != nil {
+		return nil, statementError(err)
+	}
+
+	candidates := statement.Detect(transactions)
+
+	tracked, err := s.trackedMerchants(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &DetectSubscriptionsResponse{
+		Candidates:   make([]*SubscriptionCandidate, 0, len(candidates)),
+		Transactions: len(transactions),
+	}
+	for _, candidate := range candidates {
+		currency := candidate.Currency
+		if currency == "" {
+			currency = req.Currency
+		}
+		if currency == "" {
+			return nil, apperror.ErrStatementCurrencyRequired
+		}
+
+		// the subscription starts with the last charge, so its end date is the next expected charge
+		amount := candidate.Amount
+		c := &SubscriptionCandidate{
+			Subscription: &CreateSubscriptionRequest{
+				Name:      truncateName(candidate.Name),
+				StartDate: models.SubscriptionTime(candidate.LastDate),
+				Duration:  candidate.Duration,
+				Amount:    &amount,
+				Currency:  currency,
+			},
+			FirstCharge: candidate.FirstDate.Format(upcomingDateLayout),
+			LastCharge:  candidate.LastDate.Format(upcomingDateLayout),
+			NextCharge:  candidate.NextDate.Format(upcomingDateLayout),
+			Occurrences: candidate.Occurrences,
+		}
+		if id, ok := tracked[candidate.Merchant]; ok {
+			c.TrackedSubscriptionID = &id
+		}
+
+		res.Candidates = append(res.Candidates, c)
+	}
+
+	return res, nil
+}
+
+// trackedMerchants returns the active subscriptions of the user by normalized merchant of their name
+func (s *statementService) trackedMerchants(
+	ctx context.Context,
+	userID uuid.UUID,
+) (map[string]uuid.UUID, error) {
+	rows, err := s.subscriptionRepo.GetUserSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := make(map[string]uuid.UUID, len(rows))
+	for _, row := range rows {
+		if row.DeletedAt != nil || row.EndedAt != nil || row.IsCancelled {
+			continue
+		}
+
+		if merchant := statement.NormalizeMerchant(row.Name); merchant != "" {
+			tracked[merchant] = row.ID
+		}
+	}
+
+	return tracked, nil
+}
+
+type ConfirmSubscriptionsRequest struct {
+	Subscriptions []*CreateSubscriptionRequest `json:"subscriptions" validate:"required,min=1,max=100,dive"`
+	UserID        uuid.UUID                    `json:"-"             validate:"-"`
+}
+
+// ConfirmSubscriptions creates the confirmed candidates together, none is created when one fails
+func (s *statementService) ConfirmSubscriptions(
+	ctx context.Context,
+	req *ConfirmSubscriptionsRequest,
+) ([]*models.Subscription, error) {
+	subscriptions := make([]*models.Subscription, 0, len(req.Subscriptions))
+
+	err := s.transaction.WithTx(ctx, func(txContext context.Context) error {
+		for _, sub := range req.Subscriptions {
+			sub.UserID = req.UserID
+
+			created, err := s.subscriptionService.CreateSubscription(txContext, sub)
+			if err != nil {
+				return err
+			}
+
+			subscriptions = append(subscriptions, created)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// subscription names are at most 50 characters
+func truncateName(name string) string {
+	runes := []rune(name)
+	if len(runes) > 50 {
+		return strings.TrimSpace(string(runes[:50]))
+	}
+
+	return name
+}
+
+func statementError(err error) error {
+	switch {
+	case errors.Is(err, statement.ErrUnknownFormat):
+		return apperror.ErrUnknownStatementFormat
+	case errors.Is(err, statement.ErrTooManyTransactions):
+		return apperror.ErrStatementTooLarge
+	}
+
+	var parseError *statement.ParseError
+	if errors.As(err, &parseError) {
+		return apperror.NewAppError(
+			http.StatusBadRequest,
+			fmt.Sprintf("invalid statement at line %d: %v", parseError.Line, parseError.Err),
+		)
+	}
+
+	return err
+}
@@ -0,0 +1,222 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDetectSubscriptions(t *testing.T) {
+	userID := uuid.New()
+
+	netflix := randomSubscriptionRow(userID)
+	netflix.Name = "Netflix"
+	deleted := randomSubscriptionRow(userID)
+	deleted.Name = "Gym Weekly"
+	deletedAt := time.Now()
+	deleted.DeletedAt = &deletedAt
+
+	file := strings.Join([]string{
+		"Date,Description,Amount",
+		"2025-01-15,NETFLIX.COM 111,-15.49",
+		"2025-02-14,NETFLIX.COM 222,-15.49",
+		"2025-03-17,NETFLIX.COM 333,-15.49",
+		"2025-01-06,GYM WEEKLY,-10.00",
+		"2025-01-13,GYM WEEKLY,-10.00",
+		"2025-01-20,GYM WEEKLY,-10.00",
+		"2025-01-31,SALARY,2500.00",
+	}, "\n")
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockSubscriptionRepo)
+		checkResponse func(*testing.T, *service.DetectSubscriptionsResponse, error)
+		name          string
+		file          string
+		currency      string
+	}{
+		{
+			name:     "Candidates with currency of the request",
+			file:     file,
+			currency: "USD",
+			buildStubs: func(r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().
+					GetUserSubscriptions(gomock.Any(), userID).
+					Times(1).
+					Return([]*repo.SubscriptionRow{netflix, deleted}, nil)
+			},
+			checkResponse: func(t *testing.T, res *service.DetectSubscriptionsResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 7, res.Transactions)
+				require.Len(t, res.Candidates, 2)
+
+				gym := res.Candidates[0]
+				require.Equal(t, "Gym Weekly", gym.Subscription.Name)
+				require.Equal(t, enums.Weekly, gym.Subscription.Duration)
+				// a deleted subscription is not tracked anymore
+				require.Nil(t, gym.TrackedSubscriptionID)
+
+				candidate := res.Candidates[1]
+				require.Equal(t, "Netflix", candidate.Subscription.Name)
+				require.Equal(t, int64(1549), *candidate.Subscription.Amount)
+				require.Equal(t, "USD", candidate.Subscription.Currency)
+				require.Equal(t, enums.Monthly, candidate.Subscription.Duration)
+				require.Equal(
+					t,
+					time.Date(2025, time.March, 17, 0, 0, 0, 0, time.UTC),
+					time.Time(candidate.Subscription.StartDate),
+				)
+				require.Equal(t, "2025-01-15", candidate.FirstCharge)
+				require.Equal(t, "2025-04-17", candidate.NextCharge)
+				require.Equal(t, 3, candidate.Occurrences)
+				require.Equal(t, &netflix.ID, candidate.TrackedSubscriptionID)
+			},
+		},
+		{
+			name: "Currency required",
+			file: file,
+			buildStubs: func(r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().
+					GetUserSubscriptions(gomock.Any(), userID).
+					Times(1).
+					Return([]*repo.SubscriptionRow{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *service.DetectSubscriptionsResponse, err error) {
+				require.ErrorIs(t, err, apperror.ErrStatementCurrencyRequired)
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "Unknown format",
+			file: "hello world",
+			buildStubs: func(r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().GetUserSubscriptions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *service.DetectSubscriptionsResponse, err error) {
+				require.ErrorIs(t, err, apperror.ErrUnknownStatementFormat)
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "Invalid transaction",
+			file: "Date,Description,Amount\n2025-01-15,Netflix,abc\n",
+			buildStubs: func(r *mocks.MockSubscriptionRepo) {
+				r.EXPECT().GetUserSubscriptions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *service.DetectSubscriptionsResponse, err error) {
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, "invalid statement at line 2: invalid amount", appErr.Msg)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			r := mocks.NewMockSubscriptionRepo(ctrl)
+			tc.buildStubs(r)
+
+			s := service.NewStatementService(r, mocks.NewMockSubscriptionService(ctrl), &fakeTransaction{})
+
+			res, err := s.DetectSubscriptions(context.Background(), &service.DetectSubscriptionsRequest{
+				File:     strings.NewReader(tc.file),
+				Currency: tc.currency,
+				UserID:   userID,
+			})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestConfirmSubscriptions(t *testing.T) {
+	userID := uuid.New()
+	amount := int64(1549)
+
+	newRequest := func() *service.ConfirmSubscriptionsRequest {
+		return &service.ConfirmSubscriptionsRequest{
+			UserID: userID,
+			Subscriptions: []*service.CreateSubscriptionRequest{
+				{Name: "Netflix", Duration: enums.Monthly, Amount: &amount, Currency: "USD"},
+				{Name: "Gym Weekly", Duration: enums.Weekly},
+			},
+		}
+	}
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockSubscriptionService)
+		checkResponse func(*testing.T, []*models.Subscription, error, *fakeTransaction)
+		name          string
+	}{
+		{
+			name: "Created in a transaction",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().
+					CreateSubscription(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(
+						_ context.Context,
+						req *service.CreateSubscriptionRequest,
+					) (*models.Subscription, error) {
+						require.Equal(t, userID, req.UserID)
+						return &models.Subscription{ID: uuid.New(), Name: req.Name}, nil
+					})
+			},
+			checkResponse: func(
+				t *testing.T,
+				subs []*models.Subscription,
+				err error,
+				tx *fakeTransaction,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, 1, tx.calls)
+				require.Len(t, subs, 2)
+				require.Equal(t, "Netflix", subs[0].Name)
+				require.Equal(t, "Gym Weekly", subs[1].Name)
+			},
+		},
+		{
+			name: "Error",
+			buildStubs: func(s *mocks.MockSubscriptionService) {
+				s.EXPECT().
+					CreateSubscription(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("insert failed"))
+			},
+			checkResponse: func(
+				t *testing.T,
+				subs []*models.Subscription,
+				err error,
+				_ *fakeTransaction,
+			) {
+				require.Error(t, err)
+				require.Nil(t, subs)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			subscriptionService := mocks.NewMockSubscriptionService(ctrl)
+			tc.buildStubs(subscriptionService)
+
+			tx := &fakeTransaction{}
+			s := service.NewStatementService(mocks.NewMockSubscriptionRepo(ctrl), subscriptionService, tx)
+
+			subs, err := s.ConfirmSubscriptions(context.Background(), newRequest())
+			tc.checkResponse(t, subs, err, tx)
+		})
+	}
+}
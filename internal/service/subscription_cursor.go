@@ -0,0 +1,70 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+// subscriptionCursor is the payload of the opaque after and before tokens,
+// it remembers the sort of the list so a cursor can not be reused with another sort
+type subscriptionCursor struct {
+	Sort    string    `json:"s,omitempty"`
+	Order   string    `json:"o,omitempty"`
+	Value   string    `json:"v"`
+	ID      uuid.UUID `json:"id"`
+	Deleted bool      `json:"d,omitempty"`
+}
+
+func encodeSubscriptionCursor(req *GetAllSubscriptionsRequest, row *repo.SubscriptionRow) *string {
+	b, _ := json.Marshal(subscriptionCursor{
+		Sort:    req.Sort,
+		Order:   req.Order,
+		Value:   row.CursorValue(req.Sort, req.Order, req.Deleted),
+		ID:      row.ID,
+		Deleted: req.Deleted,
+	})
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return &token
+}
+
+// decodeSubscriptionCursor returns nil when neither after nor before is set
+func decodeSubscriptionCursor(req *GetAllSubscriptionsRequest) (*repo.SubscriptionCursor, error) {
+	if req.After != "" && req.Before != "" {
+		return nil, apperror.ErrInvalidCursor
+	}
+
+	token, before := req.After, false
+	if req.Before != "" {
+		token, before = req.Before, true
+	}
+
+	if token == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, apperror.ErrInvalidCursor
+	}
+
+	var cursor subscriptionCursor
+	err = json.Unmarshal(b, &cursor)
+	if err != nil {
+		return nil, apperror.ErrInvalidCursor
+	}
+
+	if cursor.Sort != req.Sort || cursor.Order != req.Order || cursor.Deleted != req.Deleted {
+		return nil, apperror.ErrInvalidCursor
+	}
+
+	return &repo.SubscriptionCursor{
+		Value:  cursor.Value,
+		ID:     cursor.ID,
+		Before: before,
+	}, nil
+}
@@ -2,10 +2,15 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
 	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
 	"github.com/sangtandoan/subscription_tracker/internal/repo"
 )
@@ -19,6 +24,47 @@ type SubscriptionService interface {
 		ctx context.Context,
 		req *CreateSubscriptionRequest,
 	) (*models.Subscription, error)
+	GetSubscription(
+		ctx context.Context,
+		id uuid.UUID,
+		userID uuid.UUID,
+	) (*models.Subscription, error)
+	UpdateSubscription(
+		ctx context.Context,
+		req *UpdateSubscriptionRequest,
+	) (*models.Subscription, error)
+	CancelSubscription(
+		ctx context.Context,
+		req *CancelSubscriptionRequest,
+	) (*models.Subscription, error)
+	ReactivateSubscription(
+		ctx context.Context,
+		id uuid.UUID,
+		userID uuid.UUID,
+	) (*models.Subscription, error)
+	SetSubscriptionCategory(
+		ctx context.Context,
+		req *SetSubscriptionCategoryRequest,
+	) (*models.Subscription, error)
+	SetSubscriptionTags(
+		ctx context.Context,
+		req *SetSubscriptionTagsRequest,
+	) (*models.Subscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	RestoreSubscription(
+		ctx context.Context,
+		id uuid.UUID,
+		userID uuid.UUID,
+	) (*models.Subscription, error)
+	GetUpcomingRenewals(
+		ctx context.Context,
+		req *GetUpcomingRenewalsRequest,
+	) (*GetUpcomingRenewalsResponse, error)
+	GetSubscriptionPrices(
+		ctx context.Context,
+		id uuid.UUID,
+		userID uuid.UUID,
+	) ([]*models.SubscriptionPrice, error)
 	GetSubscriptionsBeforeNumDays(
 		ctx context.Context,
 		num int,
@@ -26,11 +72,23 @@ type SubscriptionService interface {
 }
 
 type subscriptionService struct {
-	repo repo.SubscriptionRepo
+	repo         repo.SubscriptionRepo
+	categoryRepo repo.CategoryRepo
+	tagRepo      repo.TagRepo
+	priceRepo    repo.SubscriptionPriceRepo
+	catalogRepo  repo.CatalogRepo
+	transaction  repo.TransactionManager
 }
 
-func NewSubscriptionService(repo repo.SubscriptionRepo) *subscriptionService {
-	return &subscriptionService{repo}
+func NewSubscriptionService(
+	repo repo.SubscriptionRepo,
+	categoryRepo repo.CategoryRepo,
+	tagRepo repo.TagRepo,
+	priceRepo repo.SubscriptionPriceRepo,
+	catalogRepo repo.CatalogRepo,
+	transaction repo.TransactionManager,
+) *subscriptionService {
+	return &subscriptionService{repo, categoryRepo, tagRepo, priceRepo, catalogRepo, transaction}
 }
 
 func (s *subscriptionService) GetSubscriptionsBeforeNumDays(
@@ -58,12 +116,29 @@ func (s *subscriptionService) GetSubscriptionsBeforeNumDays(
 
 type GetAllSubscriptionsRequest struct {
 	IsCancelled *bool
-	UserID      uuid.UUID
-	Offset      int
-	Limit       int
+	CategoryID  *uuid.UUID
+	EndDateFrom *time.Time
+	EndDateTo   *time.Time
+	Status      string
+	Query       string
+	Sort        string
+	Order       string
+	// After and Before are cursors returned as next_cursor and prev_cursor,
+	// Offset is ignored when one of them is set
+	After   string
+	Before  string
+	TagIDs  []uuid.UUID
+	UserID  uuid.UUID
+	Offset  int
+	Limit   int
+	Deleted bool
 }
 
 type GetAllSubscriptionsResponse struct {
+	// NextCursor and PrevCursor are passed as after and before to get the next and previous page,
+	// they are null when there is no such page
+	NextCursor    *string                `json:"next_cursor"`
+	PrevCursor    *string                `json:"prev_cursor"`
 	Subscriptions []*models.Subscription `json:"subscriptions"`
 	Count         int                    `json:"count"`
 }
@@ -72,11 +147,27 @@ func (s *subscriptionService) GetAllSubscriptions(
 	ctx context.Context,
 	req *GetAllSubscriptionsRequest,
 ) (*GetAllSubscriptionsResponse, error) {
+	cursor, err := decodeSubscriptionCursor(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// one more row is read to know if there is a page after this one
 	arg := repo.GetAllSubscriptionsParams{
 		UserID:      req.UserID,
-		Limit:       req.Limit,
+		Limit:       req.Limit + 1,
 		Offset:      req.Offset,
+		Cursor:      cursor,
 		IsCancelled: req.IsCancelled,
+		Status:      req.Status,
+		Deleted:     req.Deleted,
+		CategoryID:  req.CategoryID,
+		TagIDs:      req.TagIDs,
+		Query:       req.Query,
+		Sort:        req.Sort,
+		Order:       req.Order,
+		EndDateFrom: req.EndDateFrom,
+		EndDateTo:   req.EndDateTo,
 	}
 
 	res, count, err := s.repo.GetAllSubscriptions(ctx, &arg)
@@ -84,6 +175,18 @@ func (s *subscriptionService) GetAllSubscriptions(
 		return nil, err
 	}
 
+	// rows before a cursor are returned in list order,
+	// so the extra row is the first one instead of the last one
+	hasMore := len(res) > req.Limit
+	isBefore := cursor != nil && cursor.Before
+	if hasMore {
+		if isBefore {
+			res = res[1:]
+		} else {
+			res = res[:req.Limit]
+		}
+	}
+
 	var arr []*models.Subscription
 	for _, row := range res {
 		var sub models.Subscription
@@ -91,29 +194,88 @@ func (s *subscriptionService) GetAllSubscriptions(
 		if err != nil {
 			return nil, err
 		}
+		if row.UserID != req.UserID {
+			hideOwnerFields(&sub)
+		}
 
 		arr = append(arr, &sub)
 	}
 
-	return &GetAllSubscriptionsResponse{
+	response := &GetAllSubscriptionsResponse{
 		Subscriptions: arr,
 		Count:         count,
-	}, nil
+	}
+
+	if len(res) == 0 {
+		return response, nil
+	}
+
+	if hasMore || isBefore {
+		response.NextCursor = encodeSubscriptionCursor(req, res[len(res)-1])
+	}
+
+	if (hasMore && isBefore) || (cursor != nil && !isBefore) || (cursor == nil && req.Offset > 0) {
+		response.PrevCursor = encodeSubscriptionCursor(req, res[0])
+	}
+
+	return response, nil
 }
 
 type CreateSubscriptionRequest struct {
 	StartDate models.SubscriptionTime `json:"start_date" validate:"required"`
-	Name      string                  `json:"name"       validate:"required,min=3,max=50"`
-	UserID    uuid.UUID               `json:"-"          validate:"-"`
-	// TODO: add validation for enum duration
-	Duration enums.Duration `json:"duration"   validate:"required"              enums:"weekly, monthly, 6 months, yearly" swaggertype:"string"`
+	// Amount is the price in minor units (e.g. cents), it must be sent together with Currency
+	Amount   *int64 `json:"amount"     validate:"required_with=Currency,omitempty,gte=0"`
+	Currency string `json:"currency"   validate:"required_with=Amount PostTrialAmount,omitempty,iso4217" example:"USD"`
+	// TrialEndDate starts the subscription with a free trial until this date,
+	// then it converts to the paid billing cycle with PostTrialAmount as price
+	TrialEndDate    *models.SubscriptionTime `json:"trial_end_date"    swaggertype:"string" example:"2025-02-15"`
+	PostTrialAmount *int64                   `json:"post_trial_amount" validate:"omitempty,gte=0"`
+	CategoryID      *uuid.UUID               `json:"category_id"`
+	// CatalogPlanID prefills the name, duration and price left empty with the ones of a catalog plan
+	CatalogPlanID *uuid.UUID `json:"catalog_plan_id"`
+	Notes         *string    `json:"notes"             validate:"omitempty,max=2000"`
+	Name          string     `json:"name"              validate:"required_without=CatalogPlanID,omitempty,min=3,max=50"`
+	// Duration accepts "weekly", "monthly", "6 months", "yearly", "quarterly",
+	// "<count> <unit>" like "45 days" or an object like {"count": 2, "unit": "year"}
+	Duration enums.Duration `json:"duration"   validate:"required_without=CatalogPlanID" swaggertype:"string" example:"3 months"`
+	UserID   uuid.UUID      `json:"-"          validate:"-"`
 }
 
 func (s *subscriptionService) CreateSubscription(
 	ctx context.Context,
 	req *CreateSubscriptionRequest,
 ) (*models.Subscription, error) {
-	endDate := calculateEndDate(req.StartDate, req.Duration)
+	if req.CatalogPlanID != nil {
+		err := s.prefillFromCatalog(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the anchor day is taken from the first start date and never drifts afterwards
+	anchorDay := time.Time(req.StartDate).Day()
+	endDate := calculateEndDate(req.StartDate, req.Duration, anchorDay)
+
+	// a trial is the first period of the subscription,
+	// the paid billing cycle is anchored on the day the trial ends
+	var trialEndDate *time.Time
+	if req.TrialEndDate != nil {
+		t := time.Time(*req.TrialEndDate)
+		if !t.After(time.Time(req.StartDate)) {
+			return nil, apperror.ErrInvalidTrialEndDate
+		}
+
+		trialEndDate = &t
+		endDate = t
+		anchorDay = t.Day()
+	}
+
+	if req.CategoryID != nil {
+		err := s.checkUserCategory(ctx, *req.CategoryID, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	id, err := uuid.NewUUID()
 	if err != nil {
@@ -126,9 +288,378 @@ func (s *subscriptionService) CreateSubscription(
 		StartDate: time.Time(req.StartDate),
 		EndDate:   endDate,
 		Name:      req.Name,
-		Duration:  req.Duration.String(),
+		Duration:  req.Duration,
+		Amount:    req.Amount,
+
+		TrialEndDate:     trialEndDate,
+		PostTrialAmount:  req.PostTrialAmount,
+		BillingAnchorDay: anchorDay,
+		CategoryID:       req.CategoryID,
+		Notes:            req.Notes,
+	}
+	if req.Currency != "" {
+		arg.Currency = &req.Currency
+	}
+
+	// the first charge of a subscription starting with a trial is at the trial end date
+	price, effectiveFrom := req.Amount, arg.StartDate
+	if trialEndDate != nil {
+		effectiveFrom = *trialEndDate
+		if req.PostTrialAmount != nil {
+			price = req.PostTrialAmount
+		}
+	}
+
+	var row *repo.SubscriptionRow
+	err = s.transaction.WithTx(ctx, func(txContext context.Context) error {
+		row, err = s.repo.CreateSubscription(txContext, arg)
+		if err != nil {
+			return err
+		}
+
+		return s.createPrice(txContext, row.ID, price, arg.Currency, effectiveFrom)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res models.Subscription
+	err = row.MapToSubscriptionModel(&res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *subscriptionService) GetSubscription(
+	ctx context.Context,
+	id uuid.UUID,
+	userID uuid.UUID,
+) (*models.Subscription, error) {
+	// accepted members can read the subscriptions shared with them
+	row, err := s.repo.GetOwnedOrSharedSubscription(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	if row.DeletedAt != nil {
+		return nil, apperror.ErrSubscriptionNotFound
+	}
+
+	var res models.Subscription
+	err = row.MapToSubscriptionModel(&res)
+	if err != nil {
+		return nil, err
+	}
+
+	if row.UserID != userID {
+		hideOwnerFields(&res)
+	}
+
+	return &res, nil
+}
+
+// hideOwnerFields marks a subscription as shared with the current user
+// and clears the notes, category, tags and payment method which are private to its owner
+func hideOwnerFields(sub *models.Subscription) {
+	sub.IsShared = true
+	sub.Notes = nil
+	sub.CategoryID = nil
+	sub.TagIDs = []uuid.UUID{}
+	sub.PaymentMethodID = nil
+}
+
+// UpdateSubscriptionRequest is a partial update, only non nil fields are changed
+type UpdateSubscriptionRequest struct {
+	StartDate *models.SubscriptionTime `json:"start_date"           swaggertype:"string"`
+	Name      *string                  `json:"name"                 validate:"omitempty,min=3,max=50"`
+	Duration  *enums.Duration          `json:"duration"             swaggertype:"string"              example:"3 months"`
+	// Amount is the price of the next charge in minor units (e.g. cents),
+	// during a trial it is the price the trial converts to
+	Amount   *int64  `json:"amount"               validate:"omitempty,gte=0"`
+	Currency *string `json:"currency"             validate:"omitempty,iso4217"      example:"USD"`
+	// PriceEffectiveDate is when a new price is first charged, it defaults to the next renewal,
+	// the amount only changes at the renewal it takes effect with when it is later
+	PriceEffectiveDate *models.SubscriptionTime `json:"price_effective_date" swaggertype:"string"              example:"2025-02-15"`
+	// Notes are free text, an empty string clears them
+	Notes  *string   `json:"notes"                validate:"omitempty,max=2000"`
+	ID     uuid.UUID `json:"-"                    validate:"-"`
+	UserID uuid.UUID `json:"-"                    validate:"-"`
+}
+
+func (s *subscriptionService) UpdateSubscription(
+	ctx context.Context,
+	req *UpdateSubscriptionRequest,
+) (*models.Subscription, error) {
+	existed, err := s.getUserSubscription(ctx, req.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := existed.Duration
+
+	arg := repo.UpdateSubscriptionParams{
+		ID:        existed.ID,
+		UserID:    existed.UserID,
+		Name:      existed.Name,
+		StartDate: existed.StartDate,
+		EndDate:   existed.EndDate,
+
+		BillingAnchorDay: existed.BillingAnchorDay,
+		Notes:            existed.Notes,
+	}
+
+	if req.Name != nil {
+		arg.Name = *req.Name
+	}
+
+	if req.Notes != nil {
+		arg.Notes = req.Notes
+		if *req.Notes == "" {
+			arg.Notes = nil
+		}
+	}
+
+	// a new start date also moves the billing anchor day
+	if req.StartDate != nil {
+		arg.StartDate = time.Time(*req.StartDate)
+		arg.BillingAnchorDay = arg.StartDate.Day()
+	}
+
+	if req.Duration != nil {
+		duration = *req.Duration
+	}
+
+	// end date only needs to be recomputed when the billing cycle changes,
+	// during a trial it always stays at the trial end date
+	if existed.InTrial() {
+		if !existed.TrialEndDate.After(arg.StartDate) {
+			return nil, apperror.ErrInvalidTrialEndDate
+		}
+		arg.BillingAnchorDay = existed.BillingAnchorDay
+	} else if req.StartDate != nil || req.Duration != nil {
+		arg.EndDate = calculateEndDate(
+			models.SubscriptionTime(arg.StartDate),
+			duration,
+			arg.BillingAnchorDay,
+		)
+	}
+	arg.Duration = duration
+
+	arg.Amount, arg.Currency, arg.PostTrialAmount = existed.Amount, existed.Currency, existed.PostTrialAmount
+
+	// the price of the next charge is the post trial price during a trial
+	price, existedPrice := &arg.Amount, existed.Amount
+	if existed.InTrial() {
+		price, existedPrice = &arg.PostTrialAmount, existed.PostTrialAmount
+	}
+
+	priceChanged := false
+	if req.Amount != nil && (*price == nil || **price != *req.Amount) {
+		*price = req.Amount
+		priceChanged = true
+	}
+
+	if req.Currency != nil {
+		currency := strings.ToUpper(*req.Currency)
+		if arg.Currency == nil || *arg.Currency != currency {
+			arg.Currency = &currency
+			priceChanged = true
+		}
+	}
+
+	if *price != nil && arg.Currency == nil {
+		return nil, apperror.ErrCurrencyRequired
+	}
+
+	// the current period is already paid, so a new price is charged from the next renewal
+	effectiveFrom := arg.EndDate
+	if req.PriceEffectiveDate != nil {
+		effectiveFrom = time.Time(*req.PriceEffectiveDate)
+	}
+
+	// a price effective after the next renewal only goes to the history until a renewal applies it,
+	// the subscription keeps the price of its next charge meanwhile
+	newPrice, newCurrency := *price, arg.Currency
+	if priceChanged && effectiveFrom.After(arg.EndDate) {
+		*price, arg.Currency = existedPrice, existed.Currency
+	}
+
+	var row *repo.SubscriptionRow
+	err = s.transaction.WithTx(ctx, func(txContext context.Context) error {
+		row, err = s.repo.UpdateSubscription(txContext, &arg)
+		if err != nil {
+			return err
+		}
+
+		if !priceChanged {
+			return nil
+		}
+
+		return s.createPrice(txContext, row.ID, newPrice, newCurrency, effectiveFrom)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	var res models.Subscription
+	err = row.MapToSubscriptionModel(&res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetSubscriptionPrices returns the price history of a subscription, oldest first
+func (s *subscriptionService) GetSubscriptionPrices(
+	ctx context.Context,
+	id uuid.UUID,
+	userID uuid.UUID,
+) ([]*models.SubscriptionPrice, error) {
+	_, err := s.getUserSubscription(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.priceRepo.GetSubscriptionPrices(ctx, id)
+}
+
+// createPrice adds a price to the history of a subscription, an unknown price is not kept
+func (s *subscriptionService) createPrice(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	amount *int64,
+	currency *string,
+	effectiveFrom time.Time,
+) error {
+	if amount == nil || currency == nil {
+		return nil
+	}
+
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.priceRepo.CreateSubscriptionPrice(ctx, &repo.CreateSubscriptionPriceParams{
+		ID:             id,
+		SubscriptionID: subscriptionID,
+		Amount:         *amount,
+		Currency:       *currency,
+		EffectiveFrom:  effectiveFrom,
+	})
+
+	return err
+}
+
+type CancelSubscriptionRequest struct {
+	ID     uuid.UUID `json:"-"`
+	UserID uuid.UUID `json:"-"`
+	// AtPeriodEnd keeps the subscription active until its end date instead of cancelling it now
+	AtPeriodEnd bool `json:"at_period_end"`
+}
+
+func (s *subscriptionService) CancelSubscription(
+	ctx context.Context,
+	req *CancelSubscriptionRequest,
+) (*models.Subscription, error) {
+	existed, err := s.getUserSubscription(ctx, req.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// cancelling at period end can still be turned into an immediate cancellation
+	if existed.IsCancelled || (existed.CancelAtPeriodEnd && req.AtPeriodEnd) {
+		return nil, apperror.ErrSubscriptionCancelled
+	}
+
+	row, err := s.repo.CancelSubscription(ctx, &repo.CancelSubscriptionParams{
+		ID:          existed.ID,
+		UserID:      existed.UserID,
+		CancelledAt: time.Now(),
+		AtPeriodEnd: req.AtPeriodEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res models.Subscription
+	err = row.MapToSubscriptionModel(&res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *subscriptionService) ReactivateSubscription(
+	ctx context.Context,
+	id uuid.UUID,
+	userID uuid.UUID,
+) (*models.Subscription, error) {
+	existed, err := s.getUserSubscription(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existed.EndedAt != nil {
+		return nil, apperror.ErrSubscriptionEnded
+	}
+
+	if !existed.IsCancelled && !existed.CancelAtPeriodEnd {
+		return nil, apperror.ErrSubscriptionNotCancelled
+	}
+
+	row, err := s.repo.ReactivateSubscription(ctx, existed.ID, existed.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var res models.Subscription
+	err = row.MapToSubscriptionModel(&res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+type SetSubscriptionCategoryRequest struct {
+	// CategoryID is null to make the subscription uncategorized
+	CategoryID *uuid.UUID `json:"category_id"`
+	ID         uuid.UUID  `json:"-"`
+	UserID     uuid.UUID  `json:"-"`
+}
+
+func (s *subscriptionService) SetSubscriptionCategory(
+	ctx context.Context,
+	req *SetSubscriptionCategoryRequest,
+) (*models.Subscription, error) {
+	existed, err := s.getUserSubscription(ctx, req.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CategoryID != nil {
+		err := s.checkUserCategory(ctx, *req.CategoryID, req.UserID)
+		if err != nil {
+			return nil, err
+		}
 	}
-	row, err := s.repo.CreateSubscription(ctx, arg)
+
+	row, err := s.repo.UpdateSubscriptionCategory(ctx, &repo.UpdateSubscriptionCategoryParams{
+		ID:         existed.ID,
+		UserID:     existed.UserID,
+		CategoryID: req.CategoryID,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -142,9 +673,186 @@ func (s *subscriptionService) CreateSubscription(
 	return &res, nil
 }
 
+type SetSubscriptionTagsRequest struct {
+	// TagIDs replaces all tags of the subscription, an empty list removes all of them
+	TagIDs []uuid.UUID `json:"tag_ids" validate:"max=50"`
+	ID     uuid.UUID   `json:"-"       validate:"-"`
+	UserID uuid.UUID   `json:"-"       validate:"-"`
+}
+
+func (s *subscriptionService) SetSubscriptionTags(
+	ctx context.Context,
+	req *SetSubscriptionTagsRequest,
+) (*models.Subscription, error) {
+	existed, err := s.getUserSubscription(ctx, req.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	tagIDs := make([]uuid.UUID, 0, len(req.TagIDs))
+	for _, tagID := range req.TagIDs {
+		if !slices.Contains(tagIDs, tagID) {
+			tagIDs = append(tagIDs, tagID)
+		}
+	}
+
+	// every tag must belong to the user, otherwise we would link tags of other users
+	if len(tagIDs) > 0 {
+		count, err := s.tagRepo.CountUserTags(ctx, req.UserID, tagIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		if count != len(tagIDs) {
+			return nil, apperror.ErrTagNotFound
+		}
+	}
+
+	err = s.tagRepo.SetSubscriptionTags(ctx, existed.ID, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.getUserSubscription(ctx, existed.ID, existed.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var res models.Subscription
+	err = row.MapToSubscriptionModel(&res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *subscriptionService) DeleteSubscription(
+	ctx context.Context,
+	id uuid.UUID,
+	userID uuid.UUID,
+) error {
+	existed, err := s.getUserSubscription(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.DeleteSubscription(ctx, existed.ID, existed.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrSubscriptionNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *subscriptionService) RestoreSubscription(
+	ctx context.Context,
+	id uuid.UUID,
+	userID uuid.UUID,
+) (*models.Subscription, error) {
+	row, err := s.repo.RestoreSubscription(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	var res models.Subscription
+	err = row.MapToSubscriptionModel(&res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// checkUserCategory returns ErrCategoryNotFound when the category does not belong to userID
+func (s *subscriptionService) checkUserCategory(
+	ctx context.Context,
+	categoryID uuid.UUID,
+	userID uuid.UUID,
+) error {
+	category, err := s.categoryRepo.GetCategoryByID(ctx, categoryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrCategoryNotFound
+		}
+		return err
+	}
+
+	if category.UserID != userID {
+		return apperror.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// getUserSubscription returns the subscription with given id only if it belongs to userID,
+// otherwise it returns ErrSubscriptionNotFound so we do not leak other users' subscriptions.
+//
+// Every endpoint reading or mutating a single subscription must go through this check.
+func (s *subscriptionService) getUserSubscription(
+	ctx context.Context,
+	id uuid.UUID,
+	userID uuid.UUID,
+) (*repo.SubscriptionRow, error) {
+	row, err := s.repo.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	// subscriptions in trash can only be restored
+	if row.UserID != userID || row.DeletedAt != nil {
+		return nil, apperror.ErrSubscriptionNotFound
+	}
+
+	return row, nil
+}
+
 func calculateEndDate(
 	startDate models.SubscriptionTime,
 	duration enums.Duration,
+	anchorDay int,
 ) time.Time {
-	return duration.AddDurationToTime(time.Time(startDate))
+	return duration.AddDurationToTimeWithAnchor(time.Time(startDate), anchorDay)
+}
+
+// prefillFromCatalog fills the fields of req left empty with the ones of its catalog plan,
+// the price is only taken when neither amount nor currency is sent
+func (s *subscriptionService) prefillFromCatalog(
+	ctx context.Context,
+	req *CreateSubscriptionRequest,
+) error {
+	row, err := s.catalogRepo.GetCatalogPlan(ctx, *req.CatalogPlanID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrCatalogPlanNotFound
+		}
+		return err
+	}
+
+	if req.Name == "" {
+		req.Name = row.ServiceName + " " + row.Plan.Name
+		if len(req.Name) > 50 {
+			req.Name = row.ServiceName
+		}
+	}
+
+	if req.Duration == (enums.Duration{}) {
+		req.Duration = row.Plan.Duration
+	}
+
+	if req.Amount == nil && req.Currency == "" && row.Plan.Amount != nil && row.Plan.Currency != nil {
+		amount := *row.Plan.Amount
+		req.Amount, req.Currency = &amount, *row.Plan.Currency
+	}
+
+	return nil
 }
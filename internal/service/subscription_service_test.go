@@ -0,0 +1,1168 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func randomSubscriptionRow(userID uuid.UUID) *repo.SubscriptionRow {
+	startDate := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	return &repo.SubscriptionRow{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      "Netflix Premium",
+		StartDate: startDate,
+		EndDate:   startDate.AddDate(0, 1, 0),
+		Duration:  enums.Monthly,
+	}
+}
+
+func TestGetSubscription(t *testing.T) {
+	userID, memberID := uuid.New(), uuid.New()
+	row := randomSubscriptionRow(userID)
+
+	notes := "family plan"
+	categoryID, paymentMethodID := uuid.New(), uuid.New()
+	sharedRow := randomSubscriptionRow(userID)
+	sharedRow.Notes = &notes
+	sharedRow.CategoryID = &categoryID
+	sharedRow.TagIDs = []uuid.UUID{uuid.New()}
+	sharedRow.PaymentMethodID = &paymentMethodID
+
+	deletedAt := time.Now()
+	deletedRow := randomSubscriptionRow(userID)
+	deletedRow.DeletedAt = &deletedAt
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockSubscriptionRepo)
+		checkResponse func(*testing.T, *models.Subscription, error)
+		name          string
+		id            uuid.UUID
+		userID        uuid.UUID
+	}{
+		{
+			name:   "Get subscription successfully",
+			id:     row.ID,
+			userID: userID,
+			buildStubs: func(repo *mocks.MockSubscriptionRepo) {
+				repo.EXPECT().
+					GetOwnedOrSharedSubscription(gomock.Any(), row.ID, userID).
+					Times(1).
+					Return(row, nil)
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.NoError(t, err)
+
+				require.NotNil(t, response)
+
+				require.Equal(t, row.ID, response.ID)
+				require.Equal(t, row.UserID, response.UserID)
+				require.Equal(t, row.Name, response.Name)
+				require.Equal(t, models.SubscriptionStatusActive, response.Status)
+				require.False(t, response.IsShared)
+			},
+		},
+		{
+			name:   "Owner sees private fields",
+			id:     sharedRow.ID,
+			userID: userID,
+			buildStubs: func(repo *mocks.MockSubscriptionRepo) {
+				repo.EXPECT().
+					GetOwnedOrSharedSubscription(gomock.Any(), sharedRow.ID, userID).
+					Times(1).
+					Return(sharedRow, nil)
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.False(t, response.IsShared)
+				require.Equal(t, notes, *response.Notes)
+				require.Equal(t, categoryID, *response.CategoryID)
+				require.Equal(t, sharedRow.TagIDs, response.TagIDs)
+				require.Equal(t, paymentMethodID, *response.PaymentMethodID)
+			},
+		},
+		{
+			name:   "Shared with an accepted member",
+			id:     sharedRow.ID,
+			userID: memberID,
+			buildStubs: func(repo *mocks.MockSubscriptionRepo) {
+				repo.EXPECT().
+					GetOwnedOrSharedSubscription(gomock.Any(), sharedRow.ID, memberID).
+					Times(1).
+					Return(sharedRow, nil)
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, sharedRow.ID, response.ID)
+				require.True(t, response.IsShared)
+
+				// the notes, category, tags and payment method are private to the owner
+				require.Nil(t, response.Notes)
+				require.Nil(t, response.CategoryID)
+				require.Empty(t, response.TagIDs)
+				require.Nil(t, response.PaymentMethodID)
+			},
+		},
+		{
+			name:   "Subscription of another user",
+			id:     row.ID,
+			userID: memberID,
+			buildStubs: func(repo *mocks.MockSubscriptionRepo) {
+				repo.EXPECT().
+					GetOwnedOrSharedSubscription(gomock.Any(), row.ID, memberID).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.Nil(t, response)
+				require.ErrorIs(t, err, apperror.ErrSubscriptionNotFound)
+			},
+		},
+		{
+			name:   "Subscription in trash",
+			id:     deletedRow.ID,
+			userID: userID,
+			buildStubs: func(repo *mocks.MockSubscriptionRepo) {
+				repo.EXPECT().
+					GetOwnedOrSharedSubscription(gomock.Any(), deletedRow.ID, userID).
+					Times(1).
+					Return(deletedRow, nil)
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.Nil(t, response)
+				require.ErrorIs(t, err, apperror.ErrSubscriptionNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSubscriptionRepo(ctrl)
+			tc.buildStubs(mockRepo)
+			subscriptionService := service.NewSubscriptionService(
+				mockRepo,
+				mocks.NewMockCategoryRepo(ctrl),
+				mocks.NewMockTagRepo(ctrl),
+				mocks.NewMockSubscriptionPriceRepo(ctrl),
+				mocks.NewMockCatalogRepo(ctrl),
+				&fakeTransaction{},
+			)
+
+			response, err := subscriptionService.GetSubscription(
+				context.Background(),
+				tc.id,
+				tc.userID,
+			)
+
+			tc.checkResponse(t, response, err)
+		})
+	}
+}
+
+func TestCreateSubscriptionWithTrial(t *testing.T) {
+	userID := uuid.New()
+	startDate := models.SubscriptionTime(time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC))
+	trialEndDate := models.SubscriptionTime(time.Date(2025, time.January, 29, 0, 0, 0, 0, time.UTC))
+	postTrialAmount := int64(1599)
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockSubscriptionRepo, *mocks.MockSubscriptionPriceRepo)
+		checkResponse func(*testing.T, *models.Subscription, error)
+		trialEndDate  *models.SubscriptionTime
+		name          string
+	}{
+		{
+			name:         "Trial ends the first period",
+			trialEndDate: &trialEndDate,
+			buildStubs: func(r *mocks.MockSubscriptionRepo, priceRepo *mocks.MockSubscriptionPriceRepo) {
+				r.EXPECT().
+					CreateSubscription(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(createSubscriptionRow)
+				// the first charge is the post trial price when the trial ends
+				priceRepo.EXPECT().
+					CreateSubscriptionPrice(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(
+						_ context.Context,
+						arg *repo.CreateSubscriptionPriceParams,
+					) (*models.SubscriptionPrice, error) {
+						require.Equal(t, postTrialAmount, arg.Amount)
+						require.Equal(t, "USD", arg.Currency)
+						require.Equal(t, time.Time(trialEndDate), arg.EffectiveFrom)
+						return &models.SubscriptionPrice{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.True(t, response.InTrial)
+				require.Equal(t, trialEndDate, response.EndDate)
+				require.Equal(t, trialEndDate, *response.TrialEndDate)
+				require.Equal(t, 29, response.BillingAnchorDay)
+				require.Equal(t, postTrialAmount, *response.PostTrialAmount)
+			},
+		},
+		{
+			name: "No trial",
+			buildStubs: func(r *mocks.MockSubscriptionRepo, priceRepo *mocks.MockSubscriptionPriceRepo) {
+				r.EXPECT().
+					CreateSubscription(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(createSubscriptionRow)
+				// the post trial price is not charged without a trial and the amount is unknown
+				priceRepo.EXPECT().CreateSubscriptionPrice(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.False(t, response.InTrial)
+				require.Nil(t, response.TrialEndDate)
+				require.Equal(t, 15, response.BillingAnchorDay)
+				require.Equal(
+					t,
+					time.Date(2025, time.February, 15, 0, 0, 0, 0, time.UTC),
+					time.Time(response.EndDate),
+				)
+			},
+		},
+		{
+			name:         "Trial ends before start date",
+			trialEndDate: &startDate,
+			buildStubs: func(r *mocks.MockSubscriptionRepo, priceRepo *mocks.MockSubscriptionPriceRepo) {
+				r.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).Times(0)
+				priceRepo.EXPECT().CreateSubscriptionPrice(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.Nil(t, response)
+				require.ErrorIs(t, err, apperror.ErrInvalidTrialEndDate)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSubscriptionRepo(ctrl)
+			mockPriceRepo := mocks.NewMockSubscriptionPriceRepo(ctrl)
+			tc.buildStubs(mockRepo, mockPriceRepo)
+			subscriptionService := service.NewSubscriptionService(
+				mockRepo,
+				mocks.NewMockCategoryRepo(ctrl),
+				mocks.NewMockTagRepo(ctrl),
+				mockPriceRepo,
+				mocks.NewMockCatalogRepo(ctrl),
+				&fakeTransaction{},
+			)
+
+			response, err := subscriptionService.CreateSubscription(
+				context.Background(),
+				&service.CreateSubscriptionRequest{
+					UserID:          userID,
+					Name:            "Netflix Premium",
+					StartDate:       startDate,
+					Duration:        enums.Monthly,
+					TrialEndDate:    tc.trialEndDate,
+					PostTrialAmount: &postTrialAmount,
+					Currency:        "USD",
+				},
+			)
+
+			tc.checkResponse(t, response, err)
+		})
+	}
+}
+
+func TestCreateSubscriptionFromCatalog(t *testing.T) {
+	userID := uuid.New()
+	planID := uuid.New()
+	startDate := models.SubscriptionTime(time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC))
+	planAmount, planCurrency := int64(1199), "USD"
+	amount := int64(999)
+
+	planRow := &repo.CatalogPlanRow{
+		ServiceName: "Spotify",
+		Plan: &models.CatalogPlan{
+			ID:       planID,
+			Slug:     "premium-individual",
+			Name:     "Premium Individual",
+			Amount:   &planAmount,
+			Currency: &planCurrency,
+			Duration: enums.Monthly,
+		},
+	}
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockCatalogRepo, *mocks.MockSubscriptionRepo)
+		checkResponse func(*testing.T, *models.Subscription, error)
+		name          string
+		req           service.CreateSubscriptionRequest
+	}{
+		{
+			name: "Prefill empty fields",
+			req:  service.CreateSubscriptionRequest{},
+			buildStubs: func(c *mocks.MockCatalogRepo, r *mocks.MockSubscriptionRepo) {
+				c.EXPECT().GetCatalogPlan(gomock.Any(), planID).Times(1).Return(planRow, nil)
+				r.EXPECT().
+					CreateSubscription(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(createSubscriptionRow)
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "Spotify Premium Individual", response.Name)
+				require.Equal(t, enums.Monthly, response.Duration)
+				require.Equal(t, planAmount, *response.Amount)
+				require.Equal(t, planCurrency, *response.Currency)
+			},
+		},
+		{
+			name: "Sent fields are kept",
+			req: service.CreateSubscriptionRequest{
+				Name:     "Family music",
+				Duration: enums.Yearly,
+				Amount:   &amount,
+				Currency: "EUR",
+			},
+			buildStubs: func(c *mocks.MockCatalogRepo, r *mocks.MockSubscriptionRepo) {
+				c.EXPECT().GetCatalogPlan(gomock.Any(), planID).Times(1).Return(planRow, nil)
+				r.EXPECT().
+					CreateSubscription(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(createSubscriptionRow)
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "Family music", response.Name)
+				require.Equal(t, enums.Yearly, response.Duration)
+				require.Equal(t, amount, *response.Amount)
+				require.Equal(t, "EUR", *response.Currency)
+			},
+		},
+		{
+			name: "Plan not found",
+			req:  service.CreateSubscriptionRequest{},
+			buildStubs: func(c *mocks.MockCatalogRepo, r *mocks.MockSubscriptionRepo) {
+				c.EXPECT().GetCatalogPlan(gomock.Any(), planID).Times(1).Return(nil, sql.ErrNoRows)
+				r.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.Nil(t, response)
+				require.ErrorIs(t, err, apperror.ErrCatalogPlanNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSubscriptionRepo(ctrl)
+			mockCatalogRepo := mocks.NewMockCatalogRepo(ctrl)
+			mockPriceRepo := mocks.NewMockSubscriptionPriceRepo(ctrl)
+			mockPriceRepo.EXPECT().
+				CreateSubscriptionPrice(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(&models.SubscriptionPrice{}, nil)
+			tc.buildStubs(mockCatalogRepo, mockRepo)
+
+			subscriptionService := service.NewSubscriptionService(
+				mockRepo,
+				mocks.NewMockCategoryRepo(ctrl),
+				mocks.NewMockTagRepo(ctrl),
+				mockPriceRepo,
+				mockCatalogRepo,
+				&fakeTransaction{},
+			)
+
+			req := tc.req
+			req.UserID = userID
+			req.StartDate = startDate
+			req.CatalogPlanID = &planID
+
+			response, err := subscriptionService.CreateSubscription(context.Background(), &req)
+			tc.checkResponse(t, response, err)
+		})
+	}
+}
+
+// createSubscriptionRow returns the row the database would return for arg
+func createSubscriptionRow(
+	_ context.Context,
+	arg repo.CreateSubscriptionParams,
+) (*repo.SubscriptionRow, error) {
+	return &repo.SubscriptionRow{
+		ID:               arg.ID,
+		UserID:           arg.UserID,
+		Name:             arg.Name,
+		StartDate:        arg.StartDate,
+		EndDate:          arg.EndDate,
+		Duration:         arg.Duration,
+		Amount:           arg.Amount,
+		Currency:         arg.Currency,
+		TrialEndDate:     arg.TrialEndDate,
+		PostTrialAmount:  arg.PostTrialAmount,
+		BillingAnchorDay: arg.BillingAnchorDay,
+	}, nil
+}
+
+func TestSetSubscriptionTags(t *testing.T) {
+	userID := uuid.New()
+	row := randomSubscriptionRow(userID)
+	tagID := uuid.New()
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockSubscriptionRepo, *mocks.MockTagRepo)
+		checkResponse func(*testing.T, *models.Subscription, error)
+		name          string
+		tagIDs        []uuid.UUID
+	}{
+		{
+			name:   "Duplicated tags are set once",
+			tagIDs: []uuid.UUID{tagID, tagID},
+			buildStubs: func(repo *mocks.MockSubscriptionRepo, tagRepo *mocks.MockTagRepo) {
+				repo.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(2).Return(row, nil)
+				tagRepo.EXPECT().
+					CountUserTags(gomock.Any(), userID, []uuid.UUID{tagID}).
+					Times(1).
+					Return(1, nil)
+				tagRepo.EXPECT().
+					SetSubscriptionTags(gomock.Any(), row.ID, []uuid.UUID{tagID}).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, row.ID, response.ID)
+			},
+		},
+		{
+			name:   "Empty list removes all tags",
+			tagIDs: []uuid.UUID{},
+			buildStubs: func(repo *mocks.MockSubscriptionRepo, tagRepo *mocks.MockTagRepo) {
+				repo.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(2).Return(row, nil)
+				tagRepo.EXPECT().CountUserTags(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				tagRepo.EXPECT().
+					SetSubscriptionTags(gomock.Any(), row.ID, []uuid.UUID{}).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+			},
+		},
+		{
+			name:   "Tag of another user",
+			tagIDs: []uuid.UUID{tagID},
+			buildStubs: func(repo *mocks.MockSubscriptionRepo, tagRepo *mocks.MockTagRepo) {
+				repo.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				tagRepo.EXPECT().
+					CountUserTags(gomock.Any(), userID, []uuid.UUID{tagID}).
+					Times(1).
+					Return(0, nil)
+				tagRepo.EXPECT().SetSubscriptionTags(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, response *models.Subscription, err error) {
+				require.Nil(t, response)
+				require.ErrorIs(t, err, apperror.ErrTagNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSubscriptionRepo(ctrl)
+			mockTagRepo := mocks.NewMockTagRepo(ctrl)
+			tc.buildStubs(mockRepo, mockTagRepo)
+			subscriptionService := service.NewSubscriptionService(
+				mockRepo,
+				mocks.NewMockCategoryRepo(ctrl),
+				mockTagRepo,
+				mocks.NewMockSubscriptionPriceRepo(ctrl),
+				mocks.NewMockCatalogRepo(ctrl),
+				&fakeTransaction{},
+			)
+
+			response, err := subscriptionService.SetSubscriptionTags(
+				context.Background(),
+				&service.SetSubscriptionTagsRequest{
+					ID:     row.ID,
+					UserID: userID,
+					TagIDs: tc.tagIDs,
+				},
+			)
+
+			tc.checkResponse(t, response, err)
+		})
+	}
+}
+
+func TestGetAllSubscriptionsCursor(t *testing.T) {
+	userID := uuid.New()
+
+	rows := make([]*repo.SubscriptionRow, 3)
+	for i := range rows {
+		rows[i] = randomSubscriptionRow(userID)
+		rows[i].Name = fmt.Sprintf("Subscription %d", i)
+	}
+
+	// a cursor returned for the first page sorted by name
+	firstPage, err := getAllSubscriptionsWithRows(
+		t,
+		&service.GetAllSubscriptionsRequest{UserID: userID, Limit: 2, Sort: "name"},
+		rows,
+		nil,
+	)
+	require.NoError(t, err)
+	require.Len(t, firstPage.Subscriptions, 2)
+	require.Nil(t, firstPage.PrevCursor)
+	require.NotNil(t, firstPage.NextCursor)
+
+	testCases := []struct {
+		checkResponse func(*testing.T, *service.GetAllSubscriptionsResponse, error)
+		req           *service.GetAllSubscriptionsRequest
+		expected      *repo.SubscriptionCursor
+		name          string
+		rows          []*repo.SubscriptionRow
+	}{
+		{
+			name: "Last page after cursor",
+			req: &service.GetAllSubscriptionsRequest{
+				UserID: userID,
+				Limit:  2,
+				Sort:   "name",
+				After:  *firstPage.NextCursor,
+			},
+			rows:     rows[2:],
+			expected: &repo.SubscriptionCursor{Value: rows[1].Name, ID: rows[1].ID},
+			checkResponse: func(t *testing.T, res *service.GetAllSubscriptionsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.Subscriptions, 1)
+				require.Nil(t, res.NextCursor)
+				require.NotNil(t, res.PrevCursor)
+			},
+		},
+		{
+			name: "First page before cursor",
+			req: &service.GetAllSubscriptionsRequest{
+				UserID: userID,
+				Limit:  1,
+				Sort:   "name",
+				Before: *firstPage.NextCursor,
+			},
+			rows: rows[:2],
+			expected: &repo.SubscriptionCursor{
+				Value:  rows[1].Name,
+				ID:     rows[1].ID,
+				Before: true,
+			},
+			checkResponse: func(t *testing.T, res *service.GetAllSubscriptionsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.Subscriptions, 1)
+				require.Equal(t, rows[1].ID, res.Subscriptions[0].ID)
+				require.NotNil(t, res.NextCursor)
+				require.NotNil(t, res.PrevCursor)
+			},
+		},
+		{
+			name: "Cursor used with another sort",
+			req: &service.GetAllSubscriptionsRequest{
+				UserID: userID,
+				Limit:  2,
+				Sort:   "price",
+				After:  *firstPage.NextCursor,
+			},
+			checkResponse: func(t *testing.T, res *service.GetAllSubscriptionsResponse, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrInvalidCursor)
+			},
+		},
+		{
+			name: "Both after and before",
+			req: &service.GetAllSubscriptionsRequest{
+				UserID: userID,
+				Limit:  2,
+				Sort:   "name",
+				After:  *firstPage.NextCursor,
+				Before: *firstPage.NextCursor,
+			},
+			checkResponse: func(t *testing.T, res *service.GetAllSubscriptionsResponse, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrInvalidCursor)
+			},
+		},
+		{
+			name: "Malformed cursor",
+			req: &service.GetAllSubscriptionsRequest{
+				UserID: userID,
+				Limit:  2,
+				After:  "not a cursor",
+			},
+			checkResponse: func(t *testing.T, res *service.GetAllSubscriptionsResponse, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrInvalidCursor)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := getAllSubscriptionsWithRows(t, tc.req, tc.rows, tc.expected)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestGetAllSubscriptionsShared(t *testing.T) {
+	userID := uuid.New()
+
+	notes := "family plan"
+	categoryID, paymentMethodID := uuid.New(), uuid.New()
+	rows := []*repo.SubscriptionRow{randomSubscriptionRow(userID), randomSubscriptionRow(uuid.New())}
+	for _, row := range rows {
+		row.Notes = &notes
+		row.CategoryID = &categoryID
+		row.TagIDs = []uuid.UUID{uuid.New()}
+		row.PaymentMethodID = &paymentMethodID
+	}
+
+	res, err := getAllSubscriptionsWithRows(
+		t,
+		&service.GetAllSubscriptionsRequest{UserID: userID, Limit: 10},
+		rows,
+		nil,
+	)
+	require.NoError(t, err)
+	require.Len(t, res.Subscriptions, 2)
+
+	owned := res.Subscriptions[0]
+	require.False(t, owned.IsShared)
+	require.Equal(t, notes, *owned.Notes)
+	require.Equal(t, categoryID, *owned.CategoryID)
+	require.Equal(t, rows[0].TagIDs, owned.TagIDs)
+	require.Equal(t, paymentMethodID, *owned.PaymentMethodID)
+
+	// the notes, category, tags and payment method of the owner are not shared with members
+	shared := res.Subscriptions[1]
+	require.True(t, shared.IsShared)
+	require.Nil(t, shared.Notes)
+	require.Nil(t, shared.CategoryID)
+	require.Empty(t, shared.TagIDs)
+	require.Nil(t, shared.PaymentMethodID)
+}
+
+// getAllSubscriptionsWithRows lists subscriptions with a repo returning rows,
+// the repo is only expected to be called with expectedCursor when rows is not nil
+func getAllSubscriptionsWithRows(
+	t *testing.T,
+	req *service.GetAllSubscriptionsRequest,
+	rows []*repo.SubscriptionRow,
+	expectedCursor *repo.SubscriptionCursor,
+) (*service.GetAllSubscriptionsResponse, error) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSubscriptionRepo(ctrl)
+	if rows != nil {
+		mockRepo.EXPECT().
+			GetAllSubscriptions(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(
+				_ context.Context,
+				arg *repo.GetAllSubscriptionsParams,
+			) ([]*repo.SubscriptionRow, int, error) {
+				require.Equal(t, req.Limit+1, arg.Limit)
+				require.Equal(t, expectedCursor, arg.Cursor)
+				return rows, len(rows), nil
+			})
+	}
+
+	subscriptionService := service.NewSubscriptionService(
+		mockRepo,
+		mocks.NewMockCategoryRepo(ctrl),
+		mocks.NewMockTagRepo(ctrl),
+		mocks.NewMockSubscriptionPriceRepo(ctrl),
+		mocks.NewMockCatalogRepo(ctrl),
+		&fakeTransaction{},
+	)
+
+	return subscriptionService.GetAllSubscriptions(context.Background(), req)
+}
+
+func TestGetUpcomingRenewals(t *testing.T) {
+	userID := uuid.New()
+	from := time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.May, 31, 0, 0, 0, 0, time.UTC)
+
+	usd, eur := "USD", "EUR"
+	weeklyAmount, monthlyAmount, trialAmount, postTrialAmount := int64(500), int64(1000), int64(0), int64(1599)
+
+	weekly := randomSubscriptionRow(userID)
+	weekly.Name = "Meal kit"
+	weekly.Duration = enums.Weekly
+	weekly.EndDate = time.Date(2025, time.May, 2, 0, 0, 0, 0, time.UTC)
+	weekly.BillingAnchorDay = 2
+	weekly.Amount, weekly.Currency = &weeklyAmount, &usd
+
+	// renews on the last day of the month, April 30 is before the range
+	monthly := randomSubscriptionRow(userID)
+	monthly.Name = "Gym"
+	monthly.EndDate = time.Date(2025, time.April, 30, 0, 0, 0, 0, time.UTC)
+	monthly.BillingAnchorDay = 31
+	monthly.Amount, monthly.Currency = &monthlyAmount, &usd
+
+	trialEndDate := time.Date(2025, time.May, 9, 0, 0, 0, 0, time.UTC)
+	trial := randomSubscriptionRow(userID)
+	trial.Name = "Streaming"
+	trial.EndDate = trialEndDate
+	trial.TrialEndDate = &trialEndDate
+	trial.BillingAnchorDay = 9
+	trial.Amount, trial.Currency, trial.PostTrialAmount = &trialAmount, &eur, &postTrialAmount
+
+	unpriced := randomSubscriptionRow(userID)
+	unpriced.Name = "Newspaper"
+	unpriced.EndDate = time.Date(2025, time.May, 16, 0, 0, 0, 0, time.UTC)
+	unpriced.BillingAnchorDay = 16
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSubscriptionRepo(ctrl)
+	mockRepo.EXPECT().
+		GetUpcomingSubscriptions(gomock.Any(), userID, to.AddDate(0, 0, 1)).
+		Times(1).
+		Return([]*repo.SubscriptionRow{monthly, weekly, trial, unpriced}, nil)
+
+	// the weekly plan costs more from its renewal of May 16
+	increasedAmount := int64(600)
+	mockPriceRepo := mocks.NewMockSubscriptionPriceRepo(ctrl)
+	mockPriceRepo.EXPECT().
+		GetScheduledSubscriptionPrices(gomock.Any(), userID).
+		Times(1).
+		Return([]*models.SubscriptionPrice{{
+			ID:             uuid.New(),
+			SubscriptionID: weekly.ID,
+			Amount:         increasedAmount,
+			Currency:       usd,
+			EffectiveFrom:  models.SubscriptionTime(time.Date(2025, time.May, 16, 0, 0, 0, 0, time.UTC)),
+		}}, nil)
+
+	subscriptionService := service.NewSubscriptionService(
+		mockRepo,
+		mocks.NewMockCategoryRepo(ctrl),
+		mocks.NewMockTagRepo(ctrl),
+		mockPriceRepo,
+		mocks.NewMockCatalogRepo(ctrl),
+		&fakeTransaction{},
+	)
+
+	res, err := subscriptionService.GetUpcomingRenewals(
+		context.Background(),
+		&service.GetUpcomingRenewalsRequest{From: from, To: to, UserID: userID},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "2025-05-01", res.From)
+	require.Equal(t, "2025-05-31", res.To)
+
+	dates := make([]string, len(res.Days))
+	for i, day := range res.Days {
+		dates[i] = day.Date
+	}
+	require.Equal(
+		t,
+		[]string{"2025-05-02", "2025-05-09", "2025-05-16", "2025-05-23", "2025-05-30", "2025-05-31"},
+		dates,
+	)
+
+	trialDay := res.Days[1]
+	require.Len(t, trialDay.Renewals, 2)
+	require.Equal(t, weekly.ID, trialDay.Renewals[0].SubscriptionID)
+	require.Equal(t, trial.ID, trialDay.Renewals[1].SubscriptionID)
+	require.True(t, trialDay.Renewals[1].TrialEnds)
+	require.Equal(t, postTrialAmount, *trialDay.Renewals[1].Amount)
+	require.Equal(t, []*service.CurrencyTotal{
+		{Currency: eur, Amount: postTrialAmount},
+		{Currency: usd, Amount: weeklyAmount},
+	}, trialDay.Totals)
+
+	unpricedDay := res.Days[2]
+	require.Len(t, unpricedDay.Renewals, 2)
+	require.Equal(t, []*service.CurrencyTotal{{Currency: usd, Amount: increasedAmount}}, unpricedDay.Totals)
+	require.Equal(t, increasedAmount, *res.Days[3].Renewals[0].Amount)
+
+	monthEnd := res.Days[5]
+	require.Len(t, monthEnd.Renewals, 1)
+	require.Equal(t, monthly.ID, monthEnd.Renewals[0].SubscriptionID)
+	require.Equal(t, []*service.CurrencyTotal{{Currency: usd, Amount: monthlyAmount}}, monthEnd.Totals)
+}
+
+// updateSubscriptionRow returns the row the database would return for arg
+func updateSubscriptionRow(
+	_ context.Context,
+	arg *repo.UpdateSubscriptionParams,
+) (*repo.SubscriptionRow, error) {
+	return &repo.SubscriptionRow{
+		ID:               arg.ID,
+		UserID:           arg.UserID,
+		Name:             arg.Name,
+		StartDate:        arg.StartDate,
+		EndDate:          arg.EndDate,
+		Duration:         arg.Duration,
+		Amount:           arg.Amount,
+		Currency:         arg.Currency,
+		PostTrialAmount:  arg.PostTrialAmount,
+		BillingAnchorDay: arg.BillingAnchorDay,
+	}, nil
+}
+
+func TestUpdateSubscription(t *testing.T) {
+	userID := uuid.New()
+	name := "Spotify Family"
+	startDate := models.SubscriptionTime(time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC))
+
+	anchored := func() *repo.SubscriptionRow {
+		row := randomSubscriptionRow(userID)
+		row.BillingAnchorDay = row.StartDate.Day()
+		return row
+	}
+
+	testCases := []struct {
+		row           *repo.SubscriptionRow
+		req           *service.UpdateSubscriptionRequest
+		buildStubs    func(*mocks.MockSubscriptionRepo, *repo.SubscriptionRow)
+		checkResponse func(*testing.T, *repo.SubscriptionRow, *models.Subscription, error)
+		name          string
+		userID        uuid.UUID
+	}{
+		{
+			name:   "Partial body",
+			row:    anchored(),
+			userID: userID,
+			req:    &service.UpdateSubscriptionRequest{Name: &name},
+			buildStubs: func(r *mocks.MockSubscriptionRepo, row *repo.SubscriptionRow) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				r.EXPECT().
+					UpdateSubscription(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(updateSubscriptionRow)
+			},
+			checkResponse: func(t *testing.T, row *repo.SubscriptionRow, res *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, name, res.Name)
+				require.Equal(t, row.StartDate, time.Time(res.StartDate))
+				require.Equal(t, row.EndDate, time.Time(res.EndDate))
+				require.Equal(t, row.Duration, res.Duration)
+				require.Equal(t, row.BillingAnchorDay, res.BillingAnchorDay)
+			},
+		},
+		{
+			name:   "Start date recalculates end date",
+			row:    anchored(),
+			userID: userID,
+			req:    &service.UpdateSubscriptionRequest{StartDate: &startDate},
+			buildStubs: func(r *mocks.MockSubscriptionRepo, row *repo.SubscriptionRow) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				r.EXPECT().
+					UpdateSubscription(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(updateSubscriptionRow)
+			},
+			checkResponse: func(t *testing.T, row *repo.SubscriptionRow, res *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, time.Time(startDate), time.Time(res.StartDate))
+				require.Equal(t, time.Date(2025, time.April, 10, 0, 0, 0, 0, time.UTC), time.Time(res.EndDate))
+				require.Equal(t, 10, res.BillingAnchorDay)
+			},
+		},
+		{
+			name:   "Duration recalculates end date",
+			row:    anchored(),
+			userID: userID,
+			req:    &service.UpdateSubscriptionRequest{Duration: &enums.Yearly},
+			buildStubs: func(r *mocks.MockSubscriptionRepo, row *repo.SubscriptionRow) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				r.EXPECT().
+					UpdateSubscription(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(updateSubscriptionRow)
+			},
+			checkResponse: func(t *testing.T, row *repo.SubscriptionRow, res *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, enums.Yearly, res.Duration)
+				require.Equal(t, row.StartDate, time.Time(res.StartDate))
+				require.Equal(t, row.StartDate.AddDate(1, 0, 0), time.Time(res.EndDate))
+			},
+		},
+		{
+			name:   "Owned by another user",
+			row:    anchored(),
+			userID: uuid.New(),
+			req:    &service.UpdateSubscriptionRequest{Name: &name},
+			buildStubs: func(r *mocks.MockSubscriptionRepo, row *repo.SubscriptionRow) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(row, nil)
+				r.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, row *repo.SubscriptionRow, res *models.Subscription, err error) {
+				require.ErrorIs(t, err, apperror.ErrSubscriptionNotFound)
+				require.Nil(t, res)
+			},
+		},
+		{
+			name:   "Not found",
+			row:    anchored(),
+			userID: userID,
+			req:    &service.UpdateSubscriptionRequest{Name: &name},
+			buildStubs: func(r *mocks.MockSubscriptionRepo, row *repo.SubscriptionRow) {
+				r.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(1).Return(nil, sql.ErrNoRows)
+				r.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, row *repo.SubscriptionRow, res *models.Subscription, err error) {
+				require.ErrorIs(t, err, apperror.ErrSubscriptionNotFound)
+				require.Nil(t, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSubscriptionRepo(ctrl)
+			tc.buildStubs(mockRepo, tc.row)
+
+			subscriptionService := service.NewSubscriptionService(
+				mockRepo,
+				mocks.NewMockCategoryRepo(ctrl),
+				mocks.NewMockTagRepo(ctrl),
+				mocks.NewMockSubscriptionPriceRepo(ctrl),
+				mocks.NewMockCatalogRepo(ctrl),
+				&fakeTransaction{},
+			)
+
+			req := tc.req
+			req.ID, req.UserID = tc.row.ID, tc.userID
+
+			res, err := subscriptionService.UpdateSubscription(context.Background(), req)
+			tc.checkResponse(t, tc.row, res, err)
+		})
+	}
+}
+
+func TestUpdateSubscriptionPrice(t *testing.T) {
+	userID := uuid.New()
+	amount, newAmount, currency := int64(1599), int64(1799), "USD"
+	effectiveDate := models.SubscriptionTime(time.Date(2025, time.January, 20, 0, 0, 0, 0, time.UTC))
+	laterDate := models.SubscriptionTime(time.Date(2025, time.April, 15, 0, 0, 0, 0, time.UTC))
+
+	priced := func() *repo.SubscriptionRow {
+		row := randomSubscriptionRow(userID)
+		row.Amount, row.Currency = &amount, &currency
+		return row
+	}
+
+	testCases := []struct {
+		row           *repo.SubscriptionRow
+		req           *service.UpdateSubscriptionRequest
+		checkPrice    func(*testing.T, *repo.CreateSubscriptionPriceParams)
+		checkResponse func(*testing.T, *models.Subscription, error)
+		name          string
+		// rejected requests never update the subscription
+		rejected bool
+	}{
+		{
+			name: "New price from the next renewal",
+			row:  priced(),
+			req:  &service.UpdateSubscriptionRequest{Amount: &newAmount},
+			checkPrice: func(t *testing.T, arg *repo.CreateSubscriptionPriceParams) {
+				require.Equal(t, newAmount, arg.Amount)
+				require.Equal(t, currency, arg.Currency)
+				require.Equal(t, time.Date(2025, time.February, 15, 0, 0, 0, 0, time.UTC), arg.EffectiveFrom)
+			},
+			checkResponse: func(t *testing.T, res *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, newAmount, *res.Amount)
+			},
+		},
+		{
+			name: "New price from effective date",
+			row:  priced(),
+			req: &service.UpdateSubscriptionRequest{
+				Amount:             &newAmount,
+				PriceEffectiveDate: &effectiveDate,
+			},
+			checkPrice: func(t *testing.T, arg *repo.CreateSubscriptionPriceParams) {
+				require.Equal(t, time.Time(effectiveDate), arg.EffectiveFrom)
+			},
+			checkResponse: func(t *testing.T, res *models.Subscription, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "New price effective after the next renewal keeps the current price",
+			row:  priced(),
+			req: &service.UpdateSubscriptionRequest{
+				Amount:             &newAmount,
+				PriceEffectiveDate: &laterDate,
+			},
+			checkPrice: func(t *testing.T, arg *repo.CreateSubscriptionPriceParams) {
+				require.Equal(t, newAmount, arg.Amount)
+				require.Equal(t, currency, arg.Currency)
+				require.Equal(t, time.Time(laterDate), arg.EffectiveFrom)
+			},
+			checkResponse: func(t *testing.T, res *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, amount, *res.Amount)
+				require.Equal(t, currency, *res.Currency)
+			},
+		},
+		{
+			name: "Post trial price during a trial",
+			row: func() *repo.SubscriptionRow {
+				row := priced()
+				trialEndDate := row.EndDate
+				row.TrialEndDate = &trialEndDate
+				row.PostTrialAmount = &amount
+				return row
+			}(),
+			req: &service.UpdateSubscriptionRequest{Amount: &newAmount},
+			checkPrice: func(t *testing.T, arg *repo.CreateSubscriptionPriceParams) {
+				require.Equal(t, newAmount, arg.Amount)
+			},
+			checkResponse: func(t *testing.T, res *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, newAmount, *res.PostTrialAmount)
+				require.Equal(t, amount, *res.Amount)
+			},
+		},
+		{
+			name: "Same price",
+			row:  priced(),
+			req:  &service.UpdateSubscriptionRequest{Amount: &amount, Currency: &currency},
+			checkResponse: func(t *testing.T, res *models.Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, amount, *res.Amount)
+			},
+		},
+		{
+			name:     "Currency required",
+			rejected: true,
+			row:      randomSubscriptionRow(userID),
+			req:      &service.UpdateSubscriptionRequest{Amount: &newAmount},
+			checkResponse: func(t *testing.T, res *models.Subscription, err error) {
+				require.ErrorIs(t, err, apperror.ErrCurrencyRequired)
+				require.Nil(t, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mockRepo := mocks.NewMockSubscriptionRepo(ctrl)
+			mockRepo.EXPECT().
+				GetSubscriptionByID(gomock.Any(), tc.row.ID).
+				Times(1).
+				Return(tc.row, nil)
+
+			updates := 1
+			if tc.rejected {
+				updates = 0
+			}
+			mockRepo.EXPECT().
+				UpdateSubscription(gomock.Any(), gomock.Any()).
+				Times(updates).
+				DoAndReturn(updateSubscriptionRow)
+
+			mockPriceRepo := mocks.NewMockSubscriptionPriceRepo(ctrl)
+			if tc.checkPrice != nil {
+				mockPriceRepo.EXPECT().
+					CreateSubscriptionPrice(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(
+						_ context.Context,
+						arg *repo.CreateSubscriptionPriceParams,
+					) (*models.SubscriptionPrice, error) {
+						require.Equal(t, tc.row.ID, arg.SubscriptionID)
+						tc.checkPrice(t, arg)
+						return &models.SubscriptionPrice{}, nil
+					})
+			} else {
+				mockPriceRepo.EXPECT().CreateSubscriptionPrice(gomock.Any(), gomock.Any()).Times(0)
+			}
+
+			subscriptionService := service.NewSubscriptionService(
+				mockRepo,
+				mocks.NewMockCategoryRepo(ctrl),
+				mocks.NewMockTagRepo(ctrl),
+				mockPriceRepo,
+				mocks.NewMockCatalogRepo(ctrl),
+				&fakeTransaction{},
+			)
+
+			req := tc.req
+			req.ID, req.UserID = tc.row.ID, userID
+
+			res, err := subscriptionService.UpdateSubscription(context.Background(), req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestGetSubscriptionPrices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	userID := uuid.New()
+	row := randomSubscriptionRow(userID)
+	prices := []*models.SubscriptionPrice{
+		{ID: uuid.New(), SubscriptionID: row.ID, Amount: 1599, Currency: "USD"},
+		{ID: uuid.New(), SubscriptionID: row.ID, Amount: 1799, Currency: "USD"},
+	}
+
+	mockRepo := mocks.NewMockSubscriptionRepo(ctrl)
+	mockRepo.EXPECT().GetSubscriptionByID(gomock.Any(), row.ID).Times(2).Return(row, nil)
+
+	mockPriceRepo := mocks.NewMockSubscriptionPriceRepo(ctrl)
+	mockPriceRepo.EXPECT().GetSubscriptionPrices(gomock.Any(), row.ID).Times(1).Return(prices, nil)
+
+	subscriptionService := service.NewSubscriptionService(
+		mockRepo,
+		mocks.NewMockCategoryRepo(ctrl),
+		mocks.NewMockTagRepo(ctrl),
+		mockPriceRepo,
+		mocks.NewMockCatalogRepo(ctrl),
+		&fakeTransaction{},
+	)
+
+	res, err := subscriptionService.GetSubscriptionPrices(context.Background(), row.ID, userID)
+	require.NoError(t, err)
+	require.Equal(t, prices, res)
+
+	// the history of another user's subscription is not found
+	res, err = subscriptionService.GetSubscriptionPrices(context.Background(), row.ID, uuid.New())
+	require.ErrorIs(t, err, apperror.ErrSubscriptionNotFound)
+	require.Nil(t, res)
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+const upcomingDateLayout = "2006-01-02"
+
+// GetUpcomingRenewalsRequest covers the days From to To, both included
+type GetUpcomingRenewalsRequest struct {
+	From   time.Time
+	To     time.Time
+	UserID uuid.UUID
+}
+
+type GetUpcomingRenewalsResponse struct {
+	From string `json:"from" example:"2025-05-01"`
+	To   string `json:"to"   example:"2025-05-31"`
+	// only days with at least one renewal are listed, in ascending order
+	Days []*UpcomingDay `json:"days"`
+}
+
+type UpcomingDay struct {
+	Date     string             `json:"date"     example:"2025-05-15"`
+	Renewals []*UpcomingRenewal `json:"renewals"`
+	// amounts of the renewals of the day summed per currency, ordered by currency
+	Totals []*CurrencyTotal `json:"totals"`
+}
+
+type UpcomingRenewal struct {
+	Amount         *int64    `json:"amount"          example:"999"`
+	Currency       *string   `json:"currency"        example:"USD"`
+	Name           string    `json:"name"            example:"Netflix"`
+	Duration       string    `json:"duration"        example:"monthly"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	// TrialEnds is true for the renewal which ends a free trial
+	TrialEnds bool `json:"trial_ends"`
+}
+
+type CurrencyTotal struct {
+	Currency string `json:"currency" example:"USD"`
+	Amount   int64  `json:"amount"   example:"1998"`
+}
+
+// GetUpcomingRenewals projects every renewal of the active subscriptions of the user between From and To.
+// A subscription renews at its end date and then every duration after it, so a weekly plan renews
+// several times in a month. Subscriptions set to cancel at period end are not renewed
+func (s *subscriptionService) GetUpcomingRenewals(
+	ctx context.Context,
+	req *GetUpcomingRenewalsRequest,
+) (*GetUpcomingRenewalsResponse, error) {
+	from := truncateToDay(req.From)
+	to := truncateToDay(req.To).AddDate(0, 0, 1)
+
+	rows, err := s.repo.GetUpcomingSubscriptions(ctx, req.UserID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := getScheduledPrices(ctx, s.priceRepo, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make(map[string]*UpcomingDay)
+	for _, row := range rows {
+		for date := row.EndDate; date.Before(to); date = row.Duration.AddDurationToTimeWithAnchor(
+			date,
+			row.BillingAnchorDay,
+		) {
+			if date.Before(from) {
+				continue
+			}
+
+			key := date.Format(upcomingDateLayout)
+			day, ok := days[key]
+			if !ok {
+				day = &UpcomingDay{Date: key}
+				days[key] = day
+			}
+
+			day.Renewals = append(day.Renewals, newUpcomingRenewal(row, date, prices))
+		}
+	}
+
+	res := &GetUpcomingRenewalsResponse{
+		From: from.Format(upcomingDateLayout),
+		To:   req.To.Format(upcomingDateLayout),
+		Days: make([]*UpcomingDay, 0, len(days)),
+	}
+
+	for _, day := range days {
+		sort.SliceStable(day.Renewals, func(i, j int) bool {
+			return day.Renewals[i].Name < day.Renewals[j].Name
+		})
+		day.Totals = sumUpcomingRenewals(day.Renewals)
+
+		res.Days = append(res.Days, day)
+	}
+
+	// dates are formatted as YYYY-MM-DD so they sort as strings
+	sort.Slice(res.Days, func(i, j int) bool {
+		return res.Days[i].Date < res.Days[j].Date
+	})
+
+	return res, nil
+}
+
+// scheduledPrices are the prices taking effect after the current period of a subscription started,
+// by subscription id and oldest first
+type scheduledPrices map[uuid.UUID][]*models.SubscriptionPrice
+
+func getScheduledPrices(
+	ctx context.Context,
+	priceRepo repo.SubscriptionPriceRepo,
+	userID uuid.UUID,
+) (scheduledPrices, error) {
+	list, err := priceRepo.GetScheduledSubscriptionPrices(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(scheduledPrices)
+	for _, price := range list {
+		prices[price.SubscriptionID] = append(prices[price.SubscriptionID], price)
+	}
+
+	return prices, nil
+}
+
+// at returns the scheduled price of a subscription in effect at date, or nil when none took effect yet
+func (p scheduledPrices) at(subscriptionID uuid.UUID, date time.Time) *models.SubscriptionPrice {
+	var price *models.SubscriptionPrice
+	for _, scheduled := range p[subscriptionID] {
+		if time.Time(scheduled.EffectiveFrom).After(date) {
+			break
+		}
+		price = scheduled
+	}
+
+	return price
+}
+
+// newUpcomingRenewal returns the renewal of row on date, a trial is billed its post trial price
+// from the renewal which ends it and a scheduled price from the renewal it takes effect with
+func newUpcomingRenewal(row *repo.SubscriptionRow, date time.Time, prices scheduledPrices) *UpcomingRenewal {
+	renewal := &UpcomingRenewal{
+		SubscriptionID: row.ID,
+		Name:           row.Name,
+		Duration:       row.Duration.String(),
+		Amount:         row.Amount,
+		Currency:       row.Currency,
+	}
+
+	if row.InTrial() {
+		renewal.TrialEnds = date.Equal(row.EndDate)
+		if row.PostTrialAmount != nil {
+			renewal.Amount = row.PostTrialAmount
+		}
+	}
+
+	if price := prices.at(row.ID, date); price != nil {
+		renewal.Amount, renewal.Currency = &price.Amount, &price.Currency
+	}
+
+	return renewal
+}
+
+// renewals without a price are left out of the totals
+func sumUpcomingRenewals(renewals []*UpcomingRenewal) []*CurrencyTotal {
+	totals := []*CurrencyTotal{}
+	for _, renewal := range renewals {
+		if renewal.Amount == nil || renewal.Currency == nil {
+			continue
+		}
+
+		idx := sort.Search(len(totals), func(i int) bool {
+			return totals[i].Currency >= *renewal.Currency
+		})
+		if idx == len(totals) || totals[idx].Currency != *renewal.Currency {
+			totals = append(totals, nil)
+			copy(totals[idx+1:], totals[idx:])
+			totals[idx] = &CurrencyTotal{Currency: *renewal.Currency}
+		}
+
+		totals[idx].Amount += *renewal.Amount
+	}
+
+	return totals
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
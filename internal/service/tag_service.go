@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+)
+
+type TagService interface {
+	GetAllTags(ctx context.Context, userID uuid.UUID) ([]*models.Tag, error)
+	CreateTag(ctx context.Context, req *CreateTagRequest) (*models.Tag, error)
+	UpdateTag(ctx context.Context, req *UpdateTagRequest) (*models.Tag, error)
+	DeleteTag(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+}
+
+type tagService struct {
+	repo repo.TagRepo
+}
+
+func NewTagService(repo repo.TagRepo) *tagService {
+	return &tagService{repo}
+}
+
+func (s *tagService) GetAllTags(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.Tag, error) {
+	return s.repo.GetAllTags(ctx, userID)
+}
+
+type CreateTagRequest struct {
+	Name   string    `json:"name" validate:"required,min=1,max=50" example:"family"`
+	UserID uuid.UUID `json:"-"    validate:"-"`
+}
+
+func (s *tagService) CreateTag(
+	ctx context.Context,
+	req *CreateTagRequest,
+) (*models.Tag, error) {
+	err := s.checkTagNameNotExisted(ctx, req.UserID, req.Name, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateTag(ctx, &repo.CreateTagParams{
+		ID:     id,
+		UserID: req.UserID,
+		Name:   req.Name,
+	})
+}
+
+type UpdateTagRequest struct {
+	Name   string    `json:"name" validate:"required,min=1,max=50" example:"family"`
+	ID     uuid.UUID `json:"-"    validate:"-"`
+	UserID uuid.UUID `json:"-"    validate:"-"`
+}
+
+func (s *tagService) UpdateTag(
+	ctx context.Context,
+	req *UpdateTagRequest,
+) (*models.Tag, error) {
+	err := s.checkTagNameNotExisted(ctx, req.UserID, req.Name, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := s.repo.UpdateTag(ctx, &repo.UpdateTagParams{
+		ID:     req.ID,
+		UserID: req.UserID,
+		Name:   req.Name,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrTagNotFound
+		}
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (s *tagService) DeleteTag(
+	ctx context.Context,
+	id uuid.UUID,
+	userID uuid.UUID,
+) error {
+	err := s.repo.DeleteTag(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrTagNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *tagService) checkTagNameNotExisted(
+	ctx context.Context,
+	userID uuid.UUID,
+	name string,
+	id uuid.UUID,
+) error {
+	return checkNameNotExisted(
+		ctx,
+		s.repo.GetTagByName,
+		func(tag *models.Tag) uuid.UUID { return tag.ID },
+		userID,
+		name,
+		id,
+	)
+}
@@ -0,0 +1,204 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+	"github.com/sangtandoan/subscription_tracker/internal/repo"
+	"github.com/sangtandoan/subscription_tracker/internal/service"
+	"github.com/sangtandoan/subscription_tracker/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateTag(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockTagRepo)
+		checkResponse func(*testing.T, *models.Tag, error)
+		name          string
+	}{
+		{
+			name: "Create tag successfully",
+			buildStubs: func(r *mocks.MockTagRepo) {
+				r.EXPECT().
+					GetTagByName(gomock.Any(), userID, "family").
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+				r.EXPECT().
+					CreateTag(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg *repo.CreateTagParams) (*models.Tag, error) {
+						return &models.Tag{ID: arg.ID, UserID: arg.UserID, Name: arg.Name}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *models.Tag, err error) {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, res.ID)
+				require.Equal(t, userID, res.UserID)
+				require.Equal(t, "family", res.Name)
+			},
+		},
+		{
+			name: "Duplicated name",
+			buildStubs: func(r *mocks.MockTagRepo) {
+				r.EXPECT().
+					GetTagByName(gomock.Any(), userID, "family").
+					Times(1).
+					Return(&models.Tag{ID: uuid.New(), UserID: userID, Name: "family"}, nil)
+				r.EXPECT().CreateTag(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *models.Tag, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrExisted)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockTagRepo(ctrl)
+			tc.buildStubs(mockRepo)
+			tagService := service.NewTagService(mockRepo)
+
+			res, err := tagService.CreateTag(
+				context.Background(),
+				&service.CreateTagRequest{Name: "family", UserID: userID},
+			)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestUpdateTag(t *testing.T) {
+	userID := uuid.New()
+	tag := &models.Tag{ID: uuid.New(), UserID: userID, Name: "family"}
+
+	testCases := []struct {
+		buildStubs    func(*mocks.MockTagRepo)
+		checkResponse func(*testing.T, *models.Tag, error)
+		name          string
+		tagName       string
+		userID        uuid.UUID
+	}{
+		{
+			name:    "Rename tag",
+			userID:  userID,
+			tagName: "work",
+			buildStubs: func(r *mocks.MockTagRepo) {
+				r.EXPECT().
+					GetTagByName(gomock.Any(), userID, "work").
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+				r.EXPECT().
+					UpdateTag(gomock.Any(), &repo.UpdateTagParams{
+						ID:     tag.ID,
+						UserID: userID,
+						Name:   "work",
+					}).
+					Times(1).
+					Return(&models.Tag{ID: tag.ID, UserID: userID, Name: "work"}, nil)
+			},
+			checkResponse: func(t *testing.T, res *models.Tag, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "work", res.Name)
+			},
+		},
+		{
+			name:    "Keep its own name",
+			userID:  userID,
+			tagName: "family",
+			buildStubs: func(r *mocks.MockTagRepo) {
+				r.EXPECT().GetTagByName(gomock.Any(), userID, "family").Times(1).Return(tag, nil)
+				r.EXPECT().UpdateTag(gomock.Any(), gomock.Any()).Times(1).Return(tag, nil)
+			},
+			checkResponse: func(t *testing.T, res *models.Tag, err error) {
+				require.NoError(t, err)
+				require.Equal(t, tag, res)
+			},
+		},
+		{
+			name:    "Duplicated name",
+			userID:  userID,
+			tagName: "shared",
+			buildStubs: func(r *mocks.MockTagRepo) {
+				r.EXPECT().
+					GetTagByName(gomock.Any(), userID, "shared").
+					Times(1).
+					Return(&models.Tag{ID: uuid.New(), UserID: userID, Name: "shared"}, nil)
+				r.EXPECT().UpdateTag(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *models.Tag, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrExisted)
+			},
+		},
+		{
+			name:    "Owned by another user",
+			userID:  uuid.New(),
+			tagName: "work",
+			buildStubs: func(r *mocks.MockTagRepo) {
+				r.EXPECT().
+					GetTagByName(gomock.Any(), gomock.Any(), "work").
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+				r.EXPECT().UpdateTag(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, res *models.Tag, err error) {
+				require.Nil(t, res)
+				require.ErrorIs(t, err, apperror.ErrTagNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockTagRepo(ctrl)
+			tc.buildStubs(mockRepo)
+			tagService := service.NewTagService(mockRepo)
+
+			res, err := tagService.UpdateTag(
+				context.Background(),
+				&service.UpdateTagRequest{ID: tag.ID, UserID: tc.userID, Name: tc.tagName},
+			)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	id := uuid.New()
+
+	mockRepo := mocks.NewMockTagRepo(ctrl)
+	mockRepo.EXPECT().DeleteTag(gomock.Any(), id, userID).Times(1).Return(nil)
+	// a tag of another user is not found
+	mockRepo.EXPECT().
+		DeleteTag(gomock.Any(), id, gomock.Not(userID)).
+		Times(1).
+		Return(sql.ErrNoRows)
+
+	tagService := service.NewTagService(mockRepo)
+
+	err := tagService.DeleteTag(context.Background(), id, uuid.New())
+	require.ErrorIs(t, err, apperror.ErrTagNotFound)
+
+	err = tagService.DeleteTag(context.Background(), id, userID)
+	require.NoError(t, err)
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// bytes of randomness of a secret token
+const secretTokenBytes = 32
+
+// newSecretToken returns a random url safe token for links which can not send the Bearer header,
+// like calendar feeds and export downloads
+func newSecretToken() (string, error) {
+	b := make([]byte, secretTokenBytes)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashSecretToken returns the sha256 hex of a token,
+// only the hash is stored so a leaked database or directory does not expose the links
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/apperror"
+)
+
+// checkNameNotExisted fails with apperror.ErrExisted when another item of the user already has name,
+// it is shared by categories and tags whose names are unique per user.
+// The item being renamed is excluded by its id, which is uuid.Nil on creation
+func checkNameNotExisted[T any](
+	ctx context.Context,
+	getByName func(ctx context.Context, userID uuid.UUID, name string) (*T, error),
+	getID func(*T) uuid.UUID,
+	userID uuid.UUID,
+	name string,
+	id uuid.UUID,
+) error {
+	existed, err := getByName(ctx, userID, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	if existed != nil && getID(existed) != id {
+		return apperror.ErrExisted
+	}
+
+	return nil
+}
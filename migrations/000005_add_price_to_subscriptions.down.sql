@@ -0,0 +1,3 @@
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS amount,
+    DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,5 @@
+-- amount is stored in minor units (e.g. cents), currency is an ISO 4217 code.
+-- Both are nullable so existing subscriptions migrate with an unknown price.
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS amount bigint CHECK (amount >= 0),
+    ADD COLUMN IF NOT EXISTS currency varchar(3);
@@ -0,0 +1,5 @@
+-- cancel_at_period_end keeps the subscription active until end_date,
+-- after that it will not be renewed anymore
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS cancelled_at timestamp,
+    ADD COLUMN IF NOT EXISTS cancel_at_period_end boolean NOT NULL DEFAULT false;
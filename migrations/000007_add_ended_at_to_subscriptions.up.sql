@@ -0,0 +1,5 @@
+-- ended_at is set by the daily job when a cancelled subscription reaches its end_date,
+-- an ended subscription is terminal and is never renewed or reminded again
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS ended_at timestamp;
+
+UPDATE subscriptions SET ended_at = end_date WHERE is_cancelled = true AND end_date < NOW();
@@ -0,0 +1,4 @@
+-- soft delete, rows are moved to trash and purged by the daily job after a retention period
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS deleted_at timestamp;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_deleted_at ON subscriptions (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,19 @@
+CREATE TYPE duration AS ENUM ('weekly', 'monthly', '6 months', 'yearly');
+
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS duration duration;
+
+-- intervals which do not exist in the enum fall back to monthly
+UPDATE subscriptions SET
+    duration = CASE
+        WHEN interval_unit = 'week' AND interval_count = 1 THEN 'weekly'
+        WHEN interval_unit = 'month' AND interval_count = 6 THEN '6 months'
+        WHEN interval_unit = 'year' AND interval_count = 1 THEN 'yearly'
+        ELSE 'monthly'
+    END::duration;
+
+ALTER TABLE subscriptions
+    ALTER COLUMN duration SET NOT NULL,
+    DROP CONSTRAINT IF EXISTS chk_subscriptions_interval_count,
+    DROP CONSTRAINT IF EXISTS chk_subscriptions_interval_unit,
+    DROP COLUMN IF EXISTS interval_count,
+    DROP COLUMN IF EXISTS interval_unit;
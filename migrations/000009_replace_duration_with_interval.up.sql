@@ -0,0 +1,22 @@
+-- duration enum only allowed 4 fixed values, it is replaced by an interval
+-- made of a count and a unit, e.g. every 3 months or every 45 days
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS interval_count integer,
+    ADD COLUMN IF NOT EXISTS interval_unit varchar(5);
+
+UPDATE subscriptions SET
+    interval_count = CASE duration WHEN '6 months' THEN 6 ELSE 1 END,
+    interval_unit = CASE duration
+        WHEN 'weekly' THEN 'week'
+        WHEN 'yearly' THEN 'year'
+        ELSE 'month'
+    END;
+
+ALTER TABLE subscriptions
+    ALTER COLUMN interval_count SET NOT NULL,
+    ALTER COLUMN interval_unit SET NOT NULL,
+    ADD CONSTRAINT chk_subscriptions_interval_count CHECK (interval_count > 0),
+    ADD CONSTRAINT chk_subscriptions_interval_unit CHECK (interval_unit IN ('day', 'week', 'month', 'year')),
+    DROP COLUMN IF EXISTS duration;
+
+DROP TYPE IF EXISTS duration;
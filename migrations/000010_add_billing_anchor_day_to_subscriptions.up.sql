@@ -0,0 +1,10 @@
+-- billing_anchor_day is the day of month a subscription renews on, renewals of monthly and yearly
+-- intervals are clamped to the last day of short months and snap back to this day afterwards.
+-- Rows which already drifted can not be recovered, so we fall back to the day of their current start date
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS billing_anchor_day smallint;
+
+UPDATE subscriptions SET billing_anchor_day = EXTRACT(DAY FROM start_date);
+
+ALTER TABLE subscriptions
+    ALTER COLUMN billing_anchor_day SET NOT NULL,
+    ADD CONSTRAINT chk_subscriptions_billing_anchor_day CHECK (billing_anchor_day BETWEEN 1 AND 31);
@@ -0,0 +1,3 @@
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS trial_end_date,
+    DROP COLUMN IF EXISTS post_trial_amount;
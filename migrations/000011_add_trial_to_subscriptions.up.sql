@@ -0,0 +1,5 @@
+-- a subscription is in its free trial while end_date has not passed trial_end_date,
+-- post_trial_amount is the price it converts to when the trial ends, stored in minor units like amount
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS trial_end_date timestamp,
+    ADD COLUMN IF NOT EXISTS post_trial_amount bigint CHECK (post_trial_amount >= 0);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_subscriptions_category_id;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS category_id;
+
+DROP TABLE IF EXISTS subscription_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS categories;
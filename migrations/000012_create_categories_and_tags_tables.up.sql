@@ -0,0 +1,38 @@
+-- a subscription belongs to at most one category and can have many tags,
+-- both are defined per user so names only need to be unique for the same user
+CREATE TABLE IF NOT EXISTS categories (
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL,
+    name varchar(50) NOT NULL,
+    created_at timestamp DEFAULT NOW(),
+
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL,
+    name varchar(50) NOT NULL,
+    created_at timestamp DEFAULT NOW(),
+
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS subscription_tags (
+    subscription_id uuid NOT NULL,
+    tag_id uuid NOT NULL,
+
+    PRIMARY KEY (subscription_id, tag_id),
+    FOREIGN KEY (subscription_id) REFERENCES subscriptions (id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_tags_tag_id ON subscription_tags (tag_id);
+
+-- deleting a category keeps its subscriptions, they just become uncategorized
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS category_id uuid REFERENCES categories (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_category_id ON subscriptions (category_id);
@@ -0,0 +1,3 @@
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS created_at;
+
+DROP INDEX IF EXISTS idx_subscriptions_name_trgm;
@@ -0,0 +1,14 @@
+-- trigram index backs the case-insensitive name search (ILIKE '%q%')
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_name_trgm ON subscriptions USING GIN (name gin_trgm_ops);
+
+-- created_at is needed to sort subscriptions by creation time,
+-- existing rows fall back to their start date
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS created_at timestamp;
+
+UPDATE subscriptions SET created_at = start_date WHERE created_at IS NULL;
+
+ALTER TABLE subscriptions
+    ALTER COLUMN created_at SET DEFAULT NOW(),
+    ALTER COLUMN created_at SET NOT NULL;
@@ -0,0 +1,2 @@
+-- every list and analytics query filters subscriptions of one user
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON subscriptions (user_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+-- only a sha256 hash of the calendar feed token is stored, the token itself is shown once when it is rotated
+ALTER TABLE users ADD COLUMN IF NOT EXISTS calendar_token_hash varchar(64) UNIQUE;
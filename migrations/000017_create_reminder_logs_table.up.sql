@@ -0,0 +1,16 @@
+-- every reminder email sent by the daily job, the subscription name is copied
+-- so the history is kept after the subscription is purged
+CREATE TABLE IF NOT EXISTS reminder_logs (
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL,
+    subscription_id uuid,
+    subscription_name varchar(255) NOT NULL,
+    kind varchar(20) NOT NULL,
+    days_before smallint NOT NULL,
+    sent_at timestamp NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (subscription_id) REFERENCES subscriptions (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminder_logs_user_id ON reminder_logs (user_id);
@@ -0,0 +1,33 @@
+-- price history of subscriptions, a price is charged from effective_from until the next price.
+-- A subscription has at most one price per day, a second change the same day replaces the first
+CREATE TABLE IF NOT EXISTS subscription_prices (
+    id uuid PRIMARY KEY,
+    subscription_id uuid NOT NULL,
+    amount bigint NOT NULL CHECK (amount >= 0),
+    currency varchar(3) NOT NULL,
+    effective_from date NOT NULL,
+    created_at timestamp NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (subscription_id) REFERENCES subscriptions (id) ON DELETE CASCADE,
+    UNIQUE (subscription_id, effective_from)
+);
+
+-- the current price of existing subscriptions starts their history,
+-- a subscription in its free trial is first charged when the trial ends
+INSERT INTO subscription_prices (id, subscription_id, amount, currency, effective_from)
+SELECT
+    gen_random_uuid(),
+    id,
+    CASE
+        WHEN trial_end_date IS NOT NULL AND end_date <= trial_end_date
+            THEN COALESCE(post_trial_amount, amount)
+        ELSE amount
+    END,
+    currency,
+    CASE
+        WHEN trial_end_date IS NOT NULL AND end_date <= trial_end_date THEN trial_end_date
+        ELSE start_date
+    END
+FROM subscriptions
+WHERE currency IS NOT NULL AND COALESCE(amount, post_trial_amount) IS NOT NULL
+ON CONFLICT DO NOTHING;
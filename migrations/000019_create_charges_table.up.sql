@@ -0,0 +1,21 @@
+-- ledger of the charge of every billing period, rows are never updated.
+-- A period is booked once per subscription, so re-running the daily job or a backfill never double-books.
+-- The subscription name is copied so the ledger is kept after the subscription is purged,
+-- amount and currency are null when the price of the period is unknown
+CREATE TABLE IF NOT EXISTS charges (
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL,
+    subscription_id uuid,
+    subscription_name varchar(255) NOT NULL,
+    period_start date NOT NULL,
+    period_end date NOT NULL,
+    amount bigint CHECK (amount >= 0),
+    currency varchar(3),
+    created_at timestamp NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (subscription_id) REFERENCES subscriptions (id) ON DELETE SET NULL,
+    UNIQUE (subscription_id, period_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_charges_user_id_period_start ON charges (user_id, period_start);
@@ -0,0 +1,30 @@
+-- monthly budgets of users per currency, a budget with an interval only covers the subscriptions
+-- billed at this interval, a budget without interval covers every subscription
+CREATE TABLE IF NOT EXISTS budgets (
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL,
+    interval_count integer CHECK (interval_count > 0),
+    interval_unit varchar(5) CHECK (interval_unit IN ('day', 'week', 'month', 'year')),
+    amount bigint NOT NULL CHECK (amount > 0),
+    currency varchar(3) NOT NULL,
+    created_at timestamp NOT NULL DEFAULT NOW(),
+    updated_at timestamp NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT chk_budgets_interval CHECK ((interval_count IS NULL) = (interval_unit IS NULL))
+);
+
+-- a user has a single budget per currency and interval
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_budgets_user_id_currency_interval
+ON budgets (user_id, currency, (COALESCE(interval_unit, '')), (COALESCE(interval_count, 0)));
+
+-- alerts sent by the daily job, a threshold is alerted once per budget and month
+CREATE TABLE IF NOT EXISTS budget_alerts (
+    budget_id uuid NOT NULL,
+    month date NOT NULL,
+    threshold smallint NOT NULL,
+    sent_at timestamp NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (budget_id, month, threshold),
+    FOREIGN KEY (budget_id) REFERENCES budgets (id) ON DELETE CASCADE
+);
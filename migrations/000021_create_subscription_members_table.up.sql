@@ -0,0 +1,20 @@
+-- users a subscription is shared with, the owner of the subscription pays it
+-- and every member who accepted owes a share of each billing period,
+-- either a percent of the price or a fixed amount in minor units
+CREATE TABLE IF NOT EXISTS subscription_members (
+    id uuid PRIMARY KEY,
+    subscription_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    share_percent smallint CHECK (share_percent BETWEEN 1 AND 100),
+    share_amount bigint CHECK (share_amount >= 0),
+    status varchar(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined')),
+    invited_at timestamp NOT NULL DEFAULT NOW(),
+    responded_at timestamp,
+
+    FOREIGN KEY (subscription_id) REFERENCES subscriptions (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT chk_subscription_members_share CHECK ((share_percent IS NULL) <> (share_amount IS NULL)),
+    UNIQUE (subscription_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_members_user_id ON subscription_members (user_id);
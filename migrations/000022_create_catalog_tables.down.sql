@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS catalog_plans;
+DROP TABLE IF EXISTS catalog_services;
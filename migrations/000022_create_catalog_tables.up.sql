@@ -0,0 +1,29 @@
+-- well-known services with their default plans, seeded from the built-in catalog on startup
+-- and editable by admins afterwards, slugs identify the seeded rows
+CREATE TABLE IF NOT EXISTS catalog_services (
+    id uuid PRIMARY KEY,
+    slug varchar(100) NOT NULL UNIQUE,
+    name varchar(50) NOT NULL,
+    logo_url text NOT NULL DEFAULT '',
+    cancel_url text NOT NULL DEFAULT '',
+    created_at timestamp NOT NULL DEFAULT NOW(),
+    updated_at timestamp NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_catalog_services_lower_name ON catalog_services (lower(name));
+
+-- amount is in minor units of currency, a plan without a known price has neither
+CREATE TABLE IF NOT EXISTS catalog_plans (
+    id uuid PRIMARY KEY,
+    service_id uuid NOT NULL,
+    slug varchar(100) NOT NULL,
+    name varchar(50) NOT NULL,
+    amount bigint CHECK (amount >= 0),
+    currency varchar(3),
+    interval_count integer NOT NULL CHECK (interval_count > 0),
+    interval_unit varchar(5) NOT NULL CHECK (interval_unit IN ('day', 'week', 'month', 'year')),
+
+    FOREIGN KEY (service_id) REFERENCES catalog_services (id) ON DELETE CASCADE,
+    CONSTRAINT chk_catalog_plans_price CHECK ((amount IS NULL) = (currency IS NULL)),
+    UNIQUE (service_id, slug)
+);
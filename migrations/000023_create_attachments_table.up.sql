@@ -0,0 +1,19 @@
+-- files attached to subscriptions like invoices and receipts, their content is kept in the blob storage
+-- under storage_key. Attachments of purged subscriptions lose their subscription
+-- and are deleted from the storage by the daily job
+CREATE TABLE IF NOT EXISTS attachments (
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL,
+    subscription_id uuid,
+    file_name varchar(255) NOT NULL,
+    content_type varchar(100) NOT NULL,
+    size bigint NOT NULL CHECK (size >= 0),
+    storage_key text NOT NULL UNIQUE,
+    created_at timestamp NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (subscription_id) REFERENCES subscriptions (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_subscription_id ON attachments (subscription_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_user_id ON attachments (user_id);
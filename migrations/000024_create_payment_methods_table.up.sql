@@ -0,0 +1,24 @@
+-- cards users pay their subscriptions with, only the last four digits are kept, never the full number.
+-- A card expires at the end of its expiry month, expiry_reminded_at is set once the owner was warned
+-- and reset when the expiry is updated
+CREATE TABLE IF NOT EXISTS payment_methods (
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL,
+    label varchar(50) NOT NULL,
+    brand varchar(20) NOT NULL,
+    last4 char(4) NOT NULL CHECK (last4 ~ '^[0-9]{4}$'),
+    expiry_month smallint NOT NULL CHECK (expiry_month BETWEEN 1 AND 12),
+    expiry_year smallint NOT NULL CHECK (expiry_year BETWEEN 2000 AND 2100),
+    expiry_reminded_at timestamp,
+    created_at timestamp NOT NULL DEFAULT NOW(),
+    updated_at timestamp NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_methods_user_id ON payment_methods (user_id);
+
+ALTER TABLE subscriptions
+ADD COLUMN IF NOT EXISTS payment_method_id uuid REFERENCES payment_methods (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_payment_method_id ON subscriptions (payment_method_id);
@@ -0,0 +1,5 @@
+-- slugs of catalog services deleted by an admin, so seeding on startup does not add them again
+CREATE TABLE IF NOT EXISTS catalog_deleted_slugs (
+    slug varchar(100) PRIMARY KEY,
+    deleted_at timestamp NOT NULL DEFAULT NOW()
+);
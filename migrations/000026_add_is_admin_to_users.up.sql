@@ -0,0 +1,3 @@
+-- only set by the operator, e.g. UPDATE users SET is_admin = true WHERE email = '...';
+-- emails are not verified on sign up, so they cannot grant admin rights on their own
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_admin boolean NOT NULL DEFAULT false;
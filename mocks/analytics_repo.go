@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repo/analytics_repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repo/analytics_repo.go -destination=./mocks/analytics_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	repo "github.com/sangtandoan/subscription_tracker/internal/repo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAnalyticsRepo is a mock of AnalyticsRepo interface.
+type MockAnalyticsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockAnalyticsRepoMockRecorder is the mock recorder for MockAnalyticsRepo.
+type MockAnalyticsRepoMockRecorder struct {
+	mock *MockAnalyticsRepo
+}
+
+// NewMockAnalyticsRepo creates a new mock instance.
+func NewMockAnalyticsRepo(ctrl *gomock.Controller) *MockAnalyticsRepo {
+	mock := &MockAnalyticsRepo{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsRepo) EXPECT() *MockAnalyticsRepoMockRecorder {
+	return m.recorder
+}
+
+// CountUnpricedSubscriptions mocks base method.
+func (m *MockAnalyticsRepo) CountUnpricedSubscriptions(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnpricedSubscriptions", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnpricedSubscriptions indicates an expected call of CountUnpricedSubscriptions.
+func (mr *MockAnalyticsRepoMockRecorder) CountUnpricedSubscriptions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnpricedSubscriptions", reflect.TypeOf((*MockAnalyticsRepo)(nil).CountUnpricedSubscriptions), ctx, userID)
+}
+
+// GetSpendByDuration mocks base method.
+func (m *MockAnalyticsRepo) GetSpendByDuration(ctx context.Context, userID uuid.UUID) ([]*repo.SpendByDurationRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpendByDuration", ctx, userID)
+	ret0, _ := ret[0].([]*repo.SpendByDurationRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpendByDuration indicates an expected call of GetSpendByDuration.
+func (mr *MockAnalyticsRepoMockRecorder) GetSpendByDuration(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpendByDuration", reflect.TypeOf((*MockAnalyticsRepo)(nil).GetSpendByDuration), ctx, userID)
+}
+
+// GetTopSpendSubscriptions mocks base method.
+func (m *MockAnalyticsRepo) GetTopSpendSubscriptions(ctx context.Context, userID uuid.UUID, limit int) ([]*repo.TopSpendSubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopSpendSubscriptions", ctx, userID, limit)
+	ret0, _ := ret[0].([]*repo.TopSpendSubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopSpendSubscriptions indicates an expected call of GetTopSpendSubscriptions.
+func (mr *MockAnalyticsRepoMockRecorder) GetTopSpendSubscriptions(ctx, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopSpendSubscriptions", reflect.TypeOf((*MockAnalyticsRepo)(nil).GetTopSpendSubscriptions), ctx, userID, limit)
+}
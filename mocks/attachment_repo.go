@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repo/attachment_repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repo/attachment_repo.go -destination=./mocks/attachment_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/sangtandoan/subscription_tracker/internal/models"
+	repo "github.com/sangtandoan/subscription_tracker/internal/repo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAttachmentRepo is a mock of AttachmentRepo interface.
+type MockAttachmentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockAttachmentRepoMockRecorder is the mock recorder for MockAttachmentRepo.
+type MockAttachmentRepoMockRecorder struct {
+	mock *MockAttachmentRepo
+}
+
+// NewMockAttachmentRepo creates a new mock instance.
+func NewMockAttachmentRepo(ctrl *gomock.Controller) *MockAttachmentRepo {
+	mock := &MockAttachmentRepo{ctrl: ctrl}
+	mock.recorder = &MockAttachmentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentRepo) EXPECT() *MockAttachmentRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateAttachment mocks base method.
+func (m *MockAttachmentRepo) CreateAttachment(ctx context.Context, arg *repo.CreateAttachmentParams) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, arg)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockAttachmentRepoMockRecorder) CreateAttachment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockAttachmentRepo)(nil).CreateAttachment), ctx, arg)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachmentRepo) DeleteAttachment(ctx context.Context, id, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentRepoMockRecorder) DeleteAttachment(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachmentRepo)(nil).DeleteAttachment), ctx, id, userID)
+}
+
+// GetAttachment mocks base method.
+func (m *MockAttachmentRepo) GetAttachment(ctx context.Context, id, userID uuid.UUID) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockAttachmentRepoMockRecorder) GetAttachment(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockAttachmentRepo)(nil).GetAttachment), ctx, id, userID)
+}
+
+// GetAttachments mocks base method.
+func (m *MockAttachmentRepo) GetAttachments(ctx context.Context, subscriptionID uuid.UUID) ([]*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", ctx, subscriptionID)
+	ret0, _ := ret[0].([]*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockAttachmentRepoMockRecorder) GetAttachments(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockAttachmentRepo)(nil).GetAttachments), ctx, subscriptionID)
+}
+
+// GetOrphanAttachments mocks base method.
+func (m *MockAttachmentRepo) GetOrphanAttachments(ctx context.Context) ([]*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrphanAttachments", ctx)
+	ret0, _ := ret[0].([]*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrphanAttachments indicates an expected call of GetOrphanAttachments.
+func (mr *MockAttachmentRepoMockRecorder) GetOrphanAttachments(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrphanAttachments", reflect.TypeOf((*MockAttachmentRepo)(nil).GetOrphanAttachments), ctx)
+}
+
+// GetUserAttachments mocks base method.
+func (m *MockAttachmentRepo) GetUserAttachments(ctx context.Context, userID uuid.UUID) ([]*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAttachments", ctx, userID)
+	ret0, _ := ret[0].([]*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAttachments indicates an expected call of GetUserAttachments.
+func (mr *MockAttachmentRepoMockRecorder) GetUserAttachments(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAttachments", reflect.TypeOf((*MockAttachmentRepo)(nil).GetUserAttachments), ctx, userID)
+}
+
+// GetUserAttachmentsSize mocks base method.
+func (m *MockAttachmentRepo) GetUserAttachmentsSize(ctx context.Context, userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAttachmentsSize", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAttachmentsSize indicates an expected call of GetUserAttachmentsSize.
+func (mr *MockAttachmentRepoMockRecorder) GetUserAttachmentsSize(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAttachmentsSize", reflect.TypeOf((*MockAttachmentRepo)(nil).GetUserAttachmentsSize), ctx, userID)
+}
@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repo/authProvider_repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repo/authProvider_repo.go -destination=./mocks/auth_provider_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/sangtandoan/subscription_tracker/internal/models"
+	repo "github.com/sangtandoan/subscription_tracker/internal/repo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthProviderRepo is a mock of AuthProviderRepo interface.
+type MockAuthProviderRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthProviderRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthProviderRepoMockRecorder is the mock recorder for MockAuthProviderRepo.
+type MockAuthProviderRepoMockRecorder struct {
+	mock *MockAuthProviderRepo
+}
+
+// NewMockAuthProviderRepo creates a new mock instance.
+func NewMockAuthProviderRepo(ctrl *gomock.Controller) *MockAuthProviderRepo {
+	mock := &MockAuthProviderRepo{ctrl: ctrl}
+	mock.recorder = &MockAuthProviderRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthProviderRepo) EXPECT() *MockAuthProviderRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateAuthProvider mocks base method.
+func (m *MockAuthProviderRepo) CreateAuthProvider(ctx context.Context, arg *repo.CreateAuthProviderParams) (*models.AuthProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuthProvider", ctx, arg)
+	ret0, _ := ret[0].(*models.AuthProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuthProvider indicates an expected call of CreateAuthProvider.
+func (mr *MockAuthProviderRepoMockRecorder) CreateAuthProvider(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthProvider", reflect.TypeOf((*MockAuthProviderRepo)(nil).CreateAuthProvider), ctx, arg)
+}
+
+// GetAuthProvidersByUserID mocks base method.
+func (m *MockAuthProviderRepo) GetAuthProvidersByUserID(ctx context.Context, userID uuid.UUID) ([]*models.AuthProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthProvidersByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*models.AuthProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthProvidersByUserID indicates an expected call of GetAuthProvidersByUserID.
+func (mr *MockAuthProviderRepoMockRecorder) GetAuthProvidersByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthProvidersByUserID", reflect.TypeOf((*MockAuthProviderRepo)(nil).GetAuthProvidersByUserID), ctx, userID)
+}
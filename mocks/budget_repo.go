@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repo/budget_repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repo/budget_repo.go -destination=./mocks/budget_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	models "github.com/sangtandoan/subscription_tracker/internal/models"
+	repo "github.com/sangtandoan/subscription_tracker/internal/repo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBudgetRepo is a mock of BudgetRepo interface.
+type MockBudgetRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockBudgetRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockBudgetRepoMockRecorder is the mock recorder for MockBudgetRepo.
+type MockBudgetRepoMockRecorder struct {
+	mock *MockBudgetRepo
+}
+
+// NewMockBudgetRepo creates a new mock instance.
+func NewMockBudgetRepo(ctrl *gomock.Controller) *MockBudgetRepo {
+	mock := &MockBudgetRepo{ctrl: ctrl}
+	mock.recorder = &MockBudgetRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBudgetRepo) EXPECT() *MockBudgetRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateBudgetAlert mocks base method.
+func (m *MockBudgetRepo) CreateBudgetAlert(ctx context.Context, budgetID uuid.UUID, month time.Time, threshold int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBudgetAlert", ctx, budgetID, month, threshold)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBudgetAlert indicates an expected call of CreateBudgetAlert.
+func (mr *MockBudgetRepoMockRecorder) CreateBudgetAlert(ctx, budgetID, month, threshold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBudgetAlert", reflect.TypeOf((*MockBudgetRepo)(nil).CreateBudgetAlert), ctx, budgetID, month, threshold)
+}
+
+// DeleteBudget mocks base method.
+func (m *MockBudgetRepo) DeleteBudget(ctx context.Context, id, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBudget", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBudget indicates an expected call of DeleteBudget.
+func (mr *MockBudgetRepoMockRecorder) DeleteBudget(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBudget", reflect.TypeOf((*MockBudgetRepo)(nil).DeleteBudget), ctx, id, userID)
+}
+
+// DeleteBudgetAlert mocks base method.
+func (m *MockBudgetRepo) DeleteBudgetAlert(ctx context.Context, budgetID uuid.UUID, month time.Time, threshold int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBudgetAlert", ctx, budgetID, month, threshold)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBudgetAlert indicates an expected call of DeleteBudgetAlert.
+func (mr *MockBudgetRepoMockRecorder) DeleteBudgetAlert(ctx, budgetID, month, threshold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBudgetAlert", reflect.TypeOf((*MockBudgetRepo)(nil).DeleteBudgetAlert), ctx, budgetID, month, threshold)
+}
+
+// GetBudgetUserIDs mocks base method.
+func (m *MockBudgetRepo) GetBudgetUserIDs(ctx context.Context) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudgetUserIDs", ctx)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBudgetUserIDs indicates an expected call of GetBudgetUserIDs.
+func (mr *MockBudgetRepoMockRecorder) GetBudgetUserIDs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgetUserIDs", reflect.TypeOf((*MockBudgetRepo)(nil).GetBudgetUserIDs), ctx)
+}
+
+// GetBudgets mocks base method.
+func (m *MockBudgetRepo) GetBudgets(ctx context.Context, userID uuid.UUID) ([]*models.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudgets", ctx, userID)
+	ret0, _ := ret[0].([]*models.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBudgets indicates an expected call of GetBudgets.
+func (mr *MockBudgetRepoMockRecorder) GetBudgets(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgets", reflect.TypeOf((*MockBudgetRepo)(nil).GetBudgets), ctx, userID)
+}
+
+// SetBudget mocks base method.
+func (m *MockBudgetRepo) SetBudget(ctx context.Context, arg *repo.SetBudgetParams) (*models.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBudget", ctx, arg)
+	ret0, _ := ret[0].(*models.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBudget indicates an expected call of SetBudget.
+func (mr *MockBudgetRepoMockRecorder) SetBudget(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBudget", reflect.TypeOf((*MockBudgetRepo)(nil).SetBudget), ctx, arg)
+}
@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repo/catalog_repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repo/catalog_repo.go -destination=./mocks/catalog_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/sangtandoan/subscription_tracker/internal/models"
+	repo "github.com/sangtandoan/subscription_tracker/internal/repo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCatalogRepo is a mock of CatalogRepo interface.
+type MockCatalogRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockCatalogRepoMockRecorder is the mock recorder for MockCatalogRepo.
+type MockCatalogRepoMockRecorder struct {
+	mock *MockCatalogRepo
+}
+
+// NewMockCatalogRepo creates a new mock instance.
+func NewMockCatalogRepo(ctrl *gomock.Controller) *MockCatalogRepo {
+	mock := &MockCatalogRepo{ctrl: ctrl}
+	mock.recorder = &MockCatalogRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogRepo) EXPECT() *MockCatalogRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateCatalogEntry mocks base method.
+func (m *MockCatalogRepo) CreateCatalogEntry(ctx context.Context, arg *repo.SaveCatalogEntryParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCatalogEntry", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCatalogEntry indicates an expected call of CreateCatalogEntry.
+func (mr *MockCatalogRepoMockRecorder) CreateCatalogEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCatalogEntry", reflect.TypeOf((*MockCatalogRepo)(nil).CreateCatalogEntry), ctx, arg)
+}
+
+// DeleteCatalogEntry mocks base method.
+func (m *MockCatalogRepo) DeleteCatalogEntry(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCatalogEntry", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCatalogEntry indicates an expected call of DeleteCatalogEntry.
+func (mr *MockCatalogRepoMockRecorder) DeleteCatalogEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCatalogEntry", reflect.TypeOf((*MockCatalogRepo)(nil).DeleteCatalogEntry), ctx, id)
+}
+
+// DeleteCatalogPlans mocks base method.
+func (m *MockCatalogRepo) DeleteCatalogPlans(ctx context.Context, serviceID uuid.UUID, keepSlugs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCatalogPlans", ctx, serviceID, keepSlugs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCatalogPlans indicates an expected call of DeleteCatalogPlans.
+func (mr *MockCatalogRepoMockRecorder) DeleteCatalogPlans(ctx, serviceID, keepSlugs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCatalogPlans", reflect.TypeOf((*MockCatalogRepo)(nil).DeleteCatalogPlans), ctx, serviceID, keepSlugs)
+}
+
+// GetCatalogEntry mocks base method.
+func (m *MockCatalogRepo) GetCatalogEntry(ctx context.Context, id uuid.UUID) (*models.CatalogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogEntry", ctx, id)
+	ret0, _ := ret[0].(*models.CatalogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogEntry indicates an expected call of GetCatalogEntry.
+func (mr *MockCatalogRepoMockRecorder) GetCatalogEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogEntry", reflect.TypeOf((*MockCatalogRepo)(nil).GetCatalogEntry), ctx, id)
+}
+
+// GetCatalogPlan mocks base method.
+func (m *MockCatalogRepo) GetCatalogPlan(ctx context.Context, id uuid.UUID) (*repo.CatalogPlanRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogPlan", ctx, id)
+	ret0, _ := ret[0].(*repo.CatalogPlanRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogPlan indicates an expected call of GetCatalogPlan.
+func (mr *MockCatalogRepoMockRecorder) GetCatalogPlan(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogPlan", reflect.TypeOf((*MockCatalogRepo)(nil).GetCatalogPlan), ctx, id)
+}
+
+// SaveCatalogPlan mocks base method.
+func (m *MockCatalogRepo) SaveCatalogPlan(ctx context.Context, arg *repo.SaveCatalogPlanParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCatalogPlan", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCatalogPlan indicates an expected call of SaveCatalogPlan.
+func (mr *MockCatalogRepoMockRecorder) SaveCatalogPlan(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCatalogPlan", reflect.TypeOf((*MockCatalogRepo)(nil).SaveCatalogPlan), ctx, arg)
+}
+
+// SearchCatalog mocks base method.
+func (m *MockCatalogRepo) SearchCatalog(ctx context.Context, query string, limit int) ([]*models.CatalogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCatalog", ctx, query, limit)
+	ret0, _ := ret[0].([]*models.CatalogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCatalog indicates an expected call of SearchCatalog.
+func (mr *MockCatalogRepoMockRecorder) SearchCatalog(ctx, query, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCatalog", reflect.TypeOf((*MockCatalogRepo)(nil).SearchCatalog), ctx, query, limit)
+}
+
+// SeedCatalogEntry mocks base method.
+func (m *MockCatalogRepo) SeedCatalogEntry(ctx context.Context, arg *repo.SaveCatalogEntryParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeedCatalogEntry", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SeedCatalogEntry indicates an expected call of SeedCatalogEntry.
+func (mr *MockCatalogRepoMockRecorder) SeedCatalogEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeedCatalogEntry", reflect.TypeOf((*MockCatalogRepo)(nil).SeedCatalogEntry), ctx, arg)
+}
+
+// UpdateCatalogEntry mocks base method.
+func (m *MockCatalogRepo) UpdateCatalogEntry(ctx context.Context, arg *repo.SaveCatalogEntryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCatalogEntry", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCatalogEntry indicates an expected call of UpdateCatalogEntry.
+func (mr *MockCatalogRepoMockRecorder) UpdateCatalogEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCatalogEntry", reflect.TypeOf((*MockCatalogRepo)(nil).UpdateCatalogEntry), ctx, arg)
+}
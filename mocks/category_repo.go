@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repo/category_repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repo/category_repo.go -destination=./mocks/category_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/sangtandoan/subscription_tracker/internal/models"
+	repo "github.com/sangtandoan/subscription_tracker/internal/repo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCategoryRepo is a mock of CategoryRepo interface.
+type MockCategoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockCategoryRepoMockRecorder is the mock recorder for MockCategoryRepo.
+type MockCategoryRepoMockRecorder struct {
+	mock *MockCategoryRepo
+}
+
+// NewMockCategoryRepo creates a new mock instance.
+func NewMockCategoryRepo(ctrl *gomock.Controller) *MockCategoryRepo {
+	mock := &MockCategoryRepo{ctrl: ctrl}
+	mock.recorder = &MockCategoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryRepo) EXPECT() *MockCategoryRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *MockCategoryRepo) CreateCategory(ctx context.Context, arg *repo.CreateCategoryParams) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, arg)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockCategoryRepoMockRecorder) CreateCategory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryRepo)(nil).CreateCategory), ctx, arg)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategoryRepo) DeleteCategory(ctx context.Context, id, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategoryRepoMockRecorder) DeleteCategory(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryRepo)(nil).DeleteCategory), ctx, id, userID)
+}
+
+// GetAllCategories mocks base method.
+func (m *MockCategoryRepo) GetAllCategories(ctx context.Context, userID uuid.UUID) ([]*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCategories", ctx, userID)
+	ret0, _ := ret[0].([]*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCategories indicates an expected call of GetAllCategories.
+func (mr *MockCategoryRepoMockRecorder) GetAllCategories(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockCategoryRepo)(nil).GetAllCategories), ctx, userID)
+}
+
+// GetCategoryByID mocks base method.
+func (m *MockCategoryRepo) GetCategoryByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryByID", ctx, id)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryByID indicates an expected call of GetCategoryByID.
+func (mr *MockCategoryRepoMockRecorder) GetCategoryByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByID", reflect.TypeOf((*MockCategoryRepo)(nil).GetCategoryByID), ctx, id)
+}
+
+// GetCategoryByName mocks base method.
+func (m *MockCategoryRepo) GetCategoryByName(ctx context.Context, userID uuid.UUID, name string) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryByName", ctx, userID, name)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryByName indicates an expected call of GetCategoryByName.
+func (mr *MockCategoryRepoMockRecorder) GetCategoryByName(ctx, userID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByName", reflect.TypeOf((*MockCategoryRepo)(nil).GetCategoryByName), ctx, userID, name)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCategoryRepo) UpdateCategory(ctx context.Context, arg *repo.UpdateCategoryParams) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, arg)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCategoryRepoMockRecorder) UpdateCategory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryRepo)(nil).UpdateCategory), ctx, arg)
+}
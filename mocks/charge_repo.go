@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repo/charge_repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repo/charge_repo.go -destination=./mocks/charge_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/sangtandoan/subscription_tracker/internal/models"
+	repo "github.com/sangtandoan/subscription_tracker/internal/repo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChargeRepo is a mock of ChargeRepo interface.
+type MockChargeRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockChargeRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockChargeRepoMockRecorder is the mock recorder for MockChargeRepo.
+type MockChargeRepoMockRecorder struct {
+	mock *MockChargeRepo
+}
+
+// NewMockChargeRepo creates a new mock instance.
+func NewMockChargeRepo(ctrl *gomock.Controller) *MockChargeRepo {
+	mock := &MockChargeRepo{ctrl: ctrl}
+	mock.recorder = &MockChargeRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChargeRepo) EXPECT() *MockChargeRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateCharge mocks base method.
+func (m *MockChargeRepo) CreateCharge(ctx context.Context, arg *repo.CreateChargeParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCharge", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCharge indicates an expected call of CreateCharge.
+func (mr *MockChargeRepoMockRecorder) CreateCharge(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCharge", reflect.TypeOf((*MockChargeRepo)(nil).CreateCharge), ctx, arg)
+}
+
+// GetCharges mocks base method.
+func (m *MockChargeRepo) GetCharges(ctx context.Context, arg *repo.GetChargesParams) ([]*models.Charge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCharges", ctx, arg)
+	ret0, _ := ret[0].([]*models.Charge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCharges indicates an expected call of GetCharges.
+func (mr *MockChargeRepoMockRecorder) GetCharges(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCharges", reflect.TypeOf((*MockChargeRepo)(nil).GetCharges), ctx, arg)
+}
@@ -0,0 +1,163 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repo/payment_method_repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repo/payment_method_repo.go -destination=./mocks/payment_method_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	models "github.com/sangtandoan/subscription_tracker/internal/models"
+	repo "github.com/sangtandoan/subscription_tracker/internal/repo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentMethodRepo is a mock of PaymentMethodRepo interface.
+type MockPaymentMethodRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentMethodRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentMethodRepoMockRecorder is the mock recorder for MockPaymentMethodRepo.
+type MockPaymentMethodRepoMockRecorder struct {
+	mock *MockPaymentMethodRepo
+}
+
+// NewMockPaymentMethodRepo creates a new mock instance.
+func NewMockPaymentMethodRepo(ctrl *gomock.Controller) *MockPaymentMethodRepo {
+	mock := &MockPaymentMethodRepo{ctrl: ctrl}
+	mock.recorder = &MockPaymentMethodRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentMethodRepo) EXPECT() *MockPaymentMethodRepoMockRecorder {
+	return m.recorder
+}
+
+// CreatePaymentMethod mocks base method.
+func (m *MockPaymentMethodRepo) CreatePaymentMethod(ctx context.Context, arg *repo.SavePaymentMethodParams) (*models.PaymentMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentMethod", ctx, arg)
+	ret0, _ := ret[0].(*models.PaymentMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentMethod indicates an expected call of CreatePaymentMethod.
+func (mr *MockPaymentMethodRepoMockRecorder) CreatePaymentMethod(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentMethod", reflect.TypeOf((*MockPaymentMethodRepo)(nil).CreatePaymentMethod), ctx, arg)
+}
+
+// DeletePaymentMethod mocks base method.
+func (m *MockPaymentMethodRepo) DeletePaymentMethod(ctx context.Context, id, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePaymentMethod", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePaymentMethod indicates an expected call of DeletePaymentMethod.
+func (mr *MockPaymentMethodRepoMockRecorder) DeletePaymentMethod(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaymentMethod", reflect.TypeOf((*MockPaymentMethodRepo)(nil).DeletePaymentMethod), ctx, id, userID)
+}
+
+// GetExpiringPaymentMethods mocks base method.
+func (m *MockPaymentMethodRepo) GetExpiringPaymentMethods(ctx context.Context, from, to time.Time) ([]*models.PaymentMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiringPaymentMethods", ctx, from, to)
+	ret0, _ := ret[0].([]*models.PaymentMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiringPaymentMethods indicates an expected call of GetExpiringPaymentMethods.
+func (mr *MockPaymentMethodRepoMockRecorder) GetExpiringPaymentMethods(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiringPaymentMethods", reflect.TypeOf((*MockPaymentMethodRepo)(nil).GetExpiringPaymentMethods), ctx, from, to)
+}
+
+// GetPaymentMethod mocks base method.
+func (m *MockPaymentMethodRepo) GetPaymentMethod(ctx context.Context, id, userID uuid.UUID) (*models.PaymentMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentMethod", ctx, id, userID)
+	ret0, _ := ret[0].(*models.PaymentMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentMethod indicates an expected call of GetPaymentMethod.
+func (mr *MockPaymentMethodRepoMockRecorder) GetPaymentMethod(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentMethod", reflect.TypeOf((*MockPaymentMethodRepo)(nil).GetPaymentMethod), ctx, id, userID)
+}
+
+// GetPaymentMethods mocks base method.
+func (m *MockPaymentMethodRepo) GetPaymentMethods(ctx context.Context, userID uuid.UUID) ([]*models.PaymentMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentMethods", ctx, userID)
+	ret0, _ := ret[0].([]*models.PaymentMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentMethods indicates an expected call of GetPaymentMethods.
+func (mr *MockPaymentMethodRepoMockRecorder) GetPaymentMethods(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentMethods", reflect.TypeOf((*MockPaymentMethodRepo)(nil).GetPaymentMethods), ctx, userID)
+}
+
+// MarkPaymentMethodReminded mocks base method.
+func (m *MockPaymentMethodRepo) MarkPaymentMethodReminded(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPaymentMethodReminded", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkPaymentMethodReminded indicates an expected call of MarkPaymentMethodReminded.
+func (mr *MockPaymentMethodRepoMockRecorder) MarkPaymentMethodReminded(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPaymentMethodReminded", reflect.TypeOf((*MockPaymentMethodRepo)(nil).MarkPaymentMethodReminded), ctx, id)
+}
+
+// UnmarkPaymentMethodReminded mocks base method.
+func (m *MockPaymentMethodRepo) UnmarkPaymentMethodReminded(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmarkPaymentMethodReminded", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmarkPaymentMethodReminded indicates an expected call of UnmarkPaymentMethodReminded.
+func (mr *MockPaymentMethodRepoMockRecorder) UnmarkPaymentMethodReminded(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarkPaymentMethodReminded", reflect.TypeOf((*MockPaymentMethodRepo)(nil).UnmarkPaymentMethodReminded), ctx, id)
+}
+
+// UpdatePaymentMethod mocks base method.
+func (m *MockPaymentMethodRepo) UpdatePaymentMethod(ctx context.Context, arg *repo.SavePaymentMethodParams) (*models.PaymentMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentMethod", ctx, arg)
+	ret0, _ := ret[0].(*models.PaymentMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentMethod indicates an expected call of UpdatePaymentMethod.
+func (mr *MockPaymentMethodRepoMockRecorder) UpdatePaymentMethod(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentMethod", reflect.TypeOf((*MockPaymentMethodRepo)(nil).UpdatePaymentMethod), ctx, arg)
+}
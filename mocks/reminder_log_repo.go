@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repo/reminder_log_repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repo/reminder_log_repo.go -destination=./mocks/reminder_log_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/sangtandoan/subscription_tracker/internal/models"
+	repo "github.com/sangtandoan/subscription_tracker/internal/repo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReminderLogRepo is a mock of ReminderLogRepo interface.
+type MockReminderLogRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderLogRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockReminderLogRepoMockRecorder is the mock recorder for MockReminderLogRepo.
+type MockReminderLogRepoMockRecorder struct {
+	mock *MockReminderLogRepo
+}
+
+// NewMockReminderLogRepo creates a new mock instance.
+func NewMockReminderLogRepo(ctrl *gomock.Controller) *MockReminderLogRepo {
+	mock := &MockReminderLogRepo{ctrl: ctrl}
+	mock.recorder = &MockReminderLogRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderLogRepo) EXPECT() *MockReminderLogRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateReminderLog mocks base method.
+func (m *MockReminderLogRepo) CreateReminderLog(ctx context.Context, arg *repo.CreateReminderLogParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReminderLog", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReminderLog indicates an expected call of CreateReminderLog.
+func (mr *MockReminderLogRepoMockRecorder) CreateReminderLog(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReminderLog", reflect.TypeOf((*MockReminderLogRepo)(nil).CreateReminderLog), ctx, arg)
+}
+
+// GetUserReminderLogs mocks base method.
+func (m *MockReminderLogRepo) GetUserReminderLogs(ctx context.Context, userID uuid.UUID) ([]*models.ReminderLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserReminderLogs", ctx, userID)
+	ret0, _ := ret[0].([]*models.ReminderLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserReminderLogs indicates an expected call of GetUserReminderLogs.
+func (mr *MockReminderLogRepoMockRecorder) GetUserReminderLogs(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReminderLogs", reflect.TypeOf((*MockReminderLogRepo)(nil).GetUserReminderLogs), ctx, userID)
+}
@@ -58,6 +58,21 @@ func (mr *MockUserRepoMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepo)(nil).CreateUser), ctx, arg)
 }
 
+// GetUserByCalendarTokenHash mocks base method.
+func (m *MockUserRepo) GetUserByCalendarTokenHash(ctx context.Context, tokenHash string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByCalendarTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByCalendarTokenHash indicates an expected call of GetUserByCalendarTokenHash.
+func (mr *MockUserRepoMockRecorder) GetUserByCalendarTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByCalendarTokenHash", reflect.TypeOf((*MockUserRepo)(nil).GetUserByCalendarTokenHash), ctx, tokenHash)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockUserRepo) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -87,3 +102,17 @@ func (mr *MockUserRepoMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserRepo)(nil).GetUserByID), ctx, id)
 }
+
+// UpdateCalendarTokenHash mocks base method.
+func (m *MockUserRepo) UpdateCalendarTokenHash(ctx context.Context, id uuid.UUID, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCalendarTokenHash", ctx, id, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCalendarTokenHash indicates an expected call of UpdateCalendarTokenHash.
+func (mr *MockUserRepoMockRecorder) UpdateCalendarTokenHash(ctx, id, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCalendarTokenHash", reflect.TypeOf((*MockUserRepo)(nil).UpdateCalendarTokenHash), ctx, id, tokenHash)
+}
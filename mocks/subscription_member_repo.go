@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repo/subscription_member_repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repo/subscription_member_repo.go -destination=./mocks/subscription_member_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/sangtandoan/subscription_tracker/internal/models"
+	repo "github.com/sangtandoan/subscription_tracker/internal/repo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptionMemberRepo is a mock of SubscriptionMemberRepo interface.
+type MockSubscriptionMemberRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionMemberRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionMemberRepoMockRecorder is the mock recorder for MockSubscriptionMemberRepo.
+type MockSubscriptionMemberRepoMockRecorder struct {
+	mock *MockSubscriptionMemberRepo
+}
+
+// NewMockSubscriptionMemberRepo creates a new mock instance.
+func NewMockSubscriptionMemberRepo(ctrl *gomock.Controller) *MockSubscriptionMemberRepo {
+	mock := &MockSubscriptionMemberRepo{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionMemberRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionMemberRepo) EXPECT() *MockSubscriptionMemberRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateMember mocks base method.
+func (m *MockSubscriptionMemberRepo) CreateMember(ctx context.Context, arg *repo.CreateMemberParams) (*models.SubscriptionMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMember", ctx, arg)
+	ret0, _ := ret[0].(*models.SubscriptionMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMember indicates an expected call of CreateMember.
+func (mr *MockSubscriptionMemberRepoMockRecorder) CreateMember(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMember", reflect.TypeOf((*MockSubscriptionMemberRepo)(nil).CreateMember), ctx, arg)
+}
+
+// DeleteMember mocks base method.
+func (m *MockSubscriptionMemberRepo) DeleteMember(ctx context.Context, id, subscriptionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", ctx, id, subscriptionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockSubscriptionMemberRepoMockRecorder) DeleteMember(ctx, id, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockSubscriptionMemberRepo)(nil).DeleteMember), ctx, id, subscriptionID)
+}
+
+// GetInvitations mocks base method.
+func (m *MockSubscriptionMemberRepo) GetInvitations(ctx context.Context, userID uuid.UUID) ([]*models.SubscriptionInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitations", ctx, userID)
+	ret0, _ := ret[0].([]*models.SubscriptionInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitations indicates an expected call of GetInvitations.
+func (mr *MockSubscriptionMemberRepoMockRecorder) GetInvitations(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitations", reflect.TypeOf((*MockSubscriptionMemberRepo)(nil).GetInvitations), ctx, userID)
+}
+
+// GetMembers mocks base method.
+func (m *MockSubscriptionMemberRepo) GetMembers(ctx context.Context, subscriptionID uuid.UUID) ([]*models.SubscriptionMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, subscriptionID)
+	ret0, _ := ret[0].([]*models.SubscriptionMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockSubscriptionMemberRepoMockRecorder) GetMembers(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockSubscriptionMemberRepo)(nil).GetMembers), ctx, subscriptionID)
+}
+
+// GetSharedMembers mocks base method.
+func (m *MockSubscriptionMemberRepo) GetSharedMembers(ctx context.Context, userID uuid.UUID) ([]*repo.SharedMemberRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedMembers", ctx, userID)
+	ret0, _ := ret[0].([]*repo.SharedMemberRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedMembers indicates an expected call of GetSharedMembers.
+func (mr *MockSubscriptionMemberRepoMockRecorder) GetSharedMembers(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedMembers", reflect.TypeOf((*MockSubscriptionMemberRepo)(nil).GetSharedMembers), ctx, userID)
+}
+
+// GetUserMemberships mocks base method.
+func (m *MockSubscriptionMemberRepo) GetUserMemberships(ctx context.Context, userID uuid.UUID) ([]*models.SubscriptionMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMemberships", ctx, userID)
+	ret0, _ := ret[0].([]*models.SubscriptionMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMemberships indicates an expected call of GetUserMemberships.
+func (mr *MockSubscriptionMemberRepoMockRecorder) GetUserMemberships(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMemberships", reflect.TypeOf((*MockSubscriptionMemberRepo)(nil).GetUserMemberships), ctx, userID)
+}
+
+// RespondInvitation mocks base method.
+func (m *MockSubscriptionMemberRepo) RespondInvitation(ctx context.Context, id, userID uuid.UUID, status string) (*models.SubscriptionMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondInvitation", ctx, id, userID, status)
+	ret0, _ := ret[0].(*models.SubscriptionMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RespondInvitation indicates an expected call of RespondInvitation.
+func (mr *MockSubscriptionMemberRepoMockRecorder) RespondInvitation(ctx, id, userID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondInvitation", reflect.TypeOf((*MockSubscriptionMemberRepo)(nil).RespondInvitation), ctx, id, userID, status)
+}
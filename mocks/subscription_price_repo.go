@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repo/subscription_price_repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repo/subscription_price_repo.go -destination=./mocks/subscription_price_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	models "github.com/sangtandoan/subscription_tracker/internal/models"
+	repo "github.com/sangtandoan/subscription_tracker/internal/repo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptionPriceRepo is a mock of SubscriptionPriceRepo interface.
+type MockSubscriptionPriceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionPriceRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionPriceRepoMockRecorder is the mock recorder for MockSubscriptionPriceRepo.
+type MockSubscriptionPriceRepoMockRecorder struct {
+	mock *MockSubscriptionPriceRepo
+}
+
+// NewMockSubscriptionPriceRepo creates a new mock instance.
+func NewMockSubscriptionPriceRepo(ctrl *gomock.Controller) *MockSubscriptionPriceRepo {
+	mock := &MockSubscriptionPriceRepo{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionPriceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionPriceRepo) EXPECT() *MockSubscriptionPriceRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateSubscriptionPrice mocks base method.
+func (m *MockSubscriptionPriceRepo) CreateSubscriptionPrice(ctx context.Context, arg *repo.CreateSubscriptionPriceParams) (*models.SubscriptionPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscriptionPrice", ctx, arg)
+	ret0, _ := ret[0].(*models.SubscriptionPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscriptionPrice indicates an expected call of CreateSubscriptionPrice.
+func (mr *MockSubscriptionPriceRepoMockRecorder) CreateSubscriptionPrice(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscriptionPrice", reflect.TypeOf((*MockSubscriptionPriceRepo)(nil).CreateSubscriptionPrice), ctx, arg)
+}
+
+// GetScheduledSubscriptionPrices mocks base method.
+func (m *MockSubscriptionPriceRepo) GetScheduledSubscriptionPrices(ctx context.Context, userID uuid.UUID) ([]*models.SubscriptionPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledSubscriptionPrices", ctx, userID)
+	ret0, _ := ret[0].([]*models.SubscriptionPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledSubscriptionPrices indicates an expected call of GetScheduledSubscriptionPrices.
+func (mr *MockSubscriptionPriceRepoMockRecorder) GetScheduledSubscriptionPrices(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledSubscriptionPrices", reflect.TypeOf((*MockSubscriptionPriceRepo)(nil).GetScheduledSubscriptionPrices), ctx, userID)
+}
+
+// GetSubscriptionPriceAt mocks base method.
+func (m *MockSubscriptionPriceRepo) GetSubscriptionPriceAt(ctx context.Context, subscriptionID uuid.UUID, at time.Time) (*models.SubscriptionPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionPriceAt", ctx, subscriptionID, at)
+	ret0, _ := ret[0].(*models.SubscriptionPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionPriceAt indicates an expected call of GetSubscriptionPriceAt.
+func (mr *MockSubscriptionPriceRepoMockRecorder) GetSubscriptionPriceAt(ctx, subscriptionID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionPriceAt", reflect.TypeOf((*MockSubscriptionPriceRepo)(nil).GetSubscriptionPriceAt), ctx, subscriptionID, at)
+}
+
+// GetSubscriptionPrices mocks base method.
+func (m *MockSubscriptionPriceRepo) GetSubscriptionPrices(ctx context.Context, subscriptionID uuid.UUID) ([]*models.SubscriptionPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionPrices", ctx, subscriptionID)
+	ret0, _ := ret[0].([]*models.SubscriptionPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionPrices indicates an expected call of GetSubscriptionPrices.
+func (mr *MockSubscriptionPriceRepoMockRecorder) GetSubscriptionPrices(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionPrices", reflect.TypeOf((*MockSubscriptionPriceRepo)(nil).GetSubscriptionPrices), ctx, subscriptionID)
+}
+
+// GetUserSubscriptionPrices mocks base method.
+func (m *MockSubscriptionPriceRepo) GetUserSubscriptionPrices(ctx context.Context, userID uuid.UUID) ([]*models.SubscriptionPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSubscriptionPrices", ctx, userID)
+	ret0, _ := ret[0].([]*models.SubscriptionPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSubscriptionPrices indicates an expected call of GetUserSubscriptionPrices.
+func (mr *MockSubscriptionPriceRepoMockRecorder) GetUserSubscriptionPrices(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSubscriptionPrices", reflect.TypeOf((*MockSubscriptionPriceRepo)(nil).GetUserSubscriptionPrices), ctx, userID)
+}
@@ -0,0 +1,357 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repo/subscription_repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repo/subscription_repo.go -destination=./mocks/subscription_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	repo "github.com/sangtandoan/subscription_tracker/internal/repo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptionRepo is a mock of SubscriptionRepo interface.
+type MockSubscriptionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionRepoMockRecorder is the mock recorder for MockSubscriptionRepo.
+type MockSubscriptionRepoMockRecorder struct {
+	mock *MockSubscriptionRepo
+}
+
+// NewMockSubscriptionRepo creates a new mock instance.
+func NewMockSubscriptionRepo(ctrl *gomock.Controller) *MockSubscriptionRepo {
+	mock := &MockSubscriptionRepo{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionRepo) EXPECT() *MockSubscriptionRepoMockRecorder {
+	return m.recorder
+}
+
+// CancelSubscription mocks base method.
+func (m *MockSubscriptionRepo) CancelSubscription(ctx context.Context, arg *repo.CancelSubscriptionParams) (*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSubscription", ctx, arg)
+	ret0, _ := ret[0].(*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSubscription indicates an expected call of CancelSubscription.
+func (mr *MockSubscriptionRepoMockRecorder) CancelSubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSubscription", reflect.TypeOf((*MockSubscriptionRepo)(nil).CancelSubscription), ctx, arg)
+}
+
+// ConvertTrialSubscription mocks base method.
+func (m *MockSubscriptionRepo) ConvertTrialSubscription(ctx context.Context, arg *repo.ConvertTrialSubscriptionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertTrialSubscription", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConvertTrialSubscription indicates an expected call of ConvertTrialSubscription.
+func (mr *MockSubscriptionRepoMockRecorder) ConvertTrialSubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertTrialSubscription", reflect.TypeOf((*MockSubscriptionRepo)(nil).ConvertTrialSubscription), ctx, arg)
+}
+
+// CountUserSubscriptions mocks base method.
+func (m *MockSubscriptionRepo) CountUserSubscriptions(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserSubscriptions", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserSubscriptions indicates an expected call of CountUserSubscriptions.
+func (mr *MockSubscriptionRepoMockRecorder) CountUserSubscriptions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserSubscriptions", reflect.TypeOf((*MockSubscriptionRepo)(nil).CountUserSubscriptions), ctx, userID)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockSubscriptionRepo) CreateSubscription(ctx context.Context, arg repo.CreateSubscriptionParams) (*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, arg)
+	ret0, _ := ret[0].(*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockSubscriptionRepoMockRecorder) CreateSubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockSubscriptionRepo)(nil).CreateSubscription), ctx, arg)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockSubscriptionRepo) DeleteSubscription(ctx context.Context, id, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockSubscriptionRepoMockRecorder) DeleteSubscription(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockSubscriptionRepo)(nil).DeleteSubscription), ctx, id, userID)
+}
+
+// EndCancelledSubscriptions mocks base method.
+func (m *MockSubscriptionRepo) EndCancelledSubscriptions(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndCancelledSubscriptions", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EndCancelledSubscriptions indicates an expected call of EndCancelledSubscriptions.
+func (mr *MockSubscriptionRepoMockRecorder) EndCancelledSubscriptions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndCancelledSubscriptions", reflect.TypeOf((*MockSubscriptionRepo)(nil).EndCancelledSubscriptions), ctx)
+}
+
+// GetAllSubscriptions mocks base method.
+func (m *MockSubscriptionRepo) GetAllSubscriptions(ctx context.Context, arg *repo.GetAllSubscriptionsParams) ([]*repo.SubscriptionRow, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSubscriptions", ctx, arg)
+	ret0, _ := ret[0].([]*repo.SubscriptionRow)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllSubscriptions indicates an expected call of GetAllSubscriptions.
+func (mr *MockSubscriptionRepoMockRecorder) GetAllSubscriptions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSubscriptions", reflect.TypeOf((*MockSubscriptionRepo)(nil).GetAllSubscriptions), ctx, arg)
+}
+
+// GetOwnedOrSharedSubscription mocks base method.
+func (m *MockSubscriptionRepo) GetOwnedOrSharedSubscription(ctx context.Context, id, userID uuid.UUID) (*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnedOrSharedSubscription", ctx, id, userID)
+	ret0, _ := ret[0].(*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnedOrSharedSubscription indicates an expected call of GetOwnedOrSharedSubscription.
+func (mr *MockSubscriptionRepoMockRecorder) GetOwnedOrSharedSubscription(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnedOrSharedSubscription", reflect.TypeOf((*MockSubscriptionRepo)(nil).GetOwnedOrSharedSubscription), ctx, id, userID)
+}
+
+// GetPaymentMethodSubscriptions mocks base method.
+func (m *MockSubscriptionRepo) GetPaymentMethodSubscriptions(ctx context.Context, paymentMethodID uuid.UUID) ([]*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentMethodSubscriptions", ctx, paymentMethodID)
+	ret0, _ := ret[0].([]*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentMethodSubscriptions indicates an expected call of GetPaymentMethodSubscriptions.
+func (mr *MockSubscriptionRepoMockRecorder) GetPaymentMethodSubscriptions(ctx, paymentMethodID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentMethodSubscriptions", reflect.TypeOf((*MockSubscriptionRepo)(nil).GetPaymentMethodSubscriptions), ctx, paymentMethodID)
+}
+
+// GetSubscriptionByID mocks base method.
+func (m *MockSubscriptionRepo) GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionByID", ctx, id)
+	ret0, _ := ret[0].(*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionByID indicates an expected call of GetSubscriptionByID.
+func (mr *MockSubscriptionRepoMockRecorder) GetSubscriptionByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionByID", reflect.TypeOf((*MockSubscriptionRepo)(nil).GetSubscriptionByID), ctx, id)
+}
+
+// GetSubscriptionsBeforeNumDays mocks base method.
+func (m *MockSubscriptionRepo) GetSubscriptionsBeforeNumDays(ctx context.Context, num int) ([]*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionsBeforeNumDays", ctx, num)
+	ret0, _ := ret[0].([]*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionsBeforeNumDays indicates an expected call of GetSubscriptionsBeforeNumDays.
+func (mr *MockSubscriptionRepoMockRecorder) GetSubscriptionsBeforeNumDays(ctx, num any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionsBeforeNumDays", reflect.TypeOf((*MockSubscriptionRepo)(nil).GetSubscriptionsBeforeNumDays), ctx, num)
+}
+
+// GetSubscriptionsNeedUpdateStartAndEndDate mocks base method.
+func (m *MockSubscriptionRepo) GetSubscriptionsNeedUpdateStartAndEndDate(ctx context.Context) ([]*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionsNeedUpdateStartAndEndDate", ctx)
+	ret0, _ := ret[0].([]*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionsNeedUpdateStartAndEndDate indicates an expected call of GetSubscriptionsNeedUpdateStartAndEndDate.
+func (mr *MockSubscriptionRepoMockRecorder) GetSubscriptionsNeedUpdateStartAndEndDate(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionsNeedUpdateStartAndEndDate", reflect.TypeOf((*MockSubscriptionRepo)(nil).GetSubscriptionsNeedUpdateStartAndEndDate), ctx)
+}
+
+// GetUpcomingSubscriptions mocks base method.
+func (m *MockSubscriptionRepo) GetUpcomingSubscriptions(ctx context.Context, userID uuid.UUID, before time.Time) ([]*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpcomingSubscriptions", ctx, userID, before)
+	ret0, _ := ret[0].([]*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpcomingSubscriptions indicates an expected call of GetUpcomingSubscriptions.
+func (mr *MockSubscriptionRepoMockRecorder) GetUpcomingSubscriptions(ctx, userID, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpcomingSubscriptions", reflect.TypeOf((*MockSubscriptionRepo)(nil).GetUpcomingSubscriptions), ctx, userID, before)
+}
+
+// GetUserSubscriptions mocks base method.
+func (m *MockSubscriptionRepo) GetUserSubscriptions(ctx context.Context, userID uuid.UUID) ([]*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSubscriptions", ctx, userID)
+	ret0, _ := ret[0].([]*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSubscriptions indicates an expected call of GetUserSubscriptions.
+func (mr *MockSubscriptionRepoMockRecorder) GetUserSubscriptions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSubscriptions", reflect.TypeOf((*MockSubscriptionRepo)(nil).GetUserSubscriptions), ctx, userID)
+}
+
+// PurgeDeletedSubscriptions mocks base method.
+func (m *MockSubscriptionRepo) PurgeDeletedSubscriptions(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedSubscriptions", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedSubscriptions indicates an expected call of PurgeDeletedSubscriptions.
+func (mr *MockSubscriptionRepoMockRecorder) PurgeDeletedSubscriptions(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedSubscriptions", reflect.TypeOf((*MockSubscriptionRepo)(nil).PurgeDeletedSubscriptions), ctx, before)
+}
+
+// ReactivateSubscription mocks base method.
+func (m *MockSubscriptionRepo) ReactivateSubscription(ctx context.Context, id, userID uuid.UUID) (*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateSubscription", ctx, id, userID)
+	ret0, _ := ret[0].(*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReactivateSubscription indicates an expected call of ReactivateSubscription.
+func (mr *MockSubscriptionRepoMockRecorder) ReactivateSubscription(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateSubscription", reflect.TypeOf((*MockSubscriptionRepo)(nil).ReactivateSubscription), ctx, id, userID)
+}
+
+// RestoreSubscription mocks base method.
+func (m *MockSubscriptionRepo) RestoreSubscription(ctx context.Context, id, userID uuid.UUID) (*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSubscription", ctx, id, userID)
+	ret0, _ := ret[0].(*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreSubscription indicates an expected call of RestoreSubscription.
+func (mr *MockSubscriptionRepoMockRecorder) RestoreSubscription(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSubscription", reflect.TypeOf((*MockSubscriptionRepo)(nil).RestoreSubscription), ctx, id, userID)
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockSubscriptionRepo) UpdateSubscription(ctx context.Context, arg *repo.UpdateSubscriptionParams) (*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", ctx, arg)
+	ret0, _ := ret[0].(*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockSubscriptionRepoMockRecorder) UpdateSubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockSubscriptionRepo)(nil).UpdateSubscription), ctx, arg)
+}
+
+// UpdateSubscriptionCategory mocks base method.
+func (m *MockSubscriptionRepo) UpdateSubscriptionCategory(ctx context.Context, arg *repo.UpdateSubscriptionCategoryParams) (*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscriptionCategory", ctx, arg)
+	ret0, _ := ret[0].(*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscriptionCategory indicates an expected call of UpdateSubscriptionCategory.
+func (mr *MockSubscriptionRepoMockRecorder) UpdateSubscriptionCategory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriptionCategory", reflect.TypeOf((*MockSubscriptionRepo)(nil).UpdateSubscriptionCategory), ctx, arg)
+}
+
+// UpdateSubscriptionPaymentMethod mocks base method.
+func (m *MockSubscriptionRepo) UpdateSubscriptionPaymentMethod(ctx context.Context, arg *repo.UpdateSubscriptionPaymentMethodParams) (*repo.SubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscriptionPaymentMethod", ctx, arg)
+	ret0, _ := ret[0].(*repo.SubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscriptionPaymentMethod indicates an expected call of UpdateSubscriptionPaymentMethod.
+func (mr *MockSubscriptionRepoMockRecorder) UpdateSubscriptionPaymentMethod(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriptionPaymentMethod", reflect.TypeOf((*MockSubscriptionRepo)(nil).UpdateSubscriptionPaymentMethod), ctx, arg)
+}
+
+// UpdateSubscriptionStartAndEndDate mocks base method.
+func (m *MockSubscriptionRepo) UpdateSubscriptionStartAndEndDate(ctx context.Context, arg *repo.UpdateSubscriptionStartAndEndDateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscriptionStartAndEndDate", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubscriptionStartAndEndDate indicates an expected call of UpdateSubscriptionStartAndEndDate.
+func (mr *MockSubscriptionRepoMockRecorder) UpdateSubscriptionStartAndEndDate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriptionStartAndEndDate", reflect.TypeOf((*MockSubscriptionRepo)(nil).UpdateSubscriptionStartAndEndDate), ctx, arg)
+}
@@ -0,0 +1,238 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/subscription_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/service/subscription_service.go -destination=./mocks/subscription_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/sangtandoan/subscription_tracker/internal/models"
+	service "github.com/sangtandoan/subscription_tracker/internal/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptionService is a mock of SubscriptionService interface.
+type MockSubscriptionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionServiceMockRecorder is the mock recorder for MockSubscriptionService.
+type MockSubscriptionServiceMockRecorder struct {
+	mock *MockSubscriptionService
+}
+
+// NewMockSubscriptionService creates a new mock instance.
+func NewMockSubscriptionService(ctrl *gomock.Controller) *MockSubscriptionService {
+	mock := &MockSubscriptionService{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionService) EXPECT() *MockSubscriptionServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelSubscription mocks base method.
+func (m *MockSubscriptionService) CancelSubscription(ctx context.Context, req *service.CancelSubscriptionRequest) (*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSubscription", ctx, req)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSubscription indicates an expected call of CancelSubscription.
+func (mr *MockSubscriptionServiceMockRecorder) CancelSubscription(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSubscription", reflect.TypeOf((*MockSubscriptionService)(nil).CancelSubscription), ctx, req)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockSubscriptionService) CreateSubscription(ctx context.Context, req *service.CreateSubscriptionRequest) (*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, req)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockSubscriptionServiceMockRecorder) CreateSubscription(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockSubscriptionService)(nil).CreateSubscription), ctx, req)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockSubscriptionService) DeleteSubscription(ctx context.Context, id, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockSubscriptionServiceMockRecorder) DeleteSubscription(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockSubscriptionService)(nil).DeleteSubscription), ctx, id, userID)
+}
+
+// GetAllSubscriptions mocks base method.
+func (m *MockSubscriptionService) GetAllSubscriptions(ctx context.Context, req *service.GetAllSubscriptionsRequest) (*service.GetAllSubscriptionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSubscriptions", ctx, req)
+	ret0, _ := ret[0].(*service.GetAllSubscriptionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllSubscriptions indicates an expected call of GetAllSubscriptions.
+func (mr *MockSubscriptionServiceMockRecorder) GetAllSubscriptions(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSubscriptions", reflect.TypeOf((*MockSubscriptionService)(nil).GetAllSubscriptions), ctx, req)
+}
+
+// GetSubscription mocks base method.
+func (m *MockSubscriptionService) GetSubscription(ctx context.Context, id, userID uuid.UUID) (*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockSubscriptionServiceMockRecorder) GetSubscription(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockSubscriptionService)(nil).GetSubscription), ctx, id, userID)
+}
+
+// GetSubscriptionPrices mocks base method.
+func (m *MockSubscriptionService) GetSubscriptionPrices(ctx context.Context, id, userID uuid.UUID) ([]*models.SubscriptionPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionPrices", ctx, id, userID)
+	ret0, _ := ret[0].([]*models.SubscriptionPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionPrices indicates an expected call of GetSubscriptionPrices.
+func (mr *MockSubscriptionServiceMockRecorder) GetSubscriptionPrices(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionPrices", reflect.TypeOf((*MockSubscriptionService)(nil).GetSubscriptionPrices), ctx, id, userID)
+}
+
+// GetSubscriptionsBeforeNumDays mocks base method.
+func (m *MockSubscriptionService) GetSubscriptionsBeforeNumDays(ctx context.Context, num int) ([]*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionsBeforeNumDays", ctx, num)
+	ret0, _ := ret[0].([]*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionsBeforeNumDays indicates an expected call of GetSubscriptionsBeforeNumDays.
+func (mr *MockSubscriptionServiceMockRecorder) GetSubscriptionsBeforeNumDays(ctx, num any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionsBeforeNumDays", reflect.TypeOf((*MockSubscriptionService)(nil).GetSubscriptionsBeforeNumDays), ctx, num)
+}
+
+// GetUpcomingRenewals mocks base method.
+func (m *MockSubscriptionService) GetUpcomingRenewals(ctx context.Context, req *service.GetUpcomingRenewalsRequest) (*service.GetUpcomingRenewalsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpcomingRenewals", ctx, req)
+	ret0, _ := ret[0].(*service.GetUpcomingRenewalsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpcomingRenewals indicates an expected call of GetUpcomingRenewals.
+func (mr *MockSubscriptionServiceMockRecorder) GetUpcomingRenewals(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpcomingRenewals", reflect.TypeOf((*MockSubscriptionService)(nil).GetUpcomingRenewals), ctx, req)
+}
+
+// ReactivateSubscription mocks base method.
+func (m *MockSubscriptionService) ReactivateSubscription(ctx context.Context, id, userID uuid.UUID) (*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateSubscription", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReactivateSubscription indicates an expected call of ReactivateSubscription.
+func (mr *MockSubscriptionServiceMockRecorder) ReactivateSubscription(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateSubscription", reflect.TypeOf((*MockSubscriptionService)(nil).ReactivateSubscription), ctx, id, userID)
+}
+
+// RestoreSubscription mocks base method.
+func (m *MockSubscriptionService) RestoreSubscription(ctx context.Context, id, userID uuid.UUID) (*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSubscription", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreSubscription indicates an expected call of RestoreSubscription.
+func (mr *MockSubscriptionServiceMockRecorder) RestoreSubscription(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSubscription", reflect.TypeOf((*MockSubscriptionService)(nil).RestoreSubscription), ctx, id, userID)
+}
+
+// SetSubscriptionCategory mocks base method.
+func (m *MockSubscriptionService) SetSubscriptionCategory(ctx context.Context, req *service.SetSubscriptionCategoryRequest) (*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSubscriptionCategory", ctx, req)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSubscriptionCategory indicates an expected call of SetSubscriptionCategory.
+func (mr *MockSubscriptionServiceMockRecorder) SetSubscriptionCategory(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubscriptionCategory", reflect.TypeOf((*MockSubscriptionService)(nil).SetSubscriptionCategory), ctx, req)
+}
+
+// SetSubscriptionTags mocks base method.
+func (m *MockSubscriptionService) SetSubscriptionTags(ctx context.Context, req *service.SetSubscriptionTagsRequest) (*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSubscriptionTags", ctx, req)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSubscriptionTags indicates an expected call of SetSubscriptionTags.
+func (mr *MockSubscriptionServiceMockRecorder) SetSubscriptionTags(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubscriptionTags", reflect.TypeOf((*MockSubscriptionService)(nil).SetSubscriptionTags), ctx, req)
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockSubscriptionService) UpdateSubscription(ctx context.Context, req *service.UpdateSubscriptionRequest) (*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", ctx, req)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockSubscriptionServiceMockRecorder) UpdateSubscription(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockSubscriptionService)(nil).UpdateSubscription), ctx, req)
+}
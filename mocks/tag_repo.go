@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repo/tag_repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repo/tag_repo.go -destination=./mocks/tag_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/sangtandoan/subscription_tracker/internal/models"
+	repo "github.com/sangtandoan/subscription_tracker/internal/repo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTagRepo is a mock of TagRepo interface.
+type MockTagRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockTagRepoMockRecorder is the mock recorder for MockTagRepo.
+type MockTagRepoMockRecorder struct {
+	mock *MockTagRepo
+}
+
+// NewMockTagRepo creates a new mock instance.
+func NewMockTagRepo(ctrl *gomock.Controller) *MockTagRepo {
+	mock := &MockTagRepo{ctrl: ctrl}
+	mock.recorder = &MockTagRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRepo) EXPECT() *MockTagRepoMockRecorder {
+	return m.recorder
+}
+
+// CountUserTags mocks base method.
+func (m *MockTagRepo) CountUserTags(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserTags", ctx, userID, ids)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserTags indicates an expected call of CountUserTags.
+func (mr *MockTagRepoMockRecorder) CountUserTags(ctx, userID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserTags", reflect.TypeOf((*MockTagRepo)(nil).CountUserTags), ctx, userID, ids)
+}
+
+// CreateTag mocks base method.
+func (m *MockTagRepo) CreateTag(ctx context.Context, arg *repo.CreateTagParams) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", ctx, arg)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockTagRepoMockRecorder) CreateTag(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockTagRepo)(nil).CreateTag), ctx, arg)
+}
+
+// DeleteTag mocks base method.
+func (m *MockTagRepo) DeleteTag(ctx context.Context, id, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockTagRepoMockRecorder) DeleteTag(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockTagRepo)(nil).DeleteTag), ctx, id, userID)
+}
+
+// GetAllTags mocks base method.
+func (m *MockTagRepo) GetAllTags(ctx context.Context, userID uuid.UUID) ([]*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTags", ctx, userID)
+	ret0, _ := ret[0].([]*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTags indicates an expected call of GetAllTags.
+func (mr *MockTagRepoMockRecorder) GetAllTags(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTags", reflect.TypeOf((*MockTagRepo)(nil).GetAllTags), ctx, userID)
+}
+
+// GetTagByID mocks base method.
+func (m *MockTagRepo) GetTagByID(ctx context.Context, id uuid.UUID) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagByID", ctx, id)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagByID indicates an expected call of GetTagByID.
+func (mr *MockTagRepoMockRecorder) GetTagByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagByID", reflect.TypeOf((*MockTagRepo)(nil).GetTagByID), ctx, id)
+}
+
+// GetTagByName mocks base method.
+func (m *MockTagRepo) GetTagByName(ctx context.Context, userID uuid.UUID, name string) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagByName", ctx, userID, name)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagByName indicates an expected call of GetTagByName.
+func (mr *MockTagRepoMockRecorder) GetTagByName(ctx, userID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagByName", reflect.TypeOf((*MockTagRepo)(nil).GetTagByName), ctx, userID, name)
+}
+
+// SetSubscriptionTags mocks base method.
+func (m *MockTagRepo) SetSubscriptionTags(ctx context.Context, subscriptionID uuid.UUID, tagIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSubscriptionTags", ctx, subscriptionID, tagIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSubscriptionTags indicates an expected call of SetSubscriptionTags.
+func (mr *MockTagRepoMockRecorder) SetSubscriptionTags(ctx, subscriptionID, tagIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubscriptionTags", reflect.TypeOf((*MockTagRepo)(nil).SetSubscriptionTags), ctx, subscriptionID, tagIDs)
+}
+
+// UpdateTag mocks base method.
+func (m *MockTagRepo) UpdateTag(ctx context.Context, arg *repo.UpdateTagParams) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTag", ctx, arg)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTag indicates an expected call of UpdateTag.
+func (mr *MockTagRepoMockRecorder) UpdateTag(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTag", reflect.TypeOf((*MockTagRepo)(nil).UpdateTag), ctx, arg)
+}
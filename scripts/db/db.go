@@ -13,22 +13,15 @@ import (
 	_ "github.com/lib/pq"
 	"github.com/sangtandoan/subscription_tracker/internal/config"
 	"github.com/sangtandoan/subscription_tracker/internal/models"
+	"github.com/sangtandoan/subscription_tracker/internal/pkg/catalog"
 	"github.com/sangtandoan/subscription_tracker/internal/pkg/enums"
 	"github.com/sangtandoan/subscription_tracker/internal/repo"
 )
 
-var names []string = []string{
-	"Spotify Premium",
-	"Netflix Premium",
-	"YouTube Premium",
-	"Apple One",
-	"Notion Plus",
-	"Figma Professional",
-	"GitHub Pro",
-	"Adobe Creative Cloud",
-	"Zoom Pro",
-	"Microsoft 365 Personal",
-}
+// names are taken from the plans of the built-in catalog, e.g. "Spotify Premium Individual"
+var names []string = catalogNames()
+
+var currencies []string = []string{"USD", "EUR", "VND"}
 
 var db *sql.DB
 
@@ -75,6 +68,7 @@ func FillSubscriptions() {
 	endDate := calculateEndDate(startDate, duration)
 	name := randomName()
 	isCancelled := randomBool()
+	amount, currency := randomPrice()
 
 	id, err := uuid.NewUUID()
 	if err != nil {
@@ -84,9 +78,11 @@ func FillSubscriptions() {
 
 	query := `
 		INSERT INTO 
-		subscriptions (id, user_id, name, start_date, end_date, duration, is_cancelled) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, user_id, name, start_date, end_date, duration, is_cancelled
+		subscriptions (id, user_id, name, start_date, end_date, interval_count, interval_unit, is_cancelled,
+			billing_anchor_day, amount, currency) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, user_id, name, start_date, end_date, interval_count, interval_unit, is_cancelled,
+			billing_anchor_day
 	`
 	userID := "676ead13-20d9-11f0-95a9-902e1685779a"
 	userIDUUID, _ := uuid.Parse(userID)
@@ -99,8 +95,12 @@ func FillSubscriptions() {
 		name,
 		time.Time(startDate),
 		endDate,
-		duration.String(),
+		duration.Count,
+		duration.Unit,
 		isCancelled,
+		time.Time(startDate).Day(),
+		amount,
+		currency,
 	)
 
 	var subcription repo.SubscriptionRow
@@ -110,8 +110,10 @@ func FillSubscriptions() {
 		&subcription.Name,
 		&subcription.StartDate,
 		&subcription.EndDate,
-		&subcription.Duration,
+		&subcription.Duration.Count,
+		&subcription.Duration.Unit,
 		&subcription.IsCancelled,
+		&subcription.BillingAnchorDay,
 	)
 	if err != nil {
 		fmt.Printf("Error inserting subscription: %v\n", err)
@@ -138,6 +140,34 @@ func randomDuration() enums.Duration {
 	return d
 }
 
+// randomPrice leaves some subscriptions without a price like the ones created before prices were tracked
+func randomPrice() (*int64, *string) {
+	if rand.Intn(10) == 0 {
+		return nil, nil
+	}
+
+	amount := int64(99 + rand.Intn(5000))
+	currency := currencies[rand.Intn(len(currencies))]
+
+	return &amount, &currency
+}
+
+func catalogNames() []string {
+	services, err := catalog.Load()
+	if err != nil {
+		panic(err)
+	}
+
+	var names []string
+	for _, service := range services {
+		for _, plan := range service.Plans {
+			names = append(names, service.Name+" "+plan.Name)
+		}
+	}
+
+	return names
+}
+
 func randomName() string {
 	return names[rand.Intn(len(names))]
 }